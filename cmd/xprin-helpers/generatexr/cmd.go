@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package generatexr implements the command for generating XRs (Composite Resources) from the schema of a Crossplane
+// XRD (CompositeResourceDefinition).
+package generatexr
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/alecthomas/kong"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	commonIO "github.com/crossplane/crossplane/v2/cmd/crank/beta/convert/io"
+)
+
+// Cmd arguments and flags for generating XRs from the schema of a Crossplane XRD (CompositeResourceDefinition).
+type Cmd struct {
+	// Flags.
+	XRD        string   `help:"The XRD YAML file to generate the XRs from. If '-', stdin will be used."                                   placeholder:"PATH"                                                                                         predictor:"file"        required:"" type:"path"`
+	Version    string   `help:"The version of the XRD to generate the XRs for. If not specified, the referenceable version will be used." placeholder:"VERSION"`
+	Name       string   `default:"example"                                                                                                help:"The name of the XRs, suffixed with their variant."                                                   placeholder:"NAME"`
+	Namespace  string   `default:"default"                                                                                                help:"The namespace of the XRs of namespaced XRDs."                                                        placeholder:"NAMESPACE"`
+	Variants   []string `enum:"minimal,maximal,boundary"                                                                                  help:"The kinds of variants to generate: minimal, maximal and/or boundary. If not specified, all of them." placeholder:"KIND,..."`
+	OutputFile string   `help:"The file to write the generated XRs YAML to. If not specified, stdout will be used."                       placeholder:"PATH"                                                                                         predictor:"file"        short:"o"   type:"path"`
+
+	fs afero.Fs
+}
+
+// Help returns help message for the generate-xr command.
+func (c *Cmd) Help() string {
+	return `
+Generate Composite Resources (XRs) from the schema of a CompositeResourceDefinition (XRD).
+
+This command will:
+- Read the XRD from the provided YAML file
+- Generate a minimal XR that sets only the required spec fields
+- Generate a maximal XR that sets every spec field
+- Generate boundary XRs that set the required spec fields plus one field at a boundary of its schema:
+  every enum value, the minimum and maximum of numbers, the minimum and maximum length of strings,
+  the shortest and a long match of patterns, and the minimum and maximum number of items of arrays
+
+Values respect the types, formats, enums, bounds and patterns of the schema and use its defaults.
+CEL validation rules (x-kubernetes-validations) are not taken into account.
+
+Examples:
+
+  # Generate all the variants of xrd.yaml and write them to stdout
+  xprin-helpers generate-xr --xrd xrd.yaml
+
+  # Generate the minimal and maximal XRs and write them to xrs.yaml
+  xprin-helpers generate-xr --xrd xrd.yaml --variants minimal,maximal -o xrs.yaml
+
+  # Generate the variants of version v1beta1 of an XRD from stdin
+  cat xrd.yaml | xprin-helpers generate-xr --xrd - --version v1beta1
+`
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run runs the generate-xr command.
+func (c *Cmd) Run(k *kong.Context) error {
+	xrdData, err := commonIO.Read(c.fs, c.XRD)
+	if err != nil {
+		return err
+	}
+
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	if err := yaml.Unmarshal(xrdData, xrd); err != nil {
+		return errors.Wrap(err, "Unmarshalling Error")
+	}
+
+	variants, err := Generate(xrd, Options{Version: c.Version, Name: c.Name, Namespace: c.Namespace, Kinds: c.Variants})
+	if err != nil {
+		return errors.Wrap(err, "failed to generate XRs from XRD")
+	}
+
+	b, err := Marshal(variants)
+	if err != nil {
+		return err
+	}
+
+	output := k.Stdout
+
+	if outputFileName := c.OutputFile; outputFileName != "" {
+		f, err := c.fs.OpenFile(outputFileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return errors.Wrap(err, "Unable to open output file")
+		}
+
+		defer func() { _ = f.Close() }()
+
+		output = f
+	}
+
+	outputW := bufio.NewWriter(output)
+	if _, err := outputW.Write(b); err != nil {
+		return errors.Wrap(err, "Writing YAML file content")
+	}
+
+	if err := outputW.Flush(); err != nil {
+		return errors.Wrap(err, "Flushing output")
+	}
+
+	return nil
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatexr
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+const (
+	// randomRange is the range of the random integers and numbers of fields without minimum or maximum.
+	randomRange = 100
+
+	// randomExtraItems is how many items more than their minimum random arrays have at most.
+	randomExtraItems = 3
+
+	// randomExtraLength is how many characters more than their minimum length random strings have at most.
+	randomExtraLength = 12
+
+	// randomKeys is how many keys random maps have at most.
+	randomKeys = 2
+
+	// randomChars are the characters of random strings.
+	randomChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// Fuzzer generates XRs at random from the schema of an XRD and shrinks them. The XRs it generates only depend on
+// the XRD, the options and the seed, so that runs can be reproduced.
+type Fuzzer struct {
+	xrd     *apiextensionsv1.CompositeResourceDefinition
+	version string
+	spec    *extv1.JSONSchemaProps
+	o       Options
+	rnd     *rand.Rand
+}
+
+// NewFuzzer returns a Fuzzer of the schema of an XRD, seeded with the given seed. The kinds of the options are
+// ignored.
+func NewFuzzer(xrd *apiextensionsv1.CompositeResourceDefinition, o Options, seed int64) (*Fuzzer, error) {
+	if xrd == nil {
+		return nil, errors.New(errNilInput)
+	}
+
+	version, err := selectVersion(xrd, o.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := specSchema(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fuzzer{
+		xrd:     xrd,
+		version: version.Name,
+		spec:    spec,
+		o:       o,
+		rnd:     rand.New(rand.NewPCG(uint64(seed), uint64(seed))), //nolint:gosec // reproducible, not secure, randomness
+	}, nil
+}
+
+// Next returns the next random XR, named after the run. Required fields are always set and optional fields half of
+// the time, to values that respect the types, formats, enums, bounds and patterns of the schema; CEL validation rules
+// are not taken into account.
+func (f *Fuzzer) Next(run int) *unstructured.Unstructured {
+	return newXR(f.xrd, f.version, f.o, fmt.Sprintf("fuzz-%d", run), f.value(f.spec))
+}
+
+// Shrink returns the XRs that are one step simpler than an XR and still respect the schema: without one of its
+// optional fields, map keys or array items above their minimum, or with one of its values replaced by the value of
+// the minimal variant. Shrinking an XR repeatedly ends with an XR without simpler XRs.
+func (f *Fuzzer) Shrink(xr *unstructured.Unstructured) []*unstructured.Unstructured {
+	spec, ok := xr.Object["spec"]
+	if !ok {
+		return nil
+	}
+
+	candidates := shrinkValue(f.spec, spec)
+
+	xrs := make([]*unstructured.Unstructured, 0, len(candidates))
+	for _, candidate := range candidates {
+		shrunk := xr.DeepCopy()
+		shrunk.Object["spec"] = candidate
+		xrs = append(xrs, shrunk)
+	}
+
+	return xrs
+}
+
+// value returns a random value of a schema.
+func (f *Fuzzer) value(s *extv1.JSONSchemaProps) any {
+	if len(s.Enum) > 0 {
+		return mustDecodeJSON(s.Enum[f.rnd.IntN(len(s.Enum))].Raw)
+	}
+
+	switch schemaType(s) {
+	case "object":
+		return f.objectValue(s)
+	case "array":
+		return f.arrayValue(s)
+	case "integer":
+		return f.integerValue(s)
+	case "number":
+		return f.numberValue(s)
+	case "boolean":
+		return f.rnd.IntN(2) == 0
+	default:
+		return f.stringValue(s)
+	}
+}
+
+func (f *Fuzzer) objectValue(s *extv1.JSONSchemaProps) map[string]any {
+	obj := map[string]any{}
+
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+
+		if slices.Contains(s.Required, name) || f.rnd.IntN(2) == 0 {
+			obj[name] = f.value(&prop)
+		}
+	}
+
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		for i := range f.rnd.IntN(randomKeys + 1) {
+			obj[fmt.Sprintf("key-%d", i)] = f.value(s.AdditionalProperties.Schema)
+		}
+	}
+
+	return obj
+}
+
+func (f *Fuzzer) arrayValue(s *extv1.JSONSchemaProps) []any {
+	lower := 0
+	if s.MinItems != nil {
+		lower = int(*s.MinItems)
+	}
+
+	upper := lower + randomExtraItems
+	if s.MaxItems != nil && int(*s.MaxItems) < upper {
+		upper = int(*s.MaxItems)
+	}
+
+	count := lower
+	if upper > lower {
+		count += f.rnd.IntN(upper - lower + 1)
+	}
+
+	list := make([]any, 0, count)
+
+	for i := range count {
+		if s.Items == nil || s.Items.Schema == nil {
+			list = append(list, fmt.Sprintf("item-%d", i))
+			continue
+		}
+
+		list = append(list, f.value(s.Items.Schema))
+	}
+
+	return uniqueItems(s, list)
+}
+
+func (f *Fuzzer) integerValue(s *extv1.JSONSchemaProps) int64 {
+	lower, hasLower := integerMinimum(s)
+	upper, hasUpper := integerMaximum(s)
+
+	switch {
+	case !hasLower && hasUpper && upper < 0:
+		lower = upper - randomRange
+	case !hasLower:
+		lower = 0
+	}
+
+	if !hasUpper {
+		upper = lower + randomRange
+	}
+
+	step := int64(1)
+	if s.MultipleOf != nil && *s.MultipleOf >= 1 {
+		step = int64(*s.MultipleOf)
+		lower = ceilDiv(lower, step) * step
+	}
+
+	if upper < lower {
+		return lower
+	}
+
+	return lower + step*f.rnd.Int64N((upper-lower)/step+1)
+}
+
+func (f *Fuzzer) numberValue(s *extv1.JSONSchemaProps) float64 {
+	if s.MultipleOf != nil {
+		return numberValue(s, 0)
+	}
+
+	lower, hasLower := numberMinimum(s)
+	upper, hasUpper := numberMaximum(s)
+
+	switch {
+	case !hasLower && hasUpper && upper < 0:
+		lower = upper - randomRange
+	case !hasLower:
+		lower = 0
+	}
+
+	if !hasUpper {
+		upper = lower + randomRange
+	}
+
+	// Two decimals keep the values readable in fixtures
+	v := math.Round((lower+f.rnd.Float64()*(upper-lower))*100) / 100
+	if v < lower || v > upper {
+		return lower
+	}
+
+	return v
+}
+
+func (f *Fuzzer) stringValue(s *extv1.JSONSchemaProps) string {
+	if v, ok := formatValues[s.Format]; ok {
+		return v
+	}
+
+	if s.Pattern != "" {
+		if v, err := matchPattern(s.Pattern, randomChooser{rnd: f.rnd}); err == nil {
+			return v
+		}
+
+		return stringValue(s, 0)
+	}
+
+	lower := 0
+	if s.MinLength != nil {
+		lower = int(*s.MinLength)
+	}
+
+	upper := lower + randomExtraLength
+	if s.MaxLength != nil && int(*s.MaxLength) < upper {
+		upper = int(*s.MaxLength)
+	}
+
+	length := lower
+	if upper > lower {
+		length += f.rnd.IntN(upper - lower + 1)
+	}
+
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = randomChars[f.rnd.IntN(len(randomChars))]
+	}
+
+	return string(b)
+}
+
+// randomChooser chooses random alternatives, repetitions and characters, preferring printable ASCII characters.
+type randomChooser struct {
+	rnd *rand.Rand
+}
+
+func (c randomChooser) alternative(count int) int { return c.rnd.IntN(count) }
+
+func (c randomChooser) repeats(minimum, maximum int) int {
+	if maximum < 0 {
+		maximum = minimum + unboundedRepeats
+	}
+
+	return minimum + c.rnd.IntN(maximum-minimum+1)
+}
+
+func (c randomChooser) char(ranges []rune) rune {
+	var printable []rune
+
+	for r := '!'; r <= '~'; r++ {
+		if inRanges(ranges, r) {
+			printable = append(printable, r)
+		}
+	}
+
+	if len(printable) == 0 {
+		return ranges[0]
+	}
+
+	return printable[c.rnd.IntN(len(printable))]
+}
+
+// uniqueItems removes the items of set and map lists that repeat an earlier item, or the keys of an earlier item.
+func uniqueItems(s *extv1.JSONSchemaProps, list []any) []any {
+	if s.XListType == nil || (*s.XListType != "set" && *s.XListType != "map") {
+		return list
+	}
+
+	seen := map[string]bool{}
+	unique := make([]any, 0, len(list))
+
+	for _, item := range list {
+		key := item
+		if *s.XListType == "map" {
+			obj, _ := item.(map[string]any)
+
+			keys := make([]any, 0, len(s.XListMapKeys))
+			for _, name := range s.XListMapKeys {
+				keys = append(keys, obj[name])
+			}
+
+			key = keys
+		}
+
+		b, _ := json.Marshal(key)
+		if seen[string(b)] {
+			continue
+		}
+
+		seen[string(b)] = true
+		unique = append(unique, item)
+	}
+
+	return unique
+}
+
+// shrinkValue returns the values that are one step simpler than a value of a schema.
+func shrinkValue(s *extv1.JSONSchemaProps, v any) []any {
+	switch v := v.(type) {
+	case map[string]any:
+		return shrinkObject(s, v)
+	case []any:
+		return shrinkArray(s, v)
+	}
+
+	minimal := (&generator{}).value(s, "", 0)
+	if reflect.DeepEqual(minimal, v) {
+		return nil
+	}
+
+	return []any{minimal}
+}
+
+// shrinkObject returns the objects without one of the optional fields or map keys of an object, then those with one
+// of their values shrunk.
+func shrinkObject(s *extv1.JSONSchemaProps, obj map[string]any) []any {
+	var result []any
+
+	keys := sortedKeys(obj)
+
+	for _, key := range keys {
+		if _, known := s.Properties[key]; known && slices.Contains(s.Required, key) {
+			continue
+		}
+
+		shrunk := maps.Clone(obj)
+		delete(shrunk, key)
+		result = append(result, shrunk)
+	}
+
+	for _, key := range keys {
+		var fieldSchema *extv1.JSONSchemaProps
+
+		if prop, ok := s.Properties[key]; ok {
+			fieldSchema = &prop
+		} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			fieldSchema = s.AdditionalProperties.Schema
+		}
+
+		if fieldSchema == nil {
+			continue
+		}
+
+		for _, value := range shrinkValue(fieldSchema, obj[key]) {
+			shrunk := maps.Clone(obj)
+			shrunk[key] = value
+			result = append(result, shrunk)
+		}
+	}
+
+	return result
+}
+
+// shrinkArray returns the arrays without one of the items of an array above its minimum number of items, then those
+// with one of their items shrunk.
+func shrinkArray(s *extv1.JSONSchemaProps, list []any) []any {
+	var result []any
+
+	if s.MinItems == nil || int64(len(list)) > *s.MinItems {
+		for i := range list {
+			result = append(result, slices.Delete(slices.Clone(list), i, i+1))
+		}
+	}
+
+	if s.Items == nil || s.Items.Schema == nil {
+		return result
+	}
+
+	for i, item := range list {
+		for _, value := range shrinkValue(s.Items.Schema, item) {
+			shrunk := slices.Clone(list)
+			shrunk[i] = value
+
+			// Shrunk items of set and map lists must not repeat other items
+			if len(uniqueItems(s, shrunk)) == len(shrunk) {
+				result = append(result, shrunk)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatexr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/xrdtocrd"
+	"github.com/crossplane-contrib/xprin/internal/validator"
+)
+
+func TestNewFuzzer(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		xrd    *apiextensionsv1.CompositeResourceDefinition
+		opts   Options
+		want   error
+	}{
+		"NilXRD": {
+			reason: "Should return error when XRD is nil",
+			want:   errors.New(errNilInput),
+		},
+		"UnknownVersion": {
+			reason: "Should return error when the XRD has no such version",
+			xrd:    generateTestXRD(t),
+			opts:   Options{Version: "v2"},
+			want:   errors.Errorf(errNoVersion, "v2"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewFuzzer(tc.xrd, tc.opts, 1)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewFuzzer(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFuzzerNext(t *testing.T) {
+	xrd := generateTestXRD(t)
+
+	crds, err := xrdtocrd.ForXRD(xrd)
+	if err != nil {
+		t.Fatalf("xrdtocrd.ForXRD(...): unexpected error: %v", err)
+	}
+
+	v, err := validator.New(crds)
+	if err != nil {
+		t.Fatalf("validator.New(...): unexpected error: %v", err)
+	}
+
+	generate := func(seed int64) []*unstructured.Unstructured {
+		f, err := NewFuzzer(xrd, Options{}, seed)
+		if err != nil {
+			t.Fatalf("NewFuzzer(...): unexpected error: %v", err)
+		}
+
+		xrs := make([]*unstructured.Unstructured, 0, 50)
+		for run := 1; run <= 50; run++ {
+			xrs = append(xrs, f.Next(run))
+		}
+
+		return xrs
+	}
+
+	xrs := generate(42)
+
+	if diff := cmp.Diff(xrs, generate(42)); diff != "" {
+		t.Errorf("\nShould generate the same XRs from the same seed\nNext(...): -first, +second:\n%s", diff)
+	}
+
+	if diff := cmp.Diff(xrs, generate(43)); diff == "" {
+		t.Errorf("\nShould generate other XRs from another seed\nNext(...): got the same XRs")
+	}
+
+	if got := xrs[2].GetName(); got != "example-fuzz-3" {
+		t.Errorf("\nShould name the XRs after their run\nNext(...): got name %q", got)
+	}
+
+	for _, xr := range xrs {
+		for _, result := range v.Validate(context.Background(), []*unstructured.Unstructured{xr}) {
+			if len(result.Errors) > 0 || result.MissingSchema {
+				t.Errorf("\nEvery random XR should be valid against the XRD\nXR %q: %+v", xr.GetName(), result)
+			}
+		}
+	}
+}
+
+func TestFuzzerShrink(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   map[string]any
+		want   []any
+	}{
+		"Minimal": {
+			reason: "Should not shrink an XR with only the minimal values of its required fields",
+			spec:   map[string]any{"name": "a", "region": "eu-west-1"},
+		},
+		"OptionalFieldsAndValues": {
+			reason: "Should remove optional fields first, then replace values by their minimal value",
+			spec:   map[string]any{"name": "bucket", "region": "us-east-1", "size": int64(42)},
+			want: []any{
+				map[string]any{"name": "bucket", "region": "us-east-1"},
+				map[string]any{"name": "a", "region": "us-east-1", "size": int64(42)},
+				map[string]any{"name": "bucket", "region": "eu-west-1", "size": int64(42)},
+				map[string]any{"name": "bucket", "region": "us-east-1", "size": int64(10)},
+			},
+		},
+		"ArraysAndMaps": {
+			reason: "Should remove map keys and array items, then shrink the items",
+			spec: map[string]any{
+				"name":   "a",
+				"region": "eu-west-1",
+				"rules":  []any{map[string]any{"days": int64(7)}},
+				"tags":   map[string]any{"team": "x"},
+			},
+			want: []any{
+				map[string]any{"name": "a", "region": "eu-west-1", "tags": map[string]any{"team": "x"}},
+				map[string]any{"name": "a", "region": "eu-west-1", "rules": []any{map[string]any{"days": int64(7)}}},
+				map[string]any{"name": "a", "region": "eu-west-1", "rules": []any{}, "tags": map[string]any{"team": "x"}},
+				map[string]any{"name": "a", "region": "eu-west-1", "rules": []any{map[string]any{"days": int64(1)}}, "tags": map[string]any{"team": "x"}},
+				map[string]any{"name": "a", "region": "eu-west-1", "rules": []any{map[string]any{"days": int64(7)}}, "tags": map[string]any{}},
+				map[string]any{"name": "a", "region": "eu-west-1", "rules": []any{map[string]any{"days": int64(7)}}, "tags": map[string]any{"team": "value"}},
+			},
+		},
+	}
+
+	f, err := NewFuzzer(generateTestXRD(t), Options{}, 1)
+	if err != nil {
+		t.Fatalf("NewFuzzer(...): unexpected error: %v", err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr := &unstructured.Unstructured{Object: map[string]any{"kind": "XBucket", "spec": tc.spec}}
+
+			var got []any
+			for _, shrunk := range f.Shrink(xr) {
+				got = append(got, shrunk.Object["spec"])
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nShrink(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,695 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatexr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+const (
+	// Error messages.
+	errNilInput        = "input is nil"
+	errNoVersion       = "XRD has no version %s"
+	errNoVersions      = "XRD has no versions"
+	errParseSchema     = "failed to parse the schema of version %s"
+	errUnknownKind     = "unknown variant kind %q (valid kinds: minimal, maximal, boundary)"
+	errFmtMarshalXR    = "failed to marshal variant %s"
+	errFmtParseDefault = "failed to parse the default or enum values of %s"
+
+	// DefaultName is the name of the generated XRs when none is given.
+	DefaultName = "example"
+
+	// defaultNamespace is the namespace of the generated XRs of namespaced XRDs when none is given.
+	defaultNamespace = "default"
+
+	// maxNameLength is the maximum length of the name of a generated XR.
+	maxNameLength = 63
+
+	// unboundedRepeats is how many times the longest match of a pattern repeats an unbounded repetition.
+	unboundedRepeats = 3
+)
+
+// Kinds of variants.
+const (
+	KindMinimal  = "minimal"  // Only the required spec fields are set
+	KindMaximal  = "maximal"  // Every spec field is set
+	KindBoundary = "boundary" // The required spec fields plus one field at a boundary of its schema
+)
+
+// Kinds are all the kinds of variants, in the order they are generated.
+var Kinds = []string{KindMinimal, KindMaximal, KindBoundary} //nolint:gochecknoglobals // list of constants
+
+// Options configure the generated variants.
+type Options struct {
+	Version   string   // Version of the XRD the XRs are generated for; the referenceable version when empty
+	Name      string   // Name of the XRs, suffixed with the variant; DefaultName when empty
+	Namespace string   // Namespace of the XRs of namespaced XRDs; "default" when empty
+	Kinds     []string // Kinds of variants to generate; all of them when empty
+}
+
+// Variant is a generated XR.
+type Variant struct {
+	Name string // Name of the variant, e.g. "minimal" or "spec.size maximum"
+	Kind string // Kind of the variant: KindMinimal, KindMaximal or KindBoundary
+	XR   *unstructured.Unstructured
+}
+
+// boundary is a value of a field at a boundary of its schema.
+type boundary struct {
+	path  string // Path of the field, with the items of arrays as [*]
+	name  string // Name of the boundary, e.g. "minimum"
+	value func(s *extv1.JSONSchemaProps) any
+}
+
+// generator generates the values of a schema. The fields on the path of the target are always set, to the value of
+// the target at the target itself.
+type generator struct {
+	all    bool // Set every field, not only the required ones
+	target *boundary
+}
+
+// Generate returns XRs of the kinds of the options generated from the schema of an XRD: a minimal XR that sets only
+// the required spec fields, a maximal XR that sets every spec field, and one boundary XR per enum value, minimum,
+// maximum, length and item count bound and pattern of the spec fields. Values respect the types, formats, enums,
+// bounds and patterns of the schema and use its defaults; CEL validation rules are not taken into account.
+func Generate(xrd *apiextensionsv1.CompositeResourceDefinition, o Options) ([]Variant, error) {
+	if xrd == nil {
+		return nil, errors.New(errNilInput)
+	}
+
+	for _, kind := range o.Kinds {
+		if !slices.Contains(Kinds, kind) {
+			return nil, errors.Errorf(errUnknownKind, kind)
+		}
+	}
+
+	version, err := selectVersion(xrd, o.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := specSchema(version)
+	if err != nil {
+		return nil, err
+	}
+
+	xrOf := func(variant string, spec any) *unstructured.Unstructured {
+		return newXR(xrd, version.Name, o, variant, spec)
+	}
+
+	var variants []Variant
+
+	if wants(o.Kinds, KindMinimal) {
+		variants = append(variants, Variant{Name: KindMinimal, Kind: KindMinimal, XR: xrOf(KindMinimal, (&generator{}).value(spec, "spec", 0))})
+	}
+
+	if wants(o.Kinds, KindMaximal) {
+		variants = append(variants, Variant{Name: KindMaximal, Kind: KindMaximal, XR: xrOf(KindMaximal, (&generator{all: true}).value(spec, "spec", 0))})
+	}
+
+	if wants(o.Kinds, KindBoundary) {
+		for _, b := range boundaries(spec, "spec") {
+			name := b.path + " " + b.name
+			g := &generator{target: &b}
+			variants = append(variants, Variant{Name: name, Kind: KindBoundary, XR: xrOf(name, g.value(spec, "spec", 0))})
+		}
+	}
+
+	return variants, nil
+}
+
+// Marshal returns the variants as a multi-document YAML, each preceded by a comment with the name of the variant.
+func Marshal(variants []Variant) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, v := range variants {
+		b, err := yaml.Marshal(v.XR.Object)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtMarshalXR, v.Name)
+		}
+
+		fmt.Fprintf(&buf, "---\n# Variant: %s\n", v.Name)
+		buf.Write(b)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// newXR returns an XR of a version of an XRD with the given spec, named after the variant.
+func newXR(xrd *apiextensionsv1.CompositeResourceDefinition, version string, o Options, variant string, spec any) *unstructured.Unstructured {
+	xr := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	xr.SetAPIVersion(xrd.Spec.Group + "/" + version)
+	xr.SetKind(xrd.Spec.Names.Kind)
+	xr.SetName(variantName(o.Name, variant))
+
+	if xrd.Spec.Scope != nil && *xrd.Spec.Scope == apiextensionsv1.CompositeResourceScopeNamespaced {
+		xr.SetNamespace(o.Namespace)
+
+		if o.Namespace == "" {
+			xr.SetNamespace(defaultNamespace)
+		}
+	}
+
+	return xr
+}
+
+func wants(kinds []string, kind string) bool {
+	return len(kinds) == 0 || slices.Contains(kinds, kind)
+}
+
+// selectVersion returns the version of an XRD with the given name, or its referenceable version (the first served
+// version when none is referenceable) when the name is empty.
+func selectVersion(xrd *apiextensionsv1.CompositeResourceDefinition, name string) (*apiextensionsv1.CompositeResourceDefinitionVersion, error) {
+	if len(xrd.Spec.Versions) == 0 {
+		return nil, errors.New(errNoVersions)
+	}
+
+	if name != "" {
+		for i := range xrd.Spec.Versions {
+			if xrd.Spec.Versions[i].Name == name {
+				return &xrd.Spec.Versions[i], nil
+			}
+		}
+
+		return nil, errors.Errorf(errNoVersion, name)
+	}
+
+	for i := range xrd.Spec.Versions {
+		if xrd.Spec.Versions[i].Referenceable {
+			return &xrd.Spec.Versions[i], nil
+		}
+	}
+
+	for i := range xrd.Spec.Versions {
+		if xrd.Spec.Versions[i].Served {
+			return &xrd.Spec.Versions[i], nil
+		}
+	}
+
+	return &xrd.Spec.Versions[0], nil
+}
+
+// specSchema returns the schema of the spec of an XRD version, after checking that its defaults and enum values decode.
+func specSchema(version *apiextensionsv1.CompositeResourceDefinitionVersion) (*extv1.JSONSchemaProps, error) {
+	if version.Schema == nil || len(version.Schema.OpenAPIV3Schema.Raw) == 0 {
+		return &extv1.JSONSchemaProps{Type: "object"}, nil
+	}
+
+	s := &extv1.JSONSchemaProps{}
+	if err := json.Unmarshal(version.Schema.OpenAPIV3Schema.Raw, s); err != nil {
+		return nil, errors.Wrapf(err, errParseSchema, version.Name)
+	}
+
+	spec, ok := s.Properties["spec"]
+	if !ok {
+		return &extv1.JSONSchemaProps{Type: "object"}, nil
+	}
+
+	if err := checkJSONValues(&spec, "spec"); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// checkJSONValues checks that the defaults and enum values of a schema can be decoded.
+func checkJSONValues(s *extv1.JSONSchemaProps, path string) error {
+	if s.Default != nil {
+		if _, err := decodeJSON(s.Default.Raw); err != nil {
+			return errors.Wrapf(err, errFmtParseDefault, path)
+		}
+	}
+
+	for _, e := range s.Enum {
+		if _, err := decodeJSON(e.Raw); err != nil {
+			return errors.Wrapf(err, errFmtParseDefault, path)
+		}
+	}
+
+	for name, prop := range s.Properties {
+		if err := checkJSONValues(&prop, path+"."+name); err != nil {
+			return err
+		}
+	}
+
+	if s.Items != nil && s.Items.Schema != nil {
+		return checkJSONValues(s.Items.Schema, path+"[*]")
+	}
+
+	return nil
+}
+
+// decodeJSON decodes a JSON value with whole numbers as int64, like the values of unstructured objects.
+func decodeJSON(raw []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return normalizeNumbers(v), nil
+}
+
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+
+		return f
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+	}
+
+	return v
+}
+
+// mustDecodeJSON decodes a JSON value checked by checkJSONValues.
+func mustDecodeJSON(raw []byte) any {
+	v, _ := decodeJSON(raw)
+	return v
+}
+
+// variantName returns the name of the XR of a variant: the name suffixed with the variant, as a DNS label.
+func variantName(name, variant string) string {
+	if name == "" {
+		name = DefaultName
+	}
+
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(name + "-" + variant) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		case !strings.HasSuffix(b.String(), "-"):
+			b.WriteRune('-')
+		}
+	}
+
+	result := b.String()
+	if len(result) > maxNameLength {
+		result = result[:maxNameLength]
+	}
+
+	return strings.Trim(result, "-")
+}
+
+// onTargetPath reports whether the field at path is the target or one of its parents.
+func (g *generator) onTargetPath(path string) bool {
+	if g.target == nil {
+		return false
+	}
+
+	return g.target.path == path || strings.HasPrefix(g.target.path, path+".") || strings.HasPrefix(g.target.path, path+"[*]")
+}
+
+// value returns a value of a schema. The index varies the values of the items of arrays, so that they are distinct.
+func (g *generator) value(s *extv1.JSONSchemaProps, path string, index int) any {
+	if g.target != nil && g.target.path == path {
+		return g.target.value(s)
+	}
+
+	if !g.onTargetPath(path) {
+		if s.Default != nil {
+			return mustDecodeJSON(s.Default.Raw)
+		}
+
+		if len(s.Enum) > 0 {
+			return mustDecodeJSON(s.Enum[index%len(s.Enum)].Raw)
+		}
+	}
+
+	switch schemaType(s) {
+	case "object":
+		return g.objectValue(s, path, index)
+	case "array":
+		return g.arrayValue(s, path, index)
+	case "integer":
+		return integerValue(s, index)
+	case "number":
+		return numberValue(s, index)
+	case "boolean":
+		return index%2 == 0
+	default:
+		return stringValue(s, index)
+	}
+}
+
+// schemaType returns the type of a schema, inferred from its other fields when it has none.
+func schemaType(s *extv1.JSONSchemaProps) string {
+	switch {
+	case s.Type != "":
+		return s.Type
+	case s.XIntOrString:
+		return "integer"
+	case len(s.Properties) > 0 || s.AdditionalProperties != nil || s.XPreserveUnknownFields != nil:
+		return "object"
+	case s.Items != nil:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+func (g *generator) objectValue(s *extv1.JSONSchemaProps, path string, index int) map[string]any {
+	obj := map[string]any{}
+
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+		propPath := path + "." + name
+
+		if g.all || slices.Contains(s.Required, name) || g.onTargetPath(propPath) {
+			obj[name] = g.value(&prop, propPath, index)
+		}
+	}
+
+	if g.all && s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		obj["key"] = g.value(s.AdditionalProperties.Schema, path+".key", index)
+	}
+
+	return obj
+}
+
+func (g *generator) arrayValue(s *extv1.JSONSchemaProps, path string, index int) []any {
+	count := 0
+	if s.MinItems != nil {
+		count = int(*s.MinItems)
+	}
+
+	if count == 0 && (g.all || g.onTargetPath(path+"[*]")) {
+		count = 1
+	}
+
+	return g.items(s, path, count, index)
+}
+
+// items returns count items of an array schema.
+func (g *generator) items(s *extv1.JSONSchemaProps, path string, count, index int) []any {
+	list := make([]any, 0, count)
+
+	for i := range count {
+		if s.Items == nil || s.Items.Schema == nil {
+			list = append(list, fmt.Sprintf("item-%d", index+i))
+			continue
+		}
+
+		list = append(list, g.value(s.Items.Schema, path+"[*]", index+i))
+	}
+
+	return list
+}
+
+// integerValue returns the smallest integer the schema allows, from 0, plus the index.
+func integerValue(s *extv1.JSONSchemaProps, index int) int64 {
+	v := int64(index)
+
+	if lower, ok := integerMinimum(s); ok && lower > 0 {
+		v = lower + int64(index)
+	}
+
+	if upper, ok := integerMaximum(s); ok && v > upper {
+		v = upper
+	}
+
+	return v
+}
+
+func integerMinimum(s *extv1.JSONSchemaProps) (int64, bool) {
+	if s.Minimum == nil {
+		return 0, false
+	}
+
+	v := int64(math.Ceil(*s.Minimum))
+	if s.ExclusiveMinimum && float64(v) == *s.Minimum {
+		v++
+	}
+
+	if s.MultipleOf != nil && *s.MultipleOf >= 1 {
+		m := int64(*s.MultipleOf)
+		v = ceilDiv(v, m) * m
+	}
+
+	return v, true
+}
+
+func integerMaximum(s *extv1.JSONSchemaProps) (int64, bool) {
+	if s.Maximum == nil {
+		return 0, false
+	}
+
+	v := int64(math.Floor(*s.Maximum))
+	if s.ExclusiveMaximum && float64(v) == *s.Maximum {
+		v--
+	}
+
+	if s.MultipleOf != nil && *s.MultipleOf >= 1 {
+		m := int64(*s.MultipleOf)
+		v = -ceilDiv(-v, m) * m
+	}
+
+	return v, true
+}
+
+func ceilDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a > 0) == (b > 0) {
+		q++
+	}
+
+	return q
+}
+
+// numberValue returns the smallest number the schema allows, from 0, plus the index.
+func numberValue(s *extv1.JSONSchemaProps, index int) float64 {
+	v := float64(index)
+
+	if lower, ok := numberMinimum(s); ok && lower > 0 {
+		v = lower + float64(index)
+	}
+
+	if upper, ok := numberMaximum(s); ok && v > upper {
+		v = upper
+	}
+
+	return v
+}
+
+func numberMinimum(s *extv1.JSONSchemaProps) (float64, bool) {
+	if s.Minimum == nil {
+		return 0, false
+	}
+
+	if s.ExclusiveMinimum {
+		return math.Nextafter(*s.Minimum, math.Inf(1)), true
+	}
+
+	return *s.Minimum, true
+}
+
+func numberMaximum(s *extv1.JSONSchemaProps) (float64, bool) {
+	if s.Maximum == nil {
+		return 0, false
+	}
+
+	if s.ExclusiveMaximum {
+		return math.Nextafter(*s.Maximum, math.Inf(-1)), true
+	}
+
+	return *s.Maximum, true
+}
+
+// formatValues are values of the string formats Kubernetes validates.
+var formatValues = map[string]string{ //nolint:gochecknoglobals // lookup table
+	"byte":      "dmFsdWU=",
+	"date":      "2026-01-01",
+	"date-time": "2026-01-01T00:00:00Z",
+	"duration":  "1h",
+	"email":     "user@example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"uuid":      "00000000-0000-4000-8000-000000000000",
+}
+
+// stringValue returns a string that matches the format or the pattern of the schema, or "value" (suffixed with the
+// index) within its length bounds.
+func stringValue(s *extv1.JSONSchemaProps, index int) string {
+	if v, ok := formatValues[s.Format]; ok {
+		return v
+	}
+
+	if s.Pattern != "" {
+		if v, err := patternString(s.Pattern, false); err == nil {
+			return v
+		}
+	}
+
+	v := "value"
+	if index > 0 {
+		v = fmt.Sprintf("value-%d", index)
+	}
+
+	if s.MinLength != nil && int64(len(v)) < *s.MinLength {
+		v += strings.Repeat("x", int(*s.MinLength)-len(v))
+	}
+
+	if s.MaxLength != nil && int64(len(v)) > *s.MaxLength {
+		v = v[:*s.MaxLength]
+	}
+
+	return v
+}
+
+// boundaries returns the boundaries of the fields of a schema: every enum value, the minimum and maximum of numbers,
+// the minimum and maximum length of strings without format or pattern, the shortest and a long match of patterns,
+// and the minimum and maximum number of items of arrays.
+func boundaries(s *extv1.JSONSchemaProps, path string) []boundary {
+	var result []boundary
+
+	add := func(name string, value func(s *extv1.JSONSchemaProps) any) {
+		result = append(result, boundary{path: path, name: name, value: value})
+	}
+
+	if len(s.Enum) > 0 {
+		for _, e := range s.Enum {
+			value := mustDecodeJSON(e.Raw)
+			add(fmt.Sprintf("enum %s", strings.TrimSpace(string(e.Raw))), func(*extv1.JSONSchemaProps) any { return value })
+		}
+
+		return result
+	}
+
+	switch schemaType(s) {
+	case "object":
+		for _, name := range sortedKeys(s.Properties) {
+			prop := s.Properties[name]
+			result = append(result, boundaries(&prop, path+"."+name)...)
+		}
+	case "array":
+		if s.MinItems != nil {
+			add("min-items", func(s *extv1.JSONSchemaProps) any {
+				return (&generator{}).items(s, path, int(*s.MinItems), 0)
+			})
+		}
+
+		if s.MaxItems != nil {
+			add("max-items", func(s *extv1.JSONSchemaProps) any {
+				return (&generator{}).items(s, path, int(*s.MaxItems), 0)
+			})
+		}
+
+		if s.Items != nil && s.Items.Schema != nil {
+			result = append(result, boundaries(s.Items.Schema, path+"[*]")...)
+		}
+	case "integer":
+		if v, ok := integerMinimum(s); ok {
+			add("minimum", func(*extv1.JSONSchemaProps) any { return v })
+		}
+
+		if v, ok := integerMaximum(s); ok {
+			add("maximum", func(*extv1.JSONSchemaProps) any { return v })
+		}
+	case "number":
+		if v, ok := numberMinimum(s); ok {
+			add("minimum", func(*extv1.JSONSchemaProps) any { return v })
+		}
+
+		if v, ok := numberMaximum(s); ok {
+			add("maximum", func(*extv1.JSONSchemaProps) any { return v })
+		}
+	case "string":
+		result = append(result, stringBoundaries(s, path)...)
+	}
+
+	return result
+}
+
+func stringBoundaries(s *extv1.JSONSchemaProps, path string) []boundary {
+	var result []boundary
+
+	add := func(name, value string) {
+		result = append(result, boundary{path: path, name: name, value: func(*extv1.JSONSchemaProps) any { return value }})
+	}
+
+	if _, ok := formatValues[s.Format]; ok {
+		return nil
+	}
+
+	if s.Pattern != "" {
+		shortest, err := patternString(s.Pattern, false)
+		if err != nil {
+			return nil
+		}
+
+		add("pattern-shortest", shortest)
+
+		if longest, err := patternString(s.Pattern, true); err == nil && longest != shortest {
+			add("pattern-long", longest)
+		}
+
+		return result
+	}
+
+	if s.MinLength != nil {
+		add("min-length", strings.Repeat("x", int(*s.MinLength)))
+	}
+
+	if s.MaxLength != nil {
+		add("max-length", strings.Repeat("x", int(*s.MaxLength)))
+	}
+
+	return result
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatexr
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/xrdtocrd"
+	"github.com/crossplane-contrib/xprin/internal/validator"
+)
+
+const testXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.org
+spec:
+  group: example.org
+  names:
+    kind: XBucket
+    plural: xbuckets
+  versions:
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [region, name]
+            properties:
+              region:
+                type: string
+                enum: [eu-west-1, us-east-1]
+              name:
+                type: string
+                pattern: '^[a-z]([-a-z0-9]*[a-z0-9])?$'
+              size:
+                type: integer
+                minimum: 1
+                maximum: 100
+                default: 10
+              description:
+                type: string
+                minLength: 3
+                maxLength: 10
+              created:
+                type: string
+                format: date-time
+              rules:
+                type: array
+                maxItems: 2
+                items:
+                  type: object
+                  required: [days]
+                  properties:
+                    days:
+                      type: integer
+                      minimum: 0
+                      exclusiveMinimum: true
+              tags:
+                type: object
+                additionalProperties:
+                  type: string
+`
+
+func generateTestXRD(t *testing.T, opts ...func(*apiextensionsv1.CompositeResourceDefinition)) *apiextensionsv1.CompositeResourceDefinition {
+	t.Helper()
+
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	if err := yaml.Unmarshal([]byte(testXRD), xrd); err != nil {
+		t.Fatalf("yaml.Unmarshal(...): unexpected error: %v", err)
+	}
+
+	for _, opt := range opts {
+		opt(xrd)
+	}
+
+	return xrd
+}
+
+func specOf(variants []Variant) map[string]any {
+	if variants == nil {
+		return nil
+	}
+
+	specs := make(map[string]any, len(variants))
+	for _, v := range variants {
+		specs[v.Name] = v.XR.Object["spec"]
+	}
+
+	return specs
+}
+
+func TestGenerate(t *testing.T) {
+	type want struct {
+		specs map[string]any
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		xrd    *apiextensionsv1.CompositeResourceDefinition
+		opts   Options
+		want   want
+	}{
+		"NilXRD": {
+			reason: "Should return error when XRD is nil",
+			want: want{
+				err: errors.New(errNilInput),
+			},
+		},
+		"UnknownKind": {
+			reason: "Should return error for an unknown kind of variant",
+			xrd:    generateTestXRD(t),
+			opts:   Options{Kinds: []string{"random"}},
+			want: want{
+				err: errors.Errorf(errUnknownKind, "random"),
+			},
+		},
+		"UnknownVersion": {
+			reason: "Should return error when the XRD has no such version",
+			xrd:    generateTestXRD(t),
+			opts:   Options{Version: "v2"},
+			want: want{
+				err: errors.Errorf(errNoVersion, "v2"),
+			},
+		},
+		"MinimalAndMaximal": {
+			reason: "Should set only the required fields in the minimal XR and every field in the maximal XR",
+			xrd:    generateTestXRD(t),
+			opts:   Options{Kinds: []string{KindMinimal, KindMaximal}},
+			want: want{
+				specs: map[string]any{
+					KindMinimal: map[string]any{"name": "a", "region": "eu-west-1"},
+					KindMaximal: map[string]any{
+						"created":     "2026-01-01T00:00:00Z",
+						"description": "value",
+						"name":        "a",
+						"region":      "eu-west-1",
+						"rules":       []any{map[string]any{"days": int64(1)}},
+						"size":        int64(10),
+						"tags":        map[string]any{"key": "value"},
+					},
+				},
+			},
+		},
+		"Boundaries": {
+			reason: "Should add one field at each boundary of its schema to the required fields",
+			xrd:    generateTestXRD(t),
+			opts:   Options{Kinds: []string{KindBoundary}},
+			want: want{
+				specs: map[string]any{
+					"spec.description min-length":  map[string]any{"name": "a", "region": "eu-west-1", "description": "xxx"},
+					"spec.description max-length":  map[string]any{"name": "a", "region": "eu-west-1", "description": "xxxxxxxxxx"},
+					"spec.name pattern-shortest":   map[string]any{"name": "a", "region": "eu-west-1"},
+					"spec.name pattern-long":       map[string]any{"name": "aaaaa", "region": "eu-west-1"},
+					`spec.region enum "eu-west-1"`: map[string]any{"name": "a", "region": "eu-west-1"},
+					`spec.region enum "us-east-1"`: map[string]any{"name": "a", "region": "us-east-1"},
+					"spec.rules max-items":         map[string]any{"name": "a", "region": "eu-west-1", "rules": []any{map[string]any{"days": int64(1)}, map[string]any{"days": int64(2)}}},
+					"spec.rules[*].days minimum":   map[string]any{"name": "a", "region": "eu-west-1", "rules": []any{map[string]any{"days": int64(1)}}},
+					"spec.size minimum":            map[string]any{"name": "a", "region": "eu-west-1", "size": int64(1)},
+					"spec.size maximum":            map[string]any{"name": "a", "region": "eu-west-1", "size": int64(100)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Generate(tc.xrd, tc.opts)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGenerate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.specs, specOf(got)); diff != "" {
+				t.Errorf("\n%s\nGenerate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateMetadata(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		xrd    *apiextensionsv1.CompositeResourceDefinition
+		opts   Options
+		want   *unstructured.Unstructured
+	}{
+		"Default": {
+			reason: "Should name cluster scoped XRs after the default name and the variant",
+			xrd:    generateTestXRD(t),
+			want: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.org/v1",
+				"kind":       "XBucket",
+				"metadata":   map[string]any{"name": "example-minimal"},
+			}},
+		},
+		"Namespaced": {
+			reason: "Should set the namespace of the XRs of namespaced XRDs",
+			xrd: generateTestXRD(t, func(xrd *apiextensionsv1.CompositeResourceDefinition) {
+				xrd.Spec.Scope = ptr.To(apiextensionsv1.CompositeResourceScopeNamespaced)
+			}),
+			opts: Options{Name: "My_Bucket", Namespace: "team-a"},
+			want: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.org/v1",
+				"kind":       "XBucket",
+				"metadata":   map[string]any{"name": "my-bucket-minimal", "namespace": "team-a"},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.opts.Kinds = []string{KindMinimal}
+
+			got, err := Generate(tc.xrd, tc.opts)
+			if err != nil {
+				t.Fatalf("Generate(...): unexpected error: %v", err)
+			}
+
+			delete(got[0].XR.Object, "spec")
+
+			if diff := cmp.Diff(tc.want, got[0].XR); diff != "" {
+				t.Errorf("\n%s\nGenerate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateValid(t *testing.T) {
+	xrd := generateTestXRD(t)
+
+	crds, err := xrdtocrd.ForXRD(xrd)
+	if err != nil {
+		t.Fatalf("xrdtocrd.ForXRD(...): unexpected error: %v", err)
+	}
+
+	v, err := validator.New(crds)
+	if err != nil {
+		t.Fatalf("validator.New(...): unexpected error: %v", err)
+	}
+
+	variants, err := Generate(xrd, Options{})
+	if err != nil {
+		t.Fatalf("Generate(...): unexpected error: %v", err)
+	}
+
+	for _, variant := range variants {
+		for _, result := range v.Validate(context.Background(), []*unstructured.Unstructured{variant.XR}) {
+			if len(result.Errors) > 0 || result.MissingSchema {
+				t.Errorf("\nEvery variant should be valid against the XRD\nvariant %q: %+v", variant.Name, result)
+			}
+		}
+	}
+}
+
+func TestPatternString(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		pattern string
+		long    bool
+		want    string
+	}{
+		"Shortest": {
+			reason:  "Should take the first alternative and the fewest repetitions",
+			pattern: `^(gp2|gp3|io1)-[0-9]{2,4}$`,
+			want:    "gp2-00",
+		},
+		"Long": {
+			reason:  "Should take the last alternative and the most repetitions",
+			pattern: `^(gp2|gp3|io1)-[0-9]{2,4}$`,
+			long:    true,
+			want:    "io1-0000",
+		},
+		"Unbounded": {
+			reason:  "Should repeat unbounded repetitions a few times in long matches",
+			pattern: `^[A-Z]+\.x*$`,
+			long:    true,
+			want:    "AAAA.xxx",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := patternString(tc.pattern, tc.long)
+			if err != nil {
+				t.Fatalf("patternString(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\npatternString(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if !regexp.MustCompile(tc.pattern).MatchString(got) {
+				t.Errorf("\n%s\npatternString(...): %q does not match %q", tc.reason, got, tc.pattern)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	variants, err := Generate(generateTestXRD(t), Options{Kinds: []string{KindMinimal, KindMaximal}})
+	if err != nil {
+		t.Fatalf("Generate(...): unexpected error: %v", err)
+	}
+
+	b, err := Marshal(variants)
+	if err != nil {
+		t.Fatalf("Marshal(...): unexpected error: %v", err)
+	}
+
+	got := string(b)
+
+	for _, want := range []string{"---\n# Variant: minimal\napiVersion: example.org/v1\n", "---\n# Variant: maximal\n", "name: example-maximal"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("\nShould include %q\nMarshal(...): got:\n%s", want, got)
+		}
+	}
+}
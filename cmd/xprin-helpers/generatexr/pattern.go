@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatexr
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const (
+	errFmtParsePattern = "failed to parse pattern %q"
+	errFmtNoMatch      = "pattern %q has no match"
+)
+
+// patternString returns a string that matches a pattern: its shortest match, or a long match that takes the last
+// alternative of alternations and repeats repetitions as often as they allow (unboundedRepeats times when unbounded).
+func patternString(pattern string, long bool) (string, error) {
+	if long {
+		return matchPattern(pattern, longChooser{})
+	}
+
+	return matchPattern(pattern, shortestChooser{})
+}
+
+// chooser chooses the alternatives, repetitions and characters of a match of a pattern.
+type chooser interface {
+	// alternative returns the index of the alternative to take out of count.
+	alternative(count int) int
+	// repeats returns how many times to repeat a repetition, maximum being -1 when unbounded.
+	repeats(minimum, maximum int) int
+	// char returns a rune of a character class, given as pairs of rune ranges.
+	char(ranges []rune) rune
+}
+
+// shortestChooser chooses the shortest match, with readable characters.
+type shortestChooser struct{}
+
+func (shortestChooser) alternative(int) int        { return 0 }
+func (shortestChooser) repeats(minimum, _ int) int { return minimum }
+func (shortestChooser) char(ranges []rune) rune    { return classRune(ranges) }
+
+// longChooser chooses the last alternatives and the most repetitions, with readable characters.
+type longChooser struct{}
+
+func (longChooser) alternative(count int) int { return count - 1 }
+
+func (longChooser) repeats(minimum, maximum int) int {
+	if maximum < 0 {
+		return minimum + unboundedRepeats
+	}
+
+	return maximum
+}
+
+func (longChooser) char(ranges []rune) rune { return classRune(ranges) }
+
+// matchPattern returns a match of a pattern with the alternatives, repetitions and characters of the chooser.
+func matchPattern(pattern string, c chooser) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtParsePattern, pattern)
+	}
+
+	var b strings.Builder
+	if !writeMatch(&b, re, c) {
+		return "", errors.Errorf(errFmtNoMatch, pattern)
+	}
+
+	// Anchors and word boundaries are ignored when generating, so check that the match is one
+	if matched, err := regexp.MatchString(pattern, b.String()); err != nil || !matched {
+		return "", errors.Errorf(errFmtNoMatch, pattern)
+	}
+
+	return b.String(), nil
+}
+
+// writeMatch writes a match of a parsed pattern and reports whether it has one.
+func writeMatch(b *strings.Builder, re *syntax.Regexp, c chooser) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return false
+		}
+
+		b.WriteRune(c.char(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteRune('a')
+	case syntax.OpCapture:
+		return writeMatch(b, re.Sub[0], c)
+	case syntax.OpStar:
+		return writeRepeat(b, re.Sub[0], 0, -1, c)
+	case syntax.OpPlus:
+		return writeRepeat(b, re.Sub[0], 1, -1, c)
+	case syntax.OpQuest:
+		return writeRepeat(b, re.Sub[0], 0, 1, c)
+	case syntax.OpRepeat:
+		return writeRepeat(b, re.Sub[0], re.Min, re.Max, c)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writeMatch(b, sub, c) {
+				return false
+			}
+		}
+	case syntax.OpAlternate:
+		return writeMatch(b, re.Sub[c.alternative(len(re.Sub))], c)
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+	}
+
+	return true
+}
+
+// writeRepeat writes between minimum and maximum (-1 for unbounded) matches of a parsed pattern.
+func writeRepeat(b *strings.Builder, re *syntax.Regexp, minimum, maximum int, c chooser) bool {
+	for range c.repeats(minimum, maximum) {
+		if !writeMatch(b, re, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// classRune returns a readable rune of a character class, given as pairs of rune ranges: a lowercase letter, a digit
+// or an uppercase letter when the class has one, its first rune otherwise.
+func classRune(ranges []rune) rune {
+	for _, preferred := range []rune{'a', 'x', '0', 'A'} {
+		if inRanges(ranges, preferred) {
+			return preferred
+		}
+	}
+
+	return ranges[0]
+}
+
+// inRanges reports whether a rune is in pairs of rune ranges.
+func inRanges(ranges []rune, r rune) bool {
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i] <= r && r <= ranges[i+1] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -22,15 +22,19 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/claimtoxr"
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/generatexr"
 	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/patchxr"
 	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/version"
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/xrdtocrd"
 )
 
 // CLI represents the command-line interface structure.
 type CLI struct {
-	ConvertClaimToXR claimtoxr.Cmd `cmd:"convert-claim-to-xr" help:"Convert a Crossplane Claim to an XR (Composite Resource)."`
-	PatchXR          patchxr.Cmd   `cmd:""                    help:"Patch a Crossplane XR (Composite Resource) with additional configurations."`
-	Version          version.Cmd   `cmd:""                    help:"Print the version of xprin-helpers"`
+	ConvertClaimToXR claimtoxr.Cmd  `cmd:"convert-claim-to-xr" help:"Convert a Crossplane Claim to an XR (Composite Resource)."`
+	GenerateXR       generatexr.Cmd `cmd:"generate-xr"         help:"Generate XRs (Composite Resources) from the schema of a Crossplane XRD (CompositeResourceDefinition)."`
+	PatchXR          patchxr.Cmd    `cmd:""                    help:"Patch a Crossplane XR (Composite Resource) with additional configurations."`
+	XRDToCRD         xrdtocrd.Cmd   `cmd:"xrd-to-crd"          help:"Derive the CRDs Crossplane generates for a Crossplane XRD (CompositeResourceDefinition)."`
+	Version          version.Cmd    `cmd:""                    help:"Print the version of xprin-helpers"`
 }
 
 func main() {
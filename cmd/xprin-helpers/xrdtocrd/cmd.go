@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package xrdtocrd implements the command for deriving the CRDs of a Crossplane XRD (CompositeResourceDefinition).
+package xrdtocrd
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/alecthomas/kong"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	commonIO "github.com/crossplane/crossplane/v2/cmd/crank/beta/convert/io"
+)
+
+// Cmd arguments and flags for deriving the CRDs of a Crossplane XRD (CompositeResourceDefinition).
+type Cmd struct {
+	// Arguments.
+	InputFile string `arg:"" default:"-" help:"The XRD YAML file to derive the CRDs from. If not specified or '-', stdin will be used." optional:"" predictor:"file" type:"path"`
+
+	// Flags.
+	OutputFile string `help:"The file to write the generated CRDs YAML to. If not specified, stdout will be used." placeholder:"PATH" predictor:"file" short:"o" type:"path"`
+
+	fs afero.Fs
+}
+
+// Help returns help message for the xrd-to-crd command.
+func (c *Cmd) Help() string {
+	return `
+Derive the CustomResourceDefinitions (CRDs) Crossplane generates for a CompositeResourceDefinition (XRD).
+
+This command will:
+- Read the XRD from the provided YAML file
+- Generate the CRD of the composite resource (XR)
+- Generate the CRD of the claim if the XRD sets claimNames
+- Include the fields Crossplane injects (e.g. spec.compositionRef, spec.resourceRefs, status.conditions)
+
+Examples:
+
+  # Derive the CRDs of xrd.yaml and write them to stdout
+  xprin-helpers xrd-to-crd xrd.yaml
+
+  # Derive the CRDs of xrd.yaml and write them to crds.yaml
+  xprin-helpers xrd-to-crd xrd.yaml -o crds.yaml
+
+  # Derive the CRDs of an XRD from stdin
+  cat xrd.yaml | xprin-helpers xrd-to-crd -
+`
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run runs the xrd-to-crd command.
+func (c *Cmd) Run(k *kong.Context) error {
+	xrdData, err := commonIO.Read(c.fs, c.InputFile)
+	if err != nil {
+		return err
+	}
+
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	if err := yaml.Unmarshal(xrdData, xrd); err != nil {
+		return errors.Wrap(err, "Unmarshalling Error")
+	}
+
+	crds, err := ForXRD(xrd)
+	if err != nil {
+		return errors.Wrap(err, "failed to derive CRDs from XRD")
+	}
+
+	b, err := Marshal(crds)
+	if err != nil {
+		return err
+	}
+
+	output := k.Stdout
+
+	if outputFileName := c.OutputFile; outputFileName != "" {
+		f, err := c.fs.OpenFile(outputFileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return errors.Wrap(err, "Unable to open output file")
+		}
+
+		defer func() { _ = f.Close() }()
+
+		output = f
+	}
+
+	outputW := bufio.NewWriter(output)
+	if _, err := outputW.Write(b); err != nil {
+		return errors.Wrap(err, "Writing YAML file content")
+	}
+
+	if err := outputW.Flush(); err != nil {
+		return errors.Wrap(err, "Flushing output")
+	}
+
+	return nil
+}
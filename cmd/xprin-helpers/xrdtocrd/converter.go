@@ -0,0 +1,503 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrdtocrd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+const (
+	// Error messages.
+	errNilInput             = "input is nil"
+	errNoSchema             = "version %s has no schema"
+	errParseSchema          = "failed to parse the schema of version %s"
+	errMissingClaimNames    = "XRD has no claimNames"
+	errConflictingClaimName = "claim name %q conflicts with the composite resource name"
+	errGenerateCompositeCRD = "failed to generate the composite resource CRD"
+	errGenerateClaimCRD     = "failed to generate the claim CRD"
+	errFmtMarshalCRD        = "failed to marshal CRD %s"
+
+	// Categories of the generated CRDs.
+	categoryComposite = "composite"
+	categoryClaim     = "claim"
+
+	// The names of composite resources and claims are used as label values, so they cannot be longer.
+	maxCompositeNameLength = 63
+	maxClaimNameLength     = 63
+)
+
+// ForXRD returns the CRDs Crossplane generates for an XRD: the CRD of its composite resource and, when the XRD sets
+// claimNames, the CRD of its claim.
+func ForXRD(xrd *apiextensionsv1.CompositeResourceDefinition) ([]*extv1.CustomResourceDefinition, error) {
+	xrCRD, err := ForCompositeResource(xrd)
+	if err != nil {
+		return nil, errors.Wrap(err, errGenerateCompositeCRD)
+	}
+
+	if xrd.Spec.ClaimNames == nil {
+		return []*extv1.CustomResourceDefinition{xrCRD}, nil
+	}
+
+	claimCRD, err := ForCompositeResourceClaim(xrd)
+	if err != nil {
+		return nil, errors.Wrap(err, errGenerateClaimCRD)
+	}
+
+	return []*extv1.CustomResourceDefinition{xrCRD, claimCRD}, nil
+}
+
+// ForCompositeResource returns the CRD of the composite resource defined by an XRD, with the fields Crossplane injects
+// in the spec and status of every composite resource.
+// Based on the CRDs Crossplane v2 generates for XRDs
+// https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/crd.go#L55-L109
+func ForCompositeResource(xrd *apiextensionsv1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+	if xrd == nil {
+		return nil, errors.New(errNilInput)
+	}
+
+	crd := newCRD(xrd, xrd.GetName(), xrd.Spec.Names)
+
+	scope := ptr.Deref(xrd.Spec.Scope, apiextensionsv1.CompositeResourceScopeLegacyCluster)
+	if scope == apiextensionsv1.CompositeResourceScopeNamespaced {
+		crd.Spec.Scope = extv1.NamespaceScoped
+	}
+
+	crd.Spec.Names.Categories = append(crd.Spec.Names.Categories, categoryComposite)
+
+	for _, vr := range xrd.Spec.Versions {
+		crdv, err := crdVersion(vr, maxCompositeNameLength)
+		if err != nil {
+			return nil, err
+		}
+
+		crdv.AdditionalPrinterColumns = append(crdv.AdditionalPrinterColumns, compositeResourcePrinterColumns(scope)...)
+
+		maps.Copy(crdv.Schema.OpenAPIV3Schema.Properties["spec"].Properties, compositeResourceSpecProps(scope, xrd.Spec.DefaultCompositionUpdatePolicy))
+		maps.Copy(crdv.Schema.OpenAPIV3Schema.Properties["status"].Properties, compositeResourceStatusProps(scope))
+
+		crd.Spec.Versions = append(crd.Spec.Versions, *crdv)
+	}
+
+	return crd, nil
+}
+
+// ForCompositeResourceClaim returns the CRD of the claim defined by an XRD with claimNames, with the fields Crossplane
+// injects in the spec and status of every claim.
+// Based on the CRDs Crossplane v2 generates for XRDs
+// https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/crd.go#L113-L164
+func ForCompositeResourceClaim(xrd *apiextensionsv1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+	if xrd == nil {
+		return nil, errors.New(errNilInput)
+	}
+
+	if err := checkClaimNames(xrd); err != nil {
+		return nil, err
+	}
+
+	crd := newCRD(xrd, xrd.Spec.ClaimNames.Plural+"."+xrd.Spec.Group, *xrd.Spec.ClaimNames)
+	crd.Spec.Scope = extv1.NamespaceScoped
+	crd.Spec.Names.Categories = append(crd.Spec.Names.Categories, categoryClaim)
+
+	for _, vr := range xrd.Spec.Versions {
+		crdv, err := crdVersion(vr, maxClaimNameLength)
+		if err != nil {
+			return nil, err
+		}
+
+		crdv.AdditionalPrinterColumns = append(crdv.AdditionalPrinterColumns, claimPrinterColumns()...)
+
+		maps.Copy(crdv.Schema.OpenAPIV3Schema.Properties["spec"].Properties, claimSpecProps(xrd.Spec.DefaultCompositeDeletePolicy))
+		// Like Crossplane, claims get the status fields of legacy composite resources
+		maps.Copy(crdv.Schema.OpenAPIV3Schema.Properties["status"].Properties, compositeResourceStatusProps(apiextensionsv1.CompositeResourceScopeLegacyCluster))
+
+		crd.Spec.Versions = append(crd.Spec.Versions, *crdv)
+	}
+
+	return crd, nil
+}
+
+// Marshal returns the CRDs as a multi-document YAML stream, without the status and the empty metadata fields of
+// objects that were never stored.
+func Marshal(crds []*extv1.CustomResourceDefinition) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, crd := range crds {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtMarshalCRD, crd.GetName())
+		}
+
+		delete(obj, "status")
+		unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtMarshalCRD, crd.GetName())
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(b)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// newCRD returns a cluster scoped CRD with the given name and names, and the group, conversion and metadata of an XRD.
+func newCRD(xrd *apiextensionsv1.CompositeResourceDefinition, name string, names extv1.CustomResourceDefinitionNames) *extv1.CustomResourceDefinition {
+	crd := &extv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{APIVersion: extv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition"},
+		Spec: extv1.CustomResourceDefinitionSpec{
+			Group:      xrd.Spec.Group,
+			Names:      names,
+			Scope:      extv1.ClusterScoped,
+			Conversion: xrd.Spec.Conversion,
+		},
+	}
+	crd.SetName(name)
+
+	labels := maps.Clone(xrd.GetLabels())
+
+	if xrd.Spec.Metadata != nil {
+		if len(xrd.Spec.Metadata.Labels) > 0 {
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+
+			maps.Copy(labels, xrd.Spec.Metadata.Labels)
+		}
+
+		crd.SetAnnotations(xrd.Spec.Metadata.Annotations)
+	}
+
+	crd.SetLabels(labels)
+
+	return crd
+}
+
+// crdVersion returns the CRD version of an XRD version: the schema of the XRD version merged into the base schema of
+// every Crossplane generated CRD, with the name of the resources limited to maxNameLength characters.
+// Based on https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/crd.go#L166-L233
+func crdVersion(vr apiextensionsv1.CompositeResourceDefinitionVersion, maxNameLength int64) (*extv1.CustomResourceDefinitionVersion, error) {
+	if vr.Schema == nil {
+		return nil, errors.Errorf(errNoSchema, vr.Name)
+	}
+
+	s := &extv1.JSONSchemaProps{}
+	if err := json.Unmarshal(vr.Schema.OpenAPIV3Schema.Raw, s); err != nil {
+		return nil, errors.Wrapf(err, errParseSchema, vr.Name)
+	}
+
+	crdv := &extv1.CustomResourceDefinitionVersion{
+		Name:                     vr.Name,
+		Served:                   vr.Served,
+		Storage:                  vr.Referenceable,
+		Deprecated:               ptr.Deref(vr.Deprecated, false),
+		DeprecationWarning:       vr.DeprecationWarning,
+		AdditionalPrinterColumns: vr.AdditionalPrinterColumns,
+		Schema:                   &extv1.CustomResourceValidation{OpenAPIV3Schema: baseProps()},
+		Subresources:             &extv1.CustomResourceSubresources{Status: &extv1.CustomResourceSubresourceStatus{}},
+	}
+
+	props := crdv.Schema.OpenAPIV3Schema.Properties
+	crdv.Schema.OpenAPIV3Schema.Description = s.Description
+
+	maxLength := maxNameLength
+	if old := s.Properties["metadata"].Properties["name"].MaxLength; old != nil && *old < maxLength {
+		maxLength = *old
+	}
+
+	metadata := props["metadata"]
+	metadata.Properties = map[string]extv1.JSONSchemaProps{"name": {Type: "string", MaxLength: ptr.To(maxLength)}}
+	props["metadata"] = metadata
+
+	xSpec := s.Properties["spec"]
+	spec := props["spec"]
+	spec.Required = append(spec.Required, xSpec.Required...)
+	spec.XPreserveUnknownFields = xSpec.XPreserveUnknownFields
+	spec.XValidations = append(spec.XValidations, xSpec.XValidations...)
+	spec.OneOf = append(spec.OneOf, xSpec.OneOf...)
+	spec.Description = xSpec.Description
+	maps.Copy(spec.Properties, xSpec.Properties)
+	props["spec"] = spec
+
+	xStatus := s.Properties["status"]
+	status := props["status"]
+	status.Required = xStatus.Required
+	status.XValidations = xStatus.XValidations
+	status.Description = xStatus.Description
+	status.OneOf = xStatus.OneOf
+	maps.Copy(status.Properties, xStatus.Properties)
+	props["status"] = status
+
+	return crdv, nil
+}
+
+// checkClaimNames returns an error when an XRD has no claimNames, or claimNames that conflict with its names.
+func checkClaimNames(xrd *apiextensionsv1.CompositeResourceDefinition) error {
+	claimNames := xrd.Spec.ClaimNames
+	if claimNames == nil {
+		return errors.New(errMissingClaimNames)
+	}
+
+	names := xrd.Spec.Names
+
+	switch {
+	case claimNames.Kind == names.Kind:
+		return errors.Errorf(errConflictingClaimName, claimNames.Kind)
+	case claimNames.Plural == names.Plural:
+		return errors.Errorf(errConflictingClaimName, claimNames.Plural)
+	case claimNames.Singular != "" && claimNames.Singular == names.Singular:
+		return errors.Errorf(errConflictingClaimName, claimNames.Singular)
+	case claimNames.ListKind != "" && claimNames.ListKind == names.ListKind:
+		return errors.Errorf(errConflictingClaimName, claimNames.ListKind)
+	}
+
+	return nil
+}
+
+// baseProps returns the base schema of every Crossplane generated CRD.
+// Based on https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/schemas.go#L48-L74
+func baseProps() *extv1.JSONSchemaProps {
+	return &extv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"spec"},
+		Properties: map[string]extv1.JSONSchemaProps{
+			"apiVersion": {Type: "string"},
+			"kind":       {Type: "string"},
+			"metadata":   {Type: "object"},
+			"spec":       {Type: "object", Properties: map[string]extv1.JSONSchemaProps{}},
+			"status":     {Type: "object", Properties: map[string]extv1.JSONSchemaProps{}},
+		},
+	}
+}
+
+// compositeResourceSpecProps returns the spec fields Crossplane injects in composite resources: directly in the spec
+// of legacy composite resources, in spec.crossplane otherwise.
+// Based on https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/schemas.go#L78-L207
+func compositeResourceSpecProps(scope apiextensionsv1.CompositeResourceScope, defaultPolicy *xpv1.UpdatePolicy) map[string]extv1.JSONSchemaProps {
+	refProps := map[string]extv1.JSONSchemaProps{
+		"apiVersion": {Type: "string"},
+		"name":       {Type: "string"},
+		"namespace":  {Type: "string"},
+		"kind":       {Type: "string"},
+	}
+
+	// Namespaced composite resources cannot reference composed resources in other namespaces
+	if scope == apiextensionsv1.CompositeResourceScopeNamespaced {
+		delete(refProps, "namespace")
+	}
+
+	props := map[string]extv1.JSONSchemaProps{
+		"compositionRef":              nameRefProps(),
+		"compositionSelector":         matchLabelsProps(),
+		"compositionRevisionRef":      nameRefProps(),
+		"compositionRevisionSelector": matchLabelsProps(),
+		"compositionUpdatePolicy":     enumProps(defaultJSON(defaultPolicy), "Automatic", "Manual"),
+		"resourceRefs": {
+			Type: "array",
+			Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{
+				Type:       "object",
+				Required:   []string{"apiVersion", "kind"},
+				Properties: refProps,
+			}},
+			XListType: ptr.To("atomic"),
+		},
+	}
+
+	if scope != apiextensionsv1.CompositeResourceScopeLegacyCluster {
+		return map[string]extv1.JSONSchemaProps{
+			"crossplane": {Type: "object", Description: "Configures how Crossplane will reconcile this composite resource", Properties: props},
+		}
+	}
+
+	props["claimRef"] = extv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"apiVersion", "kind", "namespace", "name"},
+		Properties: map[string]extv1.JSONSchemaProps{
+			"apiVersion": {Type: "string"},
+			"kind":       {Type: "string"},
+			"namespace":  {Type: "string"},
+			"name":       {Type: "string"},
+		},
+	}
+	props["writeConnectionSecretToRef"] = extv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"name", "namespace"},
+		Properties: map[string]extv1.JSONSchemaProps{
+			"name":      {Type: "string"},
+			"namespace": {Type: "string"},
+		},
+	}
+
+	return props
+}
+
+// claimSpecProps returns the spec fields Crossplane injects in claims.
+// Based on https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/schemas.go#L212-L291
+func claimSpecProps(defaultPolicy *xpv1.CompositeDeletePolicy) map[string]extv1.JSONSchemaProps {
+	return map[string]extv1.JSONSchemaProps{
+		"compositionRef":              nameRefProps(),
+		"compositionSelector":         matchLabelsProps(),
+		"compositionRevisionRef":      nameRefProps(),
+		"compositionRevisionSelector": matchLabelsProps(),
+		"compositionUpdatePolicy":     enumProps(nil, "Automatic", "Manual"),
+		"compositeDeletePolicy":       enumProps(defaultJSON(defaultPolicy), "Background", "Foreground"),
+		"resourceRef": {
+			Type:     "object",
+			Required: []string{"apiVersion", "kind", "name"},
+			Properties: map[string]extv1.JSONSchemaProps{
+				"apiVersion": {Type: "string"},
+				"kind":       {Type: "string"},
+				"name":       {Type: "string"},
+			},
+		},
+		"writeConnectionSecretToRef": {
+			Type:       "object",
+			Required:   []string{"name"},
+			Properties: map[string]extv1.JSONSchemaProps{"name": {Type: "string"}},
+		},
+	}
+}
+
+// compositeResourceStatusProps returns the status fields Crossplane injects in composite resources and claims.
+// Based on https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/schemas.go#L295-L345
+func compositeResourceStatusProps(scope apiextensionsv1.CompositeResourceScope) map[string]extv1.JSONSchemaProps {
+	props := map[string]extv1.JSONSchemaProps{
+		"conditions": {
+			Description:  "Conditions of the resource.",
+			Type:         "array",
+			XListMapKeys: []string{"type"},
+			XListType:    ptr.To("map"),
+			Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{
+				Type:     "object",
+				Required: []string{"lastTransitionTime", "reason", "status", "type"},
+				Properties: map[string]extv1.JSONSchemaProps{
+					"lastTransitionTime": {Type: "string", Format: "date-time"},
+					"message":            {Type: "string"},
+					"reason":             {Type: "string"},
+					"status":             {Type: "string"},
+					"type":               {Type: "string"},
+					"observedGeneration": {Type: "integer", Format: "int64"},
+				},
+			}},
+		},
+	}
+
+	// Only legacy composite resources have connection details and support claims
+	if scope == apiextensionsv1.CompositeResourceScopeLegacyCluster {
+		props["connectionDetails"] = extv1.JSONSchemaProps{
+			Type:       "object",
+			Properties: map[string]extv1.JSONSchemaProps{"lastPublishedTime": {Type: "string", Format: "date-time"}},
+		}
+		props["claimConditionTypes"] = extv1.JSONSchemaProps{
+			Type:      "array",
+			XListType: ptr.To("set"),
+			Items:     &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{Type: "string"}},
+		}
+	}
+
+	return props
+}
+
+// compositeResourcePrinterColumns returns the printer columns of composite resources.
+// Based on https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/schemas.go#L349-L392
+func compositeResourcePrinterColumns(scope apiextensionsv1.CompositeResourceScope) []extv1.CustomResourceColumnDefinition {
+	compositionPath := ".spec.crossplane"
+	if scope == apiextensionsv1.CompositeResourceScopeLegacyCluster {
+		compositionPath = ".spec"
+	}
+
+	return []extv1.CustomResourceColumnDefinition{
+		{Name: "SYNCED", Type: "string", JSONPath: ".status.conditions[?(@.type=='Synced')].status"},
+		{Name: "READY", Type: "string", JSONPath: ".status.conditions[?(@.type=='Ready')].status"},
+		{Name: "COMPOSITION", Type: "string", JSONPath: compositionPath + ".compositionRef.name"},
+		{Name: "COMPOSITIONREVISION", Type: "string", JSONPath: compositionPath + ".compositionRevisionRef.name", Priority: 1},
+		{Name: "AGE", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+	}
+}
+
+// claimPrinterColumns returns the printer columns of claims.
+// Based on https://github.com/crossplane/crossplane/blob/v2.1.3/internal/xcrd/schemas.go#L396-L419
+func claimPrinterColumns() []extv1.CustomResourceColumnDefinition {
+	return []extv1.CustomResourceColumnDefinition{
+		{Name: "SYNCED", Type: "string", JSONPath: ".status.conditions[?(@.type=='Synced')].status"},
+		{Name: "READY", Type: "string", JSONPath: ".status.conditions[?(@.type=='Ready')].status"},
+		{Name: "CONNECTION-SECRET", Type: "string", JSONPath: ".spec.writeConnectionSecretToRef.name"},
+		{Name: "AGE", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+	}
+}
+
+// nameRefProps returns the schema of a reference by name.
+func nameRefProps() extv1.JSONSchemaProps {
+	return extv1.JSONSchemaProps{
+		Type:       "object",
+		Required:   []string{"name"},
+		Properties: map[string]extv1.JSONSchemaProps{"name": {Type: "string"}},
+	}
+}
+
+// matchLabelsProps returns the schema of a label selector.
+func matchLabelsProps() extv1.JSONSchemaProps {
+	return extv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"matchLabels"},
+		Properties: map[string]extv1.JSONSchemaProps{
+			"matchLabels": {
+				Type: "object",
+				AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+					Allows: true,
+					Schema: &extv1.JSONSchemaProps{Type: "string"},
+				},
+			},
+		},
+	}
+}
+
+// enumProps returns the schema of a string enum with an optional default.
+func enumProps(defaultValue *extv1.JSON, values ...string) extv1.JSONSchemaProps {
+	props := extv1.JSONSchemaProps{Type: "string", Default: defaultValue}
+
+	for _, value := range values {
+		props.Enum = append(props.Enum, extv1.JSON{Raw: fmt.Appendf(nil, "%q", value)})
+	}
+
+	return props
+}
+
+// defaultJSON returns the JSON of the default of a policy field, or nil when the policy is not set.
+func defaultJSON[T ~string](policy *T) *extv1.JSON {
+	if policy == nil {
+		return nil
+	}
+
+	return &extv1.JSON{Raw: fmt.Appendf(nil, "%q", *policy)}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrdtocrd
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+const testSchema = `{
+  "type": "object",
+  "properties": {
+    "spec": {
+      "type": "object",
+      "required": ["region"],
+      "properties": {
+        "region": {"type": "string"}
+      }
+    },
+    "status": {
+      "type": "object",
+      "properties": {
+        "address": {"type": "string"}
+      }
+    }
+  }
+}`
+
+// generateTestXRD creates a test XRD with a single version.
+// It accepts optional modifier functions that can customize the XRD's content.
+func generateTestXRD(opts ...func(*apiextensionsv1.CompositeResourceDefinition)) *apiextensionsv1.CompositeResourceDefinition {
+	xrd := &apiextensionsv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xtestapps.example.org"},
+		Spec: apiextensionsv1.CompositeResourceDefinitionSpec{
+			Group: "example.org",
+			Names: extv1.CustomResourceDefinitionNames{
+				Kind:     "XTestApp",
+				ListKind: "XTestAppList",
+				Plural:   "xtestapps",
+				Singular: "xtestapp",
+			},
+			Versions: []apiextensionsv1.CompositeResourceDefinitionVersion{{
+				Name:          "v1alpha1",
+				Served:        true,
+				Referenceable: true,
+				Schema: &apiextensionsv1.CompositeResourceValidation{
+					OpenAPIV3Schema: runtime.RawExtension{Raw: []byte(testSchema)},
+				},
+			}},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(xrd)
+	}
+
+	return xrd
+}
+
+func withClaimNames(xrd *apiextensionsv1.CompositeResourceDefinition) {
+	xrd.Spec.ClaimNames = &extv1.CustomResourceDefinitionNames{
+		Kind:     "TestApp",
+		ListKind: "TestAppList",
+		Plural:   "testapps",
+		Singular: "testapp",
+	}
+}
+
+func withScope(scope apiextensionsv1.CompositeResourceScope) func(*apiextensionsv1.CompositeResourceDefinition) {
+	return func(xrd *apiextensionsv1.CompositeResourceDefinition) {
+		xrd.Spec.Scope = ptr.To(scope)
+	}
+}
+
+// crdSummary is the part of a generated CRD the tests compare.
+type crdSummary struct {
+	Name         string
+	Scope        extv1.ResourceScope
+	Categories   []string
+	Versions     []string
+	SpecProps    []string
+	SpecRequired []string
+	StatusProps  []string
+}
+
+func summarize(crds []*extv1.CustomResourceDefinition) []crdSummary {
+	if crds == nil {
+		return nil
+	}
+
+	summaries := make([]crdSummary, 0, len(crds))
+
+	for _, crd := range crds {
+		s := crdSummary{
+			Name:       crd.GetName(),
+			Scope:      crd.Spec.Scope,
+			Categories: crd.Spec.Names.Categories,
+		}
+
+		for _, v := range crd.Spec.Versions {
+			s.Versions = append(s.Versions, v.Name)
+			props := v.Schema.OpenAPIV3Schema.Properties
+			s.SpecProps = slices.Sorted(maps.Keys(props["spec"].Properties))
+			s.SpecRequired = props["spec"].Required
+			s.StatusProps = slices.Sorted(maps.Keys(props["status"].Properties))
+		}
+
+		summaries = append(summaries, s)
+	}
+
+	return summaries
+}
+
+func TestForXRD(t *testing.T) {
+	type want struct {
+		crds []crdSummary
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		xrd    *apiextensionsv1.CompositeResourceDefinition
+		want   want
+	}{
+		"NilXRD": {
+			reason: "Should return error when XRD is nil",
+			xrd:    nil,
+			want: want{
+				err: errors.Wrap(errors.New(errNilInput), errGenerateCompositeCRD),
+			},
+		},
+		"LegacyClusterWithoutClaim": {
+			reason: "Should generate only the XR CRD, with the legacy injected fields, when claimNames is not set",
+			xrd:    generateTestXRD(),
+			want: want{
+				crds: []crdSummary{{
+					Name:         "xtestapps.example.org",
+					Scope:        extv1.ClusterScoped,
+					Categories:   []string{categoryComposite},
+					Versions:     []string{"v1alpha1"},
+					SpecProps:    []string{"claimRef", "compositionRef", "compositionRevisionRef", "compositionRevisionSelector", "compositionSelector", "compositionUpdatePolicy", "region", "resourceRefs", "writeConnectionSecretToRef"},
+					SpecRequired: []string{"region"},
+					StatusProps:  []string{"address", "claimConditionTypes", "conditions", "connectionDetails"},
+				}},
+			},
+		},
+		"LegacyClusterWithClaim": {
+			reason: "Should generate the XR CRD and a namespaced claim CRD when claimNames is set",
+			xrd:    generateTestXRD(withClaimNames),
+			want: want{
+				crds: []crdSummary{
+					{
+						Name:         "xtestapps.example.org",
+						Scope:        extv1.ClusterScoped,
+						Categories:   []string{categoryComposite},
+						Versions:     []string{"v1alpha1"},
+						SpecProps:    []string{"claimRef", "compositionRef", "compositionRevisionRef", "compositionRevisionSelector", "compositionSelector", "compositionUpdatePolicy", "region", "resourceRefs", "writeConnectionSecretToRef"},
+						SpecRequired: []string{"region"},
+						StatusProps:  []string{"address", "claimConditionTypes", "conditions", "connectionDetails"},
+					},
+					{
+						Name:         "testapps.example.org",
+						Scope:        extv1.NamespaceScoped,
+						Categories:   []string{categoryClaim},
+						Versions:     []string{"v1alpha1"},
+						SpecProps:    []string{"compositeDeletePolicy", "compositionRef", "compositionRevisionRef", "compositionRevisionSelector", "compositionSelector", "compositionUpdatePolicy", "region", "resourceRef", "writeConnectionSecretToRef"},
+						SpecRequired: []string{"region"},
+						StatusProps:  []string{"address", "claimConditionTypes", "conditions", "connectionDetails"},
+					},
+				},
+			},
+		},
+		"Namespaced": {
+			reason: "Should generate a namespaced XR CRD with the injected spec fields under spec.crossplane",
+			xrd:    generateTestXRD(withScope(apiextensionsv1.CompositeResourceScopeNamespaced)),
+			want: want{
+				crds: []crdSummary{{
+					Name:         "xtestapps.example.org",
+					Scope:        extv1.NamespaceScoped,
+					Categories:   []string{categoryComposite},
+					Versions:     []string{"v1alpha1"},
+					SpecProps:    []string{"crossplane", "region"},
+					SpecRequired: []string{"region"},
+					StatusProps:  []string{"address", "conditions"},
+				}},
+			},
+		},
+		"ConflictingClaimNames": {
+			reason: "Should return error when claimNames conflict with the names of the XR",
+			xrd: generateTestXRD(withClaimNames, func(xrd *apiextensionsv1.CompositeResourceDefinition) {
+				xrd.Spec.ClaimNames.Kind = xrd.Spec.Names.Kind
+			}),
+			want: want{
+				err: errors.Wrap(errors.Errorf(errConflictingClaimName, "XTestApp"), errGenerateClaimCRD),
+			},
+		},
+		"NoSchema": {
+			reason: "Should return error when a version has no schema",
+			xrd: generateTestXRD(func(xrd *apiextensionsv1.CompositeResourceDefinition) {
+				xrd.Spec.Versions[0].Schema = nil
+			}),
+			want: want{
+				err: errors.Wrap(errors.Errorf(errNoSchema, "v1alpha1"), errGenerateCompositeCRD),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ForXRD(tc.xrd)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nForXRD(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.crds, summarize(got)); diff != "" {
+				t.Errorf("\n%s\nForXRD(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestForCompositeResourceNamespacedResourceRefs(t *testing.T) {
+	crd, err := ForCompositeResource(generateTestXRD(withScope(apiextensionsv1.CompositeResourceScopeNamespaced)))
+	if err != nil {
+		t.Fatalf("ForCompositeResource(...): unexpected error: %v", err)
+	}
+
+	crossplane := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"].Properties["crossplane"]
+	got := slices.Sorted(maps.Keys(crossplane.Properties["resourceRefs"].Items.Schema.Properties))
+
+	want := []string{"apiVersion", "kind", "name"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nNamespaced XRs should reference composed resources in their own namespace\nForCompositeResource(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	crds, err := ForXRD(generateTestXRD(withClaimNames))
+	if err != nil {
+		t.Fatalf("ForXRD(...): unexpected error: %v", err)
+	}
+
+	b, err := Marshal(crds)
+	if err != nil {
+		t.Fatalf("Marshal(...): unexpected error: %v", err)
+	}
+
+	got := string(b)
+
+	if diff := cmp.Diff(2, strings.Count(got, "---\n")); diff != "" {
+		t.Errorf("\nShould write one YAML document per CRD\nMarshal(...): -want, +got:\n%s", diff)
+	}
+
+	for _, want := range []string{"kind: CustomResourceDefinition", "name: xtestapps.example.org", "name: testapps.example.org"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("\nShould include %q\nMarshal(...): got:\n%s", want, got)
+		}
+	}
+
+	for _, unwanted := range []string{"  creationTimestamp: null", "storedVersions", "acceptedNames"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("\nShould not include %q\nMarshal(...): got:\n%s", unwanted, got)
+		}
+	}
+}
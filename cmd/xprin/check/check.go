@@ -29,9 +29,9 @@ import (
 
 // Cmd represents the check subcommand.
 type Cmd struct {
-	Config     *configtypes.Config `kong:"-"`
-	ConfigPath string              `kong:"-"`
-	fs         afero.Fs
+	Config      *configtypes.Config `kong:"-"`
+	ConfigPaths []string            `kong:"-"`
+	fs          afero.Fs
 }
 
 // AfterApply implements kong.AfterApply.
@@ -45,10 +45,19 @@ func (c *Cmd) Run(_ *kong.Context) error {
 	// combine all error messages
 	var allErrors []string
 
-	if c.ConfigPath == "" {
+	switch len(c.ConfigPaths) {
+	case 0:
 		utils.OutputPrintf("No configuration file provided, using detected dependencies\n")
-	} else {
-		utils.OutputPrintf("Configuration file: %s\n\n", c.ConfigPath)
+	case 1:
+		utils.OutputPrintf("Configuration file: %s\n\n", c.ConfigPaths[0])
+	default:
+		utils.OutputPrintf("Configuration files (lowest to highest precedence):\n")
+
+		for _, path := range c.ConfigPaths {
+			utils.OutputPrintf("- %s\n", path)
+		}
+
+		utils.OutputPrintf("\n")
 	}
 
 	// Always check dependencies, subcommands, and repositories
@@ -60,6 +69,10 @@ func (c *Cmd) Run(_ *kong.Context) error {
 		allErrors = append(allErrors, err.Error())
 	}
 
+	if err := c.Config.CheckValidation(); err != nil {
+		allErrors = append(allErrors, err.Error())
+	}
+
 	if err := c.Config.CheckRepositories(); err != nil {
 		allErrors = append(allErrors, err.Error())
 	}
@@ -73,26 +86,31 @@ func (c *Cmd) Run(_ *kong.Context) error {
 	utils.OutputPrintf("\nDependencies:\n")
 
 	for name, value := range c.Config.Dependencies {
-		utils.OutputPrintf("- %s: %s\n", name, value)
+		utils.OutputPrintf("- %s: %s%s\n", name, value, c.Config.DescribeSource(configtypes.SectionDependencies, name))
 	}
 
 	if c.Config.Subcommands != nil {
 		utils.OutputPrintf("\nSubcommands:\n")
 
 		if c.Config.Subcommands.Render != "" {
-			utils.OutputPrintf("- render: %s\n", c.Config.Subcommands.Render)
+			utils.OutputPrintf("- render: %s%s\n", c.Config.Subcommands.Render, c.Config.DescribeSource(configtypes.SectionSubcommands, "render"))
 		}
 
 		if c.Config.Subcommands.Validate != "" {
-			utils.OutputPrintf("- validate: %s\n", c.Config.Subcommands.Validate)
+			utils.OutputPrintf("- validate: %s%s\n", c.Config.Subcommands.Validate, c.Config.DescribeSource(configtypes.SectionSubcommands, "validate"))
 		}
 	}
 
+	if c.Config.Validation != nil && c.Config.Validation.Validator != "" {
+		utils.OutputPrintf("\nValidation:\n")
+		utils.OutputPrintf("- validator: %s%s\n", c.Config.Validation.Validator, c.Config.DescribeSource(configtypes.SectionValidation, "validator"))
+	}
+
 	if len(c.Config.Repositories) > 0 {
 		utils.OutputPrintf("\nRepositories:\n")
 
 		for name, path := range c.Config.Repositories {
-			utils.OutputPrintf("- %s: %s\n", name, path)
+			utils.OutputPrintf("- %s: %s%s\n", name, path, c.Config.DescribeSource(configtypes.SectionRepositories, name))
 		}
 	}
 
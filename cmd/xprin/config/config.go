@@ -29,10 +29,10 @@ import (
 
 // Cmd represents the config subcommand.
 type Cmd struct {
-	Check      bool                `help:"Check dependencies and repository configuration"`
-	Config     *configtypes.Config `kong:"-"`
-	ConfigPath string              `kong:"-"`
-	fs         afero.Fs
+	Check       bool                `help:"Check dependencies and repository configuration"`
+	Config      *configtypes.Config `kong:"-"`
+	ConfigPaths []string            `kong:"-"`
+	fs          afero.Fs
 }
 
 // AfterApply implements kong.AfterApply.
@@ -46,55 +46,93 @@ func (c *Cmd) Run(_ *kong.Context) error {
 	// combine all error messages
 	var allErrors []string
 
-	if c.ConfigPath == "" {
+	switch len(c.ConfigPaths) {
+	case 0:
 		utils.OutputPrintf("No configuration file provided, using detected dependencies\n")
-	} else {
-		utils.OutputPrintf("Configuration file: %s\n\n", c.ConfigPath)
+	case 1:
+		utils.OutputPrintf("Configuration file: %s\n\n", c.ConfigPaths[0])
+	default:
+		utils.OutputPrintf("Configuration files (lowest to highest precedence):\n")
 
-		if c.Check {
-			if err := c.Config.CheckDependencies(); err != nil {
-				allErrors = append(allErrors, err.Error())
-			}
+		for _, path := range c.ConfigPaths {
+			utils.OutputPrintf("- %s\n", path)
+		}
+
+		utils.OutputPrintf("\n")
+	}
 
-			if err := c.Config.CheckSubcommands(); err != nil {
-				allErrors = append(allErrors, err.Error())
-			}
+	if len(c.ConfigPaths) > 0 && c.Check {
+		if err := c.Config.CheckDependencies(); err != nil {
+			allErrors = append(allErrors, err.Error())
+		}
 
-			if err := c.Config.CheckRepositories(); err != nil {
-				allErrors = append(allErrors, err.Error())
-			}
+		if err := c.Config.CheckSubcommands(); err != nil {
+			allErrors = append(allErrors, err.Error())
+		}
 
-			if len(allErrors) > 0 {
-				return fmt.Errorf("configuration check failed:\n%s", strings.Join(allErrors, "\n"))
-			}
+		if err := c.Config.CheckValidation(); err != nil {
+			allErrors = append(allErrors, err.Error())
+		}
 
-			utils.OutputPrintf("Configuration check successful\n")
+		if err := c.Config.CheckRepositories(); err != nil {
+			allErrors = append(allErrors, err.Error())
 		}
+
+		if len(allErrors) > 0 {
+			return fmt.Errorf("configuration check failed:\n%s", strings.Join(allErrors, "\n"))
+		}
+
+		utils.OutputPrintf("Configuration check successful\n")
 	}
 
 	utils.OutputPrintf("\nDependencies:\n")
 
 	for name, value := range c.Config.Dependencies {
-		utils.OutputPrintf("- %s: %s\n", name, value)
+		utils.OutputPrintf("- %s: %s%s\n", name, value, c.Config.DescribeSource(configtypes.SectionDependencies, name))
 	}
 
 	if c.Config.Subcommands != nil {
 		utils.OutputPrintf("\nSubcommands:\n")
 
 		if c.Config.Subcommands.Render != "" {
-			utils.OutputPrintf("- render: %s\n", c.Config.Subcommands.Render)
+			utils.OutputPrintf("- render: %s%s\n", c.Config.Subcommands.Render, c.Config.DescribeSource(configtypes.SectionSubcommands, "render"))
 		}
 
 		if c.Config.Subcommands.Validate != "" {
-			utils.OutputPrintf("- validate: %s\n", c.Config.Subcommands.Validate)
+			utils.OutputPrintf("- validate: %s%s\n", c.Config.Subcommands.Validate, c.Config.DescribeSource(configtypes.SectionSubcommands, "validate"))
 		}
 	}
 
+	if c.Config.Validation != nil && c.Config.Validation.Validator != "" {
+		utils.OutputPrintf("\nValidation:\n")
+		utils.OutputPrintf("- validator: %s%s\n", c.Config.Validation.Validator, c.Config.DescribeSource(configtypes.SectionValidation, "validator"))
+	}
+
 	if len(c.Config.Repositories) > 0 {
 		utils.OutputPrintf("\nRepositories:\n")
 
 		for name, path := range c.Config.Repositories {
-			utils.OutputPrintf("- %s: %s\n", name, path)
+			utils.OutputPrintf("- %s: %s%s\n", name, path, c.Config.DescribeSource(configtypes.SectionRepositories, name))
+		}
+	}
+
+	if len(c.Config.Vars) > 0 {
+		utils.OutputPrintf("\nVars:\n")
+
+		for name, value := range c.Config.Vars {
+			utils.OutputPrintf("- %s: %s%s\n", name, value, c.Config.DescribeSource(configtypes.SectionVars, name))
+		}
+	}
+
+	if c.Config.Discovery != nil && (len(c.Config.Discovery.Include) > 0 || len(c.Config.Discovery.Exclude) > 0) {
+		utils.OutputPrintf("\nDiscovery:\n")
+
+		if len(c.Config.Discovery.Include) > 0 {
+			utils.OutputPrintf("- include: %s%s\n", strings.Join(c.Config.Discovery.Include, ", "), c.Config.DescribeSource(configtypes.SectionDiscovery, "include"))
+		}
+
+		if len(c.Config.Discovery.Exclude) > 0 {
+			utils.OutputPrintf("- exclude: %s%s\n", strings.Join(c.Config.Discovery.Exclude, ", "), c.Config.DescribeSource(configtypes.SectionDiscovery, "exclude"))
 		}
 	}
 
@@ -31,9 +31,9 @@ func TestCmd_Run(t *testing.T) {
 	cfg := &internalcfg.Config{}
 
 	cmd := &Cmd{
-		Check:      false,
-		Config:     cfg,
-		ConfigPath: configPath,
+		Check:       false,
+		Config:      cfg,
+		ConfigPaths: []string{configPath},
 	}
 
 	// Test that Run method works
@@ -65,12 +65,19 @@ func TestOperation_Run(t *testing.T) {
 		Repositories: map[string]string{
 			"test-repo": repoPath, // using the created test repository
 		},
+		Vars: map[string]string{
+			"branch": "main",
+		},
+		Discovery: &internalcfg.Discovery{
+			Exclude: []string{"drafts/"},
+		},
 	}
 
 	tests := []struct {
 		name           string
 		cmd            *Cmd
 		config         *internalcfg.Config
+		configPaths    []string
 		wantOutput     []string
 		wantErr        bool
 		wantErrContain string
@@ -85,6 +92,10 @@ func TestOperation_Run(t *testing.T) {
 				"test-repo:",
 				"Dependencies:",
 				"crossplane:",
+				"Vars:",
+				"- branch: main",
+				"Discovery:",
+				"- exclude: drafts/",
 			},
 		},
 		{
@@ -207,13 +218,64 @@ func TestOperation_Run(t *testing.T) {
 				"- validate: validate --bar",
 			},
 		},
+		{
+			name: "layered config shows files and sources",
+			cmd:  &Cmd{},
+			config: &internalcfg.Config{
+				Dependencies: map[string]string{
+					"crossplane": "go",
+				},
+				Subcommands: &internalcfg.Subcommands{
+					Render:   internalcfg.DefaultRenderCmd,
+					Validate: "beta validate",
+				},
+				Vars: map[string]string{
+					"branch": "main",
+				},
+				Sources: map[string]string{
+					"dependencies.crossplane": "env XPRIN_DEPENDENCIES_CROSSPLANE",
+					"subcommands.render":      internalcfg.SourceDefault,
+					"subcommands.validate":    "/repo/.xprin.yaml",
+					"vars.branch":             "/home/user/.config/xprin.yaml",
+				},
+			},
+			configPaths: []string{"/home/user/.config/xprin.yaml", "/repo/.xprin.yaml"},
+			wantOutput: []string{
+				"Configuration files (lowest to highest precedence):\n- /home/user/.config/xprin.yaml\n- /repo/.xprin.yaml",
+				"- crossplane: go (from env XPRIN_DEPENDENCIES_CROSSPLANE)",
+				"- render: " + internalcfg.DefaultRenderCmd + " (from default)",
+				"- validate: beta validate (from /repo/.xprin.yaml)",
+				"- branch: main (from /home/user/.config/xprin.yaml)",
+			},
+		},
+		{
+			name: "no config file",
+			cmd:  &Cmd{},
+			config: &internalcfg.Config{
+				Dependencies: map[string]string{
+					"crossplane": "crossplane",
+				},
+				Sources: map[string]string{
+					"dependencies.crossplane": internalcfg.SourcePath,
+				},
+			},
+			configPaths: []string{},
+			wantOutput: []string{
+				"No configuration file provided, using detected dependencies",
+				"- crossplane: crossplane (from PATH)",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Set config in the command
 			tt.cmd.Config = tt.config
-			tt.cmd.ConfigPath = "/test/config.yaml"
+			if tt.configPaths != nil {
+				tt.cmd.ConfigPaths = tt.configPaths
+			} else {
+				tt.cmd.ConfigPaths = []string{"/test/config.yaml"}
+			}
 
 			// Capture both stdout and stderr during Run
 			var err error
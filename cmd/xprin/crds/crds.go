@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crds provides the crds subcommand for the xprin tool.
+package crds
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/packagecache"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the crds subcommand.
+type Cmd struct {
+	Pull PullCmd `cmd:"" help:"Download the packages referenced by crds-from inputs into the CRDs cache, so that tests run offline"`
+}
+
+// PullCmd represents the crds pull subcommand.
+type PullCmd struct {
+	Targets      []string            `arg:""                                                                                                                            help:"One or more test targets: individual files, directories, or recursive directories (e.g., 'tests/aws/...')"`
+	Include      []string            `help:"File name patterns of testsuite files (e.g. '*_xprin.yaml'). Replaces the configured and default patterns."                 name:"include"                                                                                                   placeholder:"PATTERN,..."`
+	Exclude      []string            `help:"Gitignore-style patterns of paths to skip during discovery, in addition to the configured patterns and .xprinignore files." name:"exclude"                                                                                                   placeholder:"PATTERN,..."`
+	CRDsCacheDir string              `default:"~/.crossplane/cache"                                                                                                     help:"Directory of the package cache to download the packages into."                                             name:"crds-cache-dir"     placeholder:"PATH"`
+	Debug        bool                `help:"Show detailed debug information about test discovery"`
+	Config       *internalcfg.Config `kong:"-"`
+	fs           afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *PullCmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// SearchTarget returns the target the project configuration is discovered from: the first target, if any.
+func (c *PullCmd) SearchTarget() string {
+	if len(c.Targets) == 0 {
+		return ""
+	}
+
+	return c.Targets[0]
+}
+
+// Run executes the crds pull subcommand: it downloads the packages referenced by the crds-from inputs of the test
+// cases of the targets that are not in the CRDs cache yet.
+func (c *PullCmd) Run(_ *kong.Context) error {
+	if err := processor.CheckIncludePatterns(c.Include); err != nil {
+		return err
+	}
+
+	cacheDir, err := utils.ExpandTildeAbs(c.CRDsCacheDir)
+	if err != nil {
+		return fmt.Errorf("invalid --crds-cache-dir %s: %w", c.CRDsCacheDir, err)
+	}
+
+	packages, err := processor.CRDsPackages(c.fs, c.Targets, c.newOptions(c.Config))
+	if err != nil {
+		return err
+	}
+
+	if len(packages) == 0 {
+		utils.OutputPrintf("No packages referenced by crds-from inputs\n")
+		return nil
+	}
+
+	if err := packagecache.Pull(c.fs, cacheDir, packages, os.Stdout); err != nil {
+		return fmt.Errorf("failed to pull packages into the CRDs cache %s: %w", cacheDir, err)
+	}
+
+	utils.OutputPrintf("Packages in the CRDs cache %s:\n", cacheDir)
+
+	for _, pkg := range packages {
+		utils.OutputPrintf("- %s\n", pkg)
+	}
+
+	return nil
+}
+
+// newOptions creates the testexecutionUtils.Options that resolve the crds-from inputs of the test cases of the targets.
+func (c *PullCmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	options := &testexecutionUtils.Options{
+		Dependencies: cfg.Dependencies,
+		Repositories: cfg.Repositories,
+		Debug:        c.Debug,
+		ConfigVars:   cfg.Vars,
+		Include:      c.Include,
+		Exclude:      slices.Clone(c.Exclude),
+	}
+
+	if cfg.Discovery != nil {
+		if len(options.Include) == 0 {
+			options.Include = cfg.Discovery.Include
+		}
+
+		options.Exclude = append(slices.Clone(cfg.Discovery.Exclude), c.Exclude...)
+	}
+
+	return options
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crds
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/packagecache"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestPullCmd_Run(t *testing.T) {
+	const provider = "xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"
+
+	dir := t.TempDir()
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), "tests:\n  - name: aws\n    inputs:\n      xr: xr.yaml\n      composition: composition.yaml\n      functions: functions\n      crds-from: ["+provider+"]\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "gcp_xprin.yml"), "tests:\n  - name: gcp\n    inputs:\n      xr: xr.yaml\n      composition: composition.yaml\n      functions: functions\n")
+
+	// Packages that are already cached are not downloaded, so this runs offline
+	cacheDir := filepath.Join(dir, "cache")
+	unittestsUtils.WriteTestFile(t, packagecache.Path(cacheDir, provider), "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: buckets.s3.aws.upbound.io\n")
+
+	t.Run("pulls the packages of the crds-from inputs", func(t *testing.T) {
+		cmd := &PullCmd{Targets: []string{dir}, CRDsCacheDir: cacheDir, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Packages in the CRDs cache "+cacheDir+":\n- "+provider+"\n", output)
+	})
+
+	t.Run("no crds-from inputs", func(t *testing.T) {
+		cmd := &PullCmd{Targets: []string{dir}, Include: []string{"*.yml"}, CRDsCacheDir: cacheDir, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "No packages referenced by crds-from inputs\n", output)
+	})
+
+	t.Run("invalid include patterns", func(t *testing.T) {
+		cmd := &PullCmd{Targets: []string{dir}, Include: []string{"["}, CRDsCacheDir: cacheDir, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+		require.Error(t, cmd.Run(&kong.Context{}))
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package explain provides the explain subcommand for the xprin tool.
+package explain
+
+import (
+	"strings"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the explain subcommand.
+type Cmd struct {
+	Suite    string              `arg:""                                                                                                                     help:"Testsuite file to explain (e.g., 'tests/aws_xprin.yaml')"`
+	Test     string              `help:"Only explain the test case with this name or ID."                                                                    name:"test"                                                     placeholder:"NAME"`
+	Vars     map[string]string   `help:"Set a template variable available as {{ .Vars.KEY }} (repeatable). Overrides --var-file, testsuite and config vars." mapsep:"none"                                                   name:"var"         placeholder:"KEY=VALUE"`
+	VarFiles []string            `help:"Load template variables from a YAML file (repeatable; later files override earlier ones)."                           name:"var-file"                                                 placeholder:"PATH"`
+	Debug    bool                `help:"Show detailed debug information about path resolution"`
+	Config   *internalcfg.Config `kong:"-"`
+	fs       afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// SearchTarget returns the target the project configuration is discovered from: the testsuite file.
+func (c *Cmd) SearchTarget() string {
+	return c.Suite
+}
+
+// Run executes the explain subcommand: it prints the resolved specification of the test cases of the testsuite and the
+// commands they would run, without running anything.
+func (c *Cmd) Run(_ *kong.Context) error {
+	if err := c.loadVarFiles(); err != nil {
+		return err
+	}
+
+	return processor.Explain(c.fs, c.Suite, c.newOptions(c.Config), c.Test)
+}
+
+// newOptions creates the testexecutionUtils.Options the test cases are resolved with.
+func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	options := &testexecutionUtils.Options{
+		Dependencies:     cfg.Dependencies,
+		Repositories:     cfg.Repositories,
+		Debug:            c.Debug,
+		BuiltinValidator: cfg.Validation != nil && cfg.Validation.Validator == internalcfg.ValidatorBuiltin,
+		ConfigVars:       cfg.Vars,
+		Vars:             c.Vars,
+	}
+
+	if cfg.Subcommands != nil {
+		options.Render = strings.Fields(cfg.Subcommands.Render)
+		options.Validate = strings.Fields(cfg.Subcommands.Validate)
+	}
+
+	return options
+}
+
+// loadVarFiles loads the --var-file files and merges them under the --var variables.
+func (c *Cmd) loadVarFiles() error {
+	if len(c.VarFiles) == 0 {
+		return nil
+	}
+
+	layers := make([]map[string]string, 0, len(c.VarFiles)+1)
+
+	for _, path := range c.VarFiles {
+		vars, err := testexecutionUtils.LoadVarsFile(c.fs, path)
+		if err != nil {
+			return err
+		}
+
+		layers = append(layers, vars)
+	}
+
+	c.Vars = testexecutionUtils.MergeVars(append(layers, c.Vars)...)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCmd_Run(t *testing.T) {
+	dir := t.TempDir()
+	file := unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), `tests:
+  - name: aws
+    inputs:
+      xr: "{{ .Vars.env }}/xr.yaml"
+      composition: composition.yaml
+      functions: functions.yaml
+  - name: gcp
+    inputs:
+      xr: gcp.yaml
+      composition: composition.yaml
+      functions: functions.yaml
+`)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "prod", "xr.yaml"), "")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "composition.yaml"), "")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "functions.yaml"), "")
+	varFile := unittestsUtils.WriteTestFile(t, filepath.Join(dir, "vars.yaml"), "env: dev\n")
+
+	cfg := &internalcfg.Config{
+		Dependencies: map[string]string{"crossplane": "/usr/local/bin/crossplane"},
+		Subcommands:  &internalcfg.Subcommands{Render: "render", Validate: "beta validate"},
+	}
+
+	cmd := &Cmd{
+		Suite:    file,
+		Test:     "aws",
+		Vars:     map[string]string{"env": "prod"},
+		VarFiles: []string{varFile},
+		Config:   cfg,
+		fs:       afero.NewOsFs(),
+	}
+
+	var err error
+
+	output := unittestsUtils.CaptureStdout(func() {
+		err = cmd.Run(&kong.Context{})
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "=== "+file+": aws\n")
+	assert.Contains(t, output, "  xr: "+filepath.Join(dir, "prod", "xr.yaml")+" # rendered from {{.Vars.env}}/xr.yaml, expanded from prod/xr.yaml\n")
+	assert.Contains(t, output, "render: /usr/local/bin/crossplane render $TESTCASE_DIR/inputs/xr/xr.yaml ")
+	assert.NotContains(t, output, ": gcp\n")
+}
+
+func TestNewOptions(t *testing.T) {
+	cfg := &internalcfg.Config{
+		Subcommands: &internalcfg.Subcommands{Render: "render --include-full-xr", Validate: "beta validate"},
+		Validation:  &internalcfg.Validation{Validator: internalcfg.ValidatorBuiltin},
+		Vars:        map[string]string{"env": "dev"},
+	}
+
+	options := (&Cmd{Vars: map[string]string{"env": "prod"}}).newOptions(cfg)
+	assert.Equal(t, []string{"render", "--include-full-xr"}, options.Render)
+	assert.Equal(t, []string{"beta", "validate"}, options.Validate)
+	assert.True(t, options.BuiltinValidator)
+	assert.Equal(t, map[string]string{"env": "dev"}, options.ConfigVars)
+	assert.Equal(t, map[string]string{"env": "prod"}, options.Vars)
+}
+
+func TestSearchTarget(t *testing.T) {
+	assert.Equal(t, "tests/aws_xprin.yaml", (&Cmd{Suite: "tests/aws_xprin.yaml"}).SearchTarget())
+}
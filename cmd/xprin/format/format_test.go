@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCmd_Run(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "aws_xprin.yaml")
+	unittestsUtils.WriteTestFile(t, file, "tests:\n- name: \"aws\"\n")
+
+	t.Run("check fails on unformatted files", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Check: true, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.EqualError(t, err, "1 testsuite file not formatted (run xprin fmt to format them)")
+		assert.Equal(t, file+" is not formatted\nFAIL\n", output)
+	})
+
+	t.Run("formats the files", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Formatted "+file+"\n", output)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "tests:\n  - name: aws\n", string(data))
+	})
+
+	t.Run("invalid include pattern", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Include: []string{"[a-"}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+		require.ErrorContains(t, cmd.Run(&kong.Context{}), "invalid include pattern")
+	})
+}
+
+func TestNewOptions(t *testing.T) {
+	cfg := &internalcfg.Config{Discovery: &internalcfg.Discovery{Include: []string{"*.test.yaml"}, Exclude: []string{"drafts/"}}}
+
+	options := (&Cmd{Debug: true, Exclude: []string{"legacy/"}}).newOptions(cfg)
+	assert.True(t, options.Debug)
+	assert.Equal(t, []string{"*.test.yaml"}, options.Include)
+	assert.Equal(t, []string{"drafts/", "legacy/"}, options.Exclude)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package initsuite provides the init subcommand for the xprin tool.
+package initsuite
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane-contrib/xprin/internal/api"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/scaffold"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// defaultTestSuiteFile returns the name of the testsuite file written in the directory when --output is not set,
+// <dir>_xprin.yaml after the name of the directory.
+func defaultTestSuiteFile(dir string) string {
+	name := filepath.Base(dir)
+	if name == string(filepath.Separator) {
+		name = "testsuite"
+	}
+
+	return name + "_xprin.yaml"
+}
+
+// Cmd represents the init subcommand.
+type Cmd struct {
+	Dir          string              `arg:""                                                                                                         default:"."                                                                                                     help:"Directory with the compositions, XRDs, functions files and example XRs or claims to test (default the working directory)" optional:""`
+	Output       string              `help:"Testsuite file to write (default <dir>_xprin.yaml in the directory)."                                    name:"output"                                                                                                   placeholder:"PATH"                                                                                                              short:"o"`
+	Composition  string              `help:"Composition to test, relative to the directory, when several are found (default the first one)."         name:"composition"                                                                                              placeholder:"PATH"`
+	Functions    string              `help:"Functions file or directory, relative to the directory, when several are found (default the first one)." name:"functions"                                                                                                placeholder:"PATH"`
+	NoRender     bool                `help:"Do not render the test cases to generate starter Count and Exists assertions."                           name:"no-render"`
+	Yes          bool                `help:"Use the defaults instead of prompting for them."                                                         short:"y"`
+	Force        bool                `help:"Overwrite the testsuite file when it exists."`
+	CRDsCacheDir string              `default:"~/.crossplane/cache"                                                                                  help:"Directory of the package cache the CRDs of crds-from inputs are loaded from (filled by xprin crds pull)." name:"crds-cache-dir"                                                                                                           placeholder:"PATH"`
+	Config       *internalcfg.Config `kong:"-"`
+	fs           afero.Fs
+	in           *bufio.Reader
+	interactive  bool
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	c.in = bufio.NewReader(os.Stdin)
+
+	// Prompt only when a user can answer
+	if info, err := os.Stdin.Stat(); err == nil {
+		c.interactive = info.Mode()&os.ModeCharDevice != 0
+	}
+
+	return nil
+}
+
+// SearchTarget returns the target the project configuration is discovered from: the directory of the testsuite.
+func (c *Cmd) SearchTarget() string {
+	return c.Dir
+}
+
+// Run executes the init subcommand: it detects the inputs in the directory, prompts for the choices the flags do
+// not make (unless --yes is set or the input is not a terminal), renders the test cases for starter assertions and
+// writes the testsuite file.
+func (c *Cmd) Run(_ *kong.Context) error {
+	dir, err := utils.ExpandTildeAbs(c.Dir)
+	if err != nil {
+		return fmt.Errorf("invalid directory %s: %w", c.Dir, err)
+	}
+
+	project, err := scaffold.Detect(c.fs, dir)
+	if err != nil {
+		return err
+	}
+
+	if c.Composition == "" && len(project.Compositions) > 1 {
+		paths := make([]string, 0, len(project.Compositions))
+		for _, composition := range project.Compositions {
+			paths = append(paths, composition.Path)
+		}
+
+		if c.Composition, err = c.choose("Composition to test", paths); err != nil {
+			return err
+		}
+	}
+
+	if c.Functions == "" && len(project.Functions) > 1 {
+		if c.Functions, err = c.choose("Functions file", project.Functions); err != nil {
+			return err
+		}
+	}
+
+	output, err := c.testSuiteFile(dir)
+	if err != nil {
+		return err
+	}
+
+	if exists, _ := afero.Exists(c.fs, output); exists && !c.Force {
+		return fmt.Errorf("testsuite file %s already exists (use --force to overwrite it)", output)
+	}
+
+	spec, err := project.TestSuite(scaffold.Options{Composition: c.Composition, Functions: c.Functions, Base: filepath.Dir(output)})
+	if err != nil {
+		return err
+	}
+
+	render := !c.NoRender
+	if render && c.interactive && !c.Yes {
+		if render, err = c.confirm("Render the test cases to generate starter assertions"); err != nil {
+			return err
+		}
+	}
+
+	if render {
+		if err := c.addStarterAssertions(output, spec); err != nil {
+			return err
+		}
+	}
+
+	data, err := scaffold.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	if err := c.fs.MkdirAll(filepath.Dir(output), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory of %s: %w", output, err)
+	}
+
+	if err := afero.WriteFile(c.fs, output, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write testsuite file %s: %w", output, err)
+	}
+
+	utils.OutputPrintf("Wrote %s with %d test cases\n", output, len(spec.Tests))
+
+	return nil
+}
+
+// testSuiteFile returns the absolute path of the testsuite file to write: --output, the answer to the prompt or
+// <dir>_xprin.yaml in the directory (see defaultTestSuiteFile).
+func (c *Cmd) testSuiteFile(dir string) (string, error) {
+	output := c.Output
+
+	if output == "" && c.interactive && !c.Yes {
+		answer, err := c.prompt(fmt.Sprintf("Testsuite file [%s]: ", defaultTestSuiteFile(dir)))
+		if err != nil {
+			return "", err
+		}
+
+		if answer != "" {
+			output = filepath.Join(dir, answer)
+		}
+	}
+
+	if output == "" {
+		output = filepath.Join(dir, defaultTestSuiteFile(dir))
+	}
+
+	path, err := utils.ExpandTildeAbs(output)
+	if err != nil {
+		return "", fmt.Errorf("invalid --output %s: %w", output, err)
+	}
+
+	return path, nil
+}
+
+// addStarterAssertions renders the test cases of the testsuite and sets their starter assertions. Test cases that
+// fail to render keep no assertions, with a warning.
+func (c *Cmd) addStarterAssertions(testSuiteFile string, spec *api.TestSuiteSpec) error {
+	cacheDir, err := utils.ExpandTildeAbs(c.CRDsCacheDir)
+	if err != nil {
+		return fmt.Errorf("invalid --crds-cache-dir %s: %w", c.CRDsCacheDir, err)
+	}
+
+	rendered, err := scaffold.Render(c.newOptions(c.Config, cacheDir), testSuiteFile, spec, io.Discard)
+	if err != nil {
+		return fmt.Errorf("failed to render the test cases: %w", err)
+	}
+
+	for i, testCase := range spec.Tests {
+		resources, ok := rendered[testCase.Name]
+		if !ok {
+			utils.WarningPrintf("Test case '%s' failed to render, so it has no assertions (run xprin test %s for details)\n", testCase.Name, testSuiteFile)
+			continue
+		}
+
+		spec.Tests[i].Assertions = scaffold.StarterAssertions(resources)
+	}
+
+	return nil
+}
+
+// newOptions creates the testexecutionUtils.Options the test cases are rendered with.
+func (c *Cmd) newOptions(cfg *internalcfg.Config, cacheDir string) *testexecutionUtils.Options {
+	options := &testexecutionUtils.Options{
+		Dependencies:     cfg.Dependencies,
+		Repositories:     cfg.Repositories,
+		BuiltinValidator: cfg.Validation != nil && cfg.Validation.Validator == internalcfg.ValidatorBuiltin,
+		ConfigVars:       cfg.Vars,
+		CRDsCacheDir:     cacheDir,
+	}
+
+	if cfg.Subcommands != nil {
+		options.Render = strings.Fields(cfg.Subcommands.Render)
+		options.Validate = strings.Fields(cfg.Subcommands.Validate)
+	}
+
+	return options
+}
+
+// choose returns one of the options: the one the user picks by number when interactive, or the first one.
+func (c *Cmd) choose(label string, options []string) (string, error) {
+	if !c.interactive || c.Yes {
+		return options[0], nil
+	}
+
+	utils.OutputPrintf("%s:\n", label)
+
+	for i, option := range options {
+		utils.OutputPrintf("  %d) %s\n", i+1, option)
+	}
+
+	for {
+		answer, err := c.prompt("Choice [1]: ")
+		if err != nil {
+			return "", err
+		}
+
+		if answer == "" {
+			return options[0], nil
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return options[n-1], nil
+		}
+
+		utils.OutputPrintf("Enter a number between 1 and %d\n", len(options))
+	}
+}
+
+// confirm asks a yes or no question, yes by default.
+func (c *Cmd) confirm(question string) (bool, error) {
+	answer, err := c.prompt(question + "? [Y/n]: ")
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "n", "no":
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// prompt prints a prompt and returns the trimmed line the user answers with, which is empty at the end of the input.
+func (c *Cmd) prompt(prompt string) (string, error) {
+	utils.OutputPrintf("%s", prompt)
+
+	line, err := c.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
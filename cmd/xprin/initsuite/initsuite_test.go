@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initsuite
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane-contrib/xprin/internal/api"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"sigs.k8s.io/yaml"
+)
+
+// newTestDir writes a directory with two compositions, two functions files and an XR.
+func newTestDir(t *testing.T) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "buckets")
+
+	for _, name := range []string{"a.yaml", "b.yaml"} {
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "compositions", name), "apiVersion: apiextensions.crossplane.io/v1\nkind: Composition\nmetadata:\n  name: "+name+"\nspec:\n  compositeTypeRef:\n    apiVersion: example.org/v1\n    kind: XBucket\n")
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "functions", name), "apiVersion: pkg.crossplane.io/v1beta1\nkind: Function\nmetadata:\n  name: function-"+name+"\n")
+	}
+
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "xr.yaml"), "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\n")
+
+	return dir
+}
+
+// readTestSuite reads a written testsuite file.
+func readTestSuite(t *testing.T, path string) *api.TestSuiteSpec {
+	t.Helper()
+
+	data, err := afero.ReadFile(afero.NewOsFs(), path)
+	require.NoError(t, err)
+
+	spec := &api.TestSuiteSpec{}
+	require.NoError(t, yaml.UnmarshalStrict(data, spec))
+
+	return spec
+}
+
+func TestCmd_Run(t *testing.T) {
+	t.Run("non-interactive defaults", func(t *testing.T) {
+		dir := newTestDir(t)
+		cmd := &Cmd{Dir: dir, NoRender: true, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Wrote "+filepath.Join(dir, "buckets_xprin.yaml")+" with 1 test cases\n", output)
+
+		spec := readTestSuite(t, filepath.Join(dir, "buckets_xprin.yaml"))
+		assert.Equal(t, "compositions/a.yaml", spec.Common.Inputs.Composition)
+		assert.Equal(t, "functions/a.yaml", spec.Common.Inputs.Functions)
+		assert.Equal(t, []api.TestCase{{Name: "bucket", Inputs: api.Inputs{XR: "xr.yaml"}}}, spec.Tests)
+	})
+
+	t.Run("flags", func(t *testing.T) {
+		dir := newTestDir(t)
+		output := filepath.Join(dir, "tests", "bucket_xprin.yaml")
+		cmd := &Cmd{Dir: dir, Output: output, Composition: "compositions/b.yaml", Functions: "functions", NoRender: true, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		unittestsUtils.CaptureStdout(func() {
+			require.NoError(t, cmd.Run(&kong.Context{}))
+		})
+
+		spec := readTestSuite(t, output)
+		assert.Equal(t, "../compositions/b.yaml", spec.Common.Inputs.Composition)
+		assert.Equal(t, "../functions", spec.Common.Inputs.Functions)
+		assert.Equal(t, "../xr.yaml", spec.Tests[0].Inputs.XR)
+	})
+
+	t.Run("interactive", func(t *testing.T) {
+		dir := newTestDir(t)
+		cmd := &Cmd{
+			Dir:         dir,
+			Config:      &internalcfg.Config{},
+			fs:          afero.NewOsFs(),
+			in:          bufio.NewReader(strings.NewReader("3\n2\n\nbucket_xprin.yaml\nn\n")),
+			interactive: true,
+		}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output, "Composition to test:\n  1) compositions/a.yaml\n  2) compositions/b.yaml\nChoice [1]: Enter a number between 1 and 2\nChoice [1]: ")
+		assert.Contains(t, output, "Testsuite file [buckets_xprin.yaml]: Render the test cases to generate starter assertions? [Y/n]: ")
+
+		spec := readTestSuite(t, filepath.Join(dir, "bucket_xprin.yaml"))
+		assert.Equal(t, "compositions/b.yaml", spec.Common.Inputs.Composition)
+		assert.Equal(t, "functions/a.yaml", spec.Common.Inputs.Functions)
+	})
+
+	t.Run("existing testsuite file", func(t *testing.T) {
+		dir := newTestDir(t)
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "buckets_xprin.yaml"), "tests: []\n")
+
+		cmd := &Cmd{Dir: dir, NoRender: true, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+		require.ErrorContains(t, cmd.Run(&kong.Context{}), "already exists (use --force to overwrite it)")
+
+		cmd.Force = true
+
+		unittestsUtils.CaptureStdout(func() {
+			require.NoError(t, cmd.Run(&kong.Context{}))
+		})
+		assert.Len(t, readTestSuite(t, filepath.Join(dir, "buckets_xprin.yaml")).Tests, 1)
+	})
+
+	t.Run("failing renders leave no assertions", func(t *testing.T) {
+		dir := newTestDir(t)
+		cmd := &Cmd{Dir: dir, Yes: true, CRDsCacheDir: filepath.Join(dir, "cache"), Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureOutput(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output.Stderr, "Test case 'bucket' failed to render, so it has no assertions")
+		assert.False(t, readTestSuite(t, filepath.Join(dir, "buckets_xprin.yaml")).Tests[0].Assertions.HasAssertions())
+	})
+}
+
+func TestDefaultTestSuiteFile(t *testing.T) {
+	assert.Equal(t, "buckets_xprin.yaml", defaultTestSuiteFile("/apis/buckets"))
+	assert.Equal(t, "testsuite_xprin.yaml", defaultTestSuiteFile("/"))
+}
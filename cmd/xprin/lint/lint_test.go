@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCmd_Run(t *testing.T) {
+	dir := t.TempDir()
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), "tests:\n  - name: aws\n    inputs:\n      xr: xr.yaml\n      composition: composition.yaml\n      functions: functions\n")
+
+	t.Run("reports the problems of the testsuite files", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.EqualError(t, err, "lint found 3 errors")
+		assert.Contains(t, output, filepath.Join(dir, "aws_xprin.yaml")+":4: error: xr xr.yaml does not exist (missing-input)\n")
+		assert.Contains(t, output, "Linted 1 testsuite file: 3 errors, 0 warnings\nFAIL\n")
+	})
+
+	t.Run("include patterns", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Include: []string{"*.yml"}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Linted 0 testsuite files: 0 errors, 0 warnings\n", output)
+	})
+
+	t.Run("invalid include pattern", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Include: []string{"[a-"}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+		require.ErrorContains(t, cmd.Run(&kong.Context{}), "invalid include pattern")
+	})
+}
+
+func TestNewOptions(t *testing.T) {
+	cfg := &internalcfg.Config{Discovery: &internalcfg.Discovery{Include: []string{"*.test.yaml"}, Exclude: []string{"drafts/"}}}
+
+	options := (&Cmd{Debug: true, Exclude: []string{"legacy/"}}).newOptions(cfg)
+	assert.True(t, options.Debug)
+	assert.Equal(t, []string{"*.test.yaml"}, options.Include)
+	assert.Equal(t, []string{"drafts/", "legacy/"}, options.Exclude)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package list provides the list subcommand for the xprin tool.
+package list
+
+import (
+	"slices"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the list subcommand.
+type Cmd struct {
+	Targets []string            `arg:""                                                                                                                            help:"One or more test targets: individual files, directories, or recursive directories (e.g., 'tests/aws/...')"`
+	Include []string            `help:"File name patterns of testsuite files (e.g. '*_xprin.yaml'). Replaces the configured and default patterns."                 name:"include"                                                                                                   placeholder:"PATTERN,..."`
+	Exclude []string            `help:"Gitignore-style patterns of paths to skip during discovery, in addition to the configured patterns and .xprinignore files." name:"exclude"                                                                                                   placeholder:"PATTERN,..."`
+	Debug   bool                `help:"Show detailed debug information about test discovery"`
+	Config  *internalcfg.Config `kong:"-"`
+	fs      afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// SearchTarget returns the target the project configuration is discovered from: the first target, if any.
+func (c *Cmd) SearchTarget() string {
+	if len(c.Targets) == 0 {
+		return ""
+	}
+
+	return c.Targets[0]
+}
+
+// Run executes the list subcommand: it prints the discovered testsuite files and their test cases without running them.
+func (c *Cmd) Run(_ *kong.Context) error {
+	if err := processor.CheckIncludePatterns(c.Include); err != nil {
+		return err
+	}
+
+	return processor.ProcessTargets(c.fs, c.Targets, c.newOptions(c.Config))
+}
+
+// newOptions creates the testexecutionUtils.Options that list the test cases of the targets.
+func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	options := &testexecutionUtils.Options{
+		Dependencies: cfg.Dependencies,
+		Repositories: cfg.Repositories,
+		Debug:        c.Debug,
+		ConfigVars:   cfg.Vars,
+		List:         true,
+		Include:      c.Include,
+		Exclude:      slices.Clone(c.Exclude),
+	}
+
+	if cfg.Discovery != nil {
+		if len(options.Include) == 0 {
+			options.Include = cfg.Discovery.Include
+		}
+
+		options.Exclude = append(slices.Clone(cfg.Discovery.Exclude), c.Exclude...)
+	}
+
+	return options
+}
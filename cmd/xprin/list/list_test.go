@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCmd_Run(t *testing.T) {
+	dir := t.TempDir()
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), "tests:\n  - name: basic\n  - name: advanced\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "gcp_xprin.yml"), "tests:\n  - name: basic\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "drafts", "draft_xprin.yaml"), "tests:\n  - name: draft\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, ".xprinignore"), "drafts/\n")
+
+	t.Run("prints the discovered testsuite files and test cases", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir + "/..."}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+
+		aws := filepath.Join(dir, "aws_xprin.yaml")
+		gcp := filepath.Join(dir, "gcp_xprin.yml")
+		assert.Equal(t, aws+": basic\n"+aws+": advanced\n"+gcp+": basic\n", output)
+	})
+
+	t.Run("include patterns", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir + "/..."}, Include: []string{"*.yml"}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "gcp_xprin.yml")+": basic\n", output)
+	})
+
+	t.Run("invalid include pattern", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Include: []string{"[a-"}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		err := cmd.Run(&kong.Context{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid include pattern")
+	})
+}
+
+func TestNewOptions(t *testing.T) {
+	cfg := &internalcfg.Config{Discovery: &internalcfg.Discovery{Include: []string{"*.test.yaml"}, Exclude: []string{"drafts/"}}}
+
+	options := (&Cmd{Exclude: []string{"legacy/"}}).newOptions(cfg)
+	assert.True(t, options.List)
+	assert.Equal(t, []string{"*.test.yaml"}, options.Include)
+	assert.Equal(t, []string{"drafts/", "legacy/"}, options.Exclude)
+
+	options = (&Cmd{Include: []string{"xprin.yaml"}}).newOptions(cfg)
+	assert.Equal(t, []string{"xprin.yaml"}, options.Include)
+}
@@ -18,13 +18,19 @@ limitations under the License.
 package main
 
 import (
-	"errors"
 	"log"
 	"os"
 
 	"github.com/alecthomas/kong"
 	checkCmd "github.com/crossplane-contrib/xprin/cmd/xprin/check"
 	configCmd "github.com/crossplane-contrib/xprin/cmd/xprin/config"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/crds"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/explain"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/format"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/initsuite"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/lint"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/list"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/mutate"
 	"github.com/crossplane-contrib/xprin/cmd/xprin/test"
 	"github.com/crossplane-contrib/xprin/cmd/xprin/version"
 	internalConfig "github.com/crossplane-contrib/xprin/internal/config"
@@ -33,13 +39,26 @@ import (
 
 // CLI represents the command-line interface.
 type CLI struct {
-	ConfigFile string        `default:"~/.config/xprin.yaml" help:"Path to xprin config file"            short:"c" type:"path"`
+	ConfigFile string        `default:"~/.config/xprin.yaml" help:"Path to xprin config file"                                                                          short:"c"   type:"path"`
 	Check      checkCmd.Cmd  `cmd:""                         help:"Check dependencies and configuration"`
 	Config     configCmd.Cmd `cmd:""                         help:"Manage xprin configuration"`
+	CRDs       crds.Cmd      `cmd:""                         help:"Manage the CRDs cache"                                                                              name:"crds"`
+	Explain    explain.Cmd   `cmd:""                         help:"Print the resolved test cases of a testsuite and the commands they would run, without running them"`
+	Fmt        format.Cmd    `cmd:""                         help:"Rewrite the testsuite files in their canonical form"`
+	Init       initsuite.Cmd `cmd:""                         help:"Generate a starter testsuite file for a directory of compositions and examples"`
+	Lint       lint.Cmd      `cmd:""                         help:"Check the testsuite files for problems without running them"`
+	List       list.Cmd      `cmd:""                         help:"List the discovered testsuite files and test cases"`
+	Mutate     mutate.Cmd    `cmd:""                         help:"Run the tests with mutated compositions and report the mutations they do not detect"`
 	Test       test.Cmd      `cmd:""                         help:"Run Crossplane tests"`
 	Version    version.Cmd   `cmd:""                         help:"Print the version of xprin"`
 }
 
+// searchTargeter is implemented by the commands whose project configuration is discovered from one of their targets.
+type searchTargeter interface {
+	// SearchTarget returns the target the project configuration is discovered from, or "" for the working directory.
+	SearchTarget() string
+}
+
 func main() {
 	var cli CLI
 
@@ -49,28 +68,35 @@ func main() {
 		kong.UsageOnError(),
 	)
 
-	configPath := cli.ConfigFile
 	fs := afero.NewOsFs()
 
-	cfg, err := internalConfig.Load(fs, configPath)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			configPath = ""
+	// Project configuration discovery starts from the target of the command, or from the working directory
+	startDir := "."
+	if command, ok := ctx.Selected().Target.Addr().Interface().(searchTargeter); ok && command.SearchTarget() != "" {
+		startDir = internalConfig.SearchDirForTarget(fs, command.SearchTarget())
+	}
 
-			cfg, err = internalConfig.Fallback()
-			if err != nil {
-				log.Fatalf("%v", err)
-			}
-		} else {
-			log.Fatalf("Failed to load configuration: %v", err)
-		}
+	cfg, err := internalConfig.Resolve(fs, internalConfig.ResolveOptions{
+		UserConfigPath: cli.ConfigFile,
+		StartDir:       startDir,
+		Environ:        os.Environ(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
 	// Set config in the command structs
 	cli.Check.Config = cfg
-	cli.Check.ConfigPath = configPath
+	cli.Check.ConfigPaths = cfg.Files
 	cli.Config.Config = cfg
-	cli.Config.ConfigPath = configPath
+	cli.Config.ConfigPaths = cfg.Files
+	cli.CRDs.Pull.Config = cfg
+	cli.Explain.Config = cfg
+	cli.Fmt.Config = cfg
+	cli.Init.Config = cfg
+	cli.Lint.Config = cfg
+	cli.List.Config = cfg
+	cli.Mutate.Config = cfg
 	cli.Test.Config = cfg
 
 	// Run the selected command
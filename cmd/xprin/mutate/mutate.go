@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mutate provides the mutate subcommand for the xprin tool.
+package mutate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the mutate subcommand.
+type Cmd struct {
+	Targets      []string            `arg:""                                                                                                                            help:"One or more test targets: individual files, directories, or recursive directories (e.g., 'tests/aws/...')"`
+	Verbose      bool                `help:"Also show the mutations that were killed, with the test cases that killed them"                                             short:"v"`
+	Debug        bool                `help:"Show detailed debug information about test discovery, path resolution, mutations and execution"`
+	Vars         map[string]string   `help:"Set a template variable available as {{ .Vars.KEY }} (repeatable). Overrides testsuite and config vars."                    mapsep:"none"                                                                                                    name:"var"                placeholder:"KEY=VALUE"`
+	Include      []string            `help:"File name patterns of testsuite files (e.g. '*_xprin.yaml'). Replaces the configured and default patterns."                 name:"include"                                                                                                   placeholder:"PATTERN,..."`
+	Exclude      []string            `help:"Gitignore-style patterns of paths to skip during discovery, in addition to the configured patterns and .xprinignore files." name:"exclude"                                                                                                   placeholder:"PATTERN,..."`
+	CRDsCacheDir string              `default:"~/.crossplane/cache"                                                                                                     help:"Directory of the package cache the CRDs of crds-from inputs are loaded from (filled by xprin crds pull)."  name:"crds-cache-dir"     placeholder:"PATH"`
+	Config       *internalcfg.Config `kong:"-"`
+	fs           afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// SearchTarget returns the target the project configuration is discovered from: the first target, if any.
+func (c *Cmd) SearchTarget() string {
+	if len(c.Targets) == 0 {
+		return ""
+	}
+
+	return c.Targets[0]
+}
+
+// Run executes the mutate subcommand: it runs the test cases of the targets once per mutation of the compositions
+// they use and reports the mutations that survived.
+func (c *Cmd) Run(_ *kong.Context) error {
+	if err := c.Config.CheckValidation(); err != nil {
+		return err
+	}
+
+	if err := processor.CheckIncludePatterns(c.Include); err != nil {
+		return err
+	}
+
+	cacheDir, err := utils.ExpandTildeAbs(c.CRDsCacheDir)
+	if err != nil {
+		return fmt.Errorf("invalid --crds-cache-dir %s: %w", c.CRDsCacheDir, err)
+	}
+
+	c.CRDsCacheDir = cacheDir
+
+	return processor.Mutate(c.fs, c.Targets, c.newOptions(c.Config))
+}
+
+// newOptions creates the testexecutionUtils.Options the test cases of the targets run with.
+func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	options := &testexecutionUtils.Options{
+		Dependencies:     cfg.Dependencies,
+		Repositories:     cfg.Repositories,
+		Verbose:          c.Verbose,
+		Debug:            c.Debug,
+		BuiltinValidator: cfg.Validation != nil && cfg.Validation.Validator == internalcfg.ValidatorBuiltin,
+		ConfigVars:       cfg.Vars,
+		Vars:             c.Vars,
+		Include:          c.Include,
+		Exclude:          slices.Clone(c.Exclude),
+		CRDsCacheDir:     c.CRDsCacheDir,
+	}
+
+	if cfg.Subcommands != nil {
+		options.Render = strings.Fields(cfg.Subcommands.Render)
+		options.Validate = strings.Fields(cfg.Subcommands.Validate)
+	}
+
+	if cfg.Discovery != nil {
+		if len(options.Include) == 0 {
+			options.Include = cfg.Discovery.Include
+		}
+
+		options.Exclude = append(slices.Clone(cfg.Discovery.Exclude), c.Exclude...)
+	}
+
+	return options
+}
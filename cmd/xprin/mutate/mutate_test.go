@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCmd_Run(t *testing.T) {
+	dir := t.TempDir()
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), "tests:\n  - name: aws\n    inputs:\n      xr: xr.yaml\n      composition: composition.yaml\n      functions: functions\n")
+
+	t.Run("test cases failing without mutations", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, CRDsCacheDir: filepath.Join(dir, "cache"), Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		unittestsUtils.CaptureOutput(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.ErrorContains(t, err, "test cases fail without mutations")
+	})
+
+	t.Run("no testsuite files", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Include: []string{"*.yml"}, CRDsCacheDir: filepath.Join(dir, "cache"), Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = cmd.Run(&kong.Context{})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Mutations: no compositions used by the test cases\n", output)
+	})
+
+	t.Run("invalid include patterns", func(t *testing.T) {
+		cmd := &Cmd{Targets: []string{dir}, Include: []string{"["}, Config: &internalcfg.Config{}, fs: afero.NewOsFs()}
+		require.Error(t, cmd.Run(&kong.Context{}))
+	})
+}
+
+func TestCmd_newOptions(t *testing.T) {
+	cmd := &Cmd{Verbose: true, Exclude: []string{"vendor/"}, CRDsCacheDir: "/cache"}
+	cfg := &internalcfg.Config{
+		Discovery:   &internalcfg.Discovery{Include: []string{"*_test.yaml"}, Exclude: []string{"tmp/"}},
+		Subcommands: &internalcfg.Subcommands{Render: "render --x", Validate: "beta validate"},
+	}
+
+	options := cmd.newOptions(cfg)
+
+	assert.True(t, options.Verbose)
+	assert.Equal(t, []string{"*_test.yaml"}, options.Include)
+	assert.Equal(t, []string{"tmp/", "vendor/"}, options.Exclude)
+	assert.Equal(t, []string{"render", "--x"}, options.Render)
+	assert.Equal(t, []string{"beta", "validate"}, options.Validate)
+	assert.Equal(t, "/cache", options.CRDsCacheDir)
+	assert.Nil(t, options.Mutant)
+}
@@ -18,6 +18,11 @@ limitations under the License.
 package test
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/kong"
@@ -31,16 +36,36 @@ import (
 
 // Cmd represents the test subcommand.
 type Cmd struct {
-	Targets        []string            `arg:""                                                                                      help:"One or more test targets: individual files (e.g., 'tests/aws_xprin.yaml'), directories (e.g., 'tests/aws/'), or recursive directories (e.g., 'tests/aws/...'). Files must be named 'xprin.yaml' or '*_xprin.yaml'"`
-	ShowRender     bool                `help:"Display a list of the rendered resources in Kind/Name format. Requires --verbose."    name:"show-render"`
-	ShowValidate   bool                `help:"Display validation results for each resource. Requires --verbose."                    name:"show-validate"`
-	ShowHooks      bool                `help:"Display the execution hooks for each test case. Requires --verbose."                  name:"show-hooks"`
-	ShowAssertions bool                `help:"Display assertion results for each test case. Requires --verbose."                    name:"show-assertions"`
-	Verbose        bool                `help:"Show verbose test output and results (similar to go test -v)"                         short:"v"`
-	Debug          bool                `help:"Show detailed debug information about test discovery, path resolution, and execution"`
-	Color          string              `default:"auto"                                                                              enum:"on,off,auto"                                                                                                                                                                                                       help:"Specify color usage: on, off, or auto (default auto)." name:"color"`
-	Config         *internalcfg.Config `kong:"-"`
-	fs             afero.Fs
+	Targets            []string            `arg:""                                                                                                                                                                           help:"One or more test targets: individual files (e.g., 'tests/aws_xprin.yaml'), directories (e.g., 'tests/aws/'), or recursive directories (e.g., 'tests/aws/...'). Files must be named 'xprin.yaml' or '*_xprin.yaml'"`
+	ShowRender         bool                `help:"Display a list of the rendered resources in Kind/Name format. Requires --verbose."                                                                                         name:"show-render"`
+	ShowValidate       bool                `help:"Display validation results for each resource. Requires --verbose."                                                                                                         name:"show-validate"`
+	ShowHooks          bool                `help:"Display the execution hooks for each test case. Requires --verbose."                                                                                                       name:"show-hooks"`
+	ShowAssertions     bool                `help:"Display assertion results for each test case. Requires --verbose."                                                                                                         name:"show-assertions"`
+	Verbose            bool                `help:"Show verbose test output and results (similar to go test -v)"                                                                                                              short:"v"`
+	Debug              bool                `help:"Show detailed debug information about test discovery, path resolution, and execution"`
+	Color              string              `default:"auto"                                                                                                                                                                   enum:"on,off,auto"                                                                                                                                                                                                       help:"Specify color usage: on, off, or auto (default auto)."                                              name:"color"`
+	Vars               map[string]string   `help:"Set a template variable available as {{ .Vars.KEY }} (repeatable). Overrides --var-file, testsuite and config vars."                                                       mapsep:"none"                                                                                                                                                                                                            name:"var"                                                                                                placeholder:"KEY=VALUE"`
+	VarFiles           []string            `help:"Load template variables from a YAML file (repeatable; later files override earlier ones)."                                                                                 name:"var-file"                                                                                                                                                                                                          placeholder:"PATH"`
+	Crossplane         []string            `help:"Run every testsuite once per crossplane dependency (e.g. crossplane-1.20,crossplane-2.0) and label the results with it."                                                   name:"crossplane"                                                                                                                                                                                                        placeholder:"DEPENDENCY,..."`
+	CompareWith        string              `help:"Render every test case again with a baseline and report a dyff between both renders: a crossplane dependency (e.g. crossplane-2.0), functions:PATH or git:REPOSITORY@REF." name:"compare-with"                                                                                                                                                                                                      placeholder:"BASELINE"`
+	ChangedSince       string              `help:"Only run the test cases whose inputs or golden files changed since the given git ref (in the git repository of the working directory)."                                    name:"changed-since"                                                                                                                                                                                                     placeholder:"REF"`
+	List               bool                `help:"Print the selected test cases instead of running them."                                                                                                                    name:"list"`
+	DryRun             bool                `help:"Check that the test cases can run without running them: resolve their templates and input paths and copy their inputs, but run no hooks, render or validate."              name:"dry-run"`
+	Watch              bool                `help:"Keep running: watch the testsuite files and their inputs and re-run the affected test cases when they change."                                                             name:"watch"`
+	Include            []string            `help:"File name patterns of testsuite files (e.g. '*_xprin.yaml'). Replaces the configured and default patterns."                                                                name:"include"                                                                                                                                                                                                           placeholder:"PATTERN,..."`
+	Exclude            []string            `help:"Gitignore-style patterns of paths to skip during discovery, in addition to the configured patterns and .xprinignore files."                                                name:"exclude"                                                                                                                                                                                                           placeholder:"PATTERN,..."`
+	ShardIndex         int                 `help:"Only run the test cases of this shard, between 0 and --shard-total minus 1."                                                                                               name:"shard-index"                                                                                                                                                                                                       placeholder:"INDEX"`
+	ShardTotal         int                 `help:"Partition the test cases deterministically into this number of shards (e.g. one per CI runner)."                                                                           name:"shard-total"                                                                                                                                                                                                       placeholder:"TOTAL"`
+	ArtifactsDir       string              `help:"Keep the inputs, outputs, hooks logs and assertion results of every test case in this directory, with an index.json file (e.g. to upload from CI)."                        name:"artifacts-dir"                                                                                                                                                                                                     placeholder:"PATH"`
+	ArtifactsRetention string              `default:"always"                                                                                                                                                                 enum:"always,on-failure"                                                                                                                                                                                                 help:"Which test cases keep their artifacts with --artifacts-dir: always or on-failure (default always)." name:"artifacts-retention"`
+	CRDsCacheDir       string              `default:"~/.crossplane/cache"                                                                                                                                                    help:"Directory of the package cache the CRDs of crds-from inputs are loaded from (filled by xprin crds pull)."                                                                                                          name:"crds-cache-dir"                                                                                     placeholder:"PATH"`
+	InputWarningsFatal bool                `help:"Fail the input validation of test cases when their XR or Claim has fields that are not in the XRD schema and would be pruned."                                             name:"input-warnings-as-errors"`
+	Coverage           bool                `help:"Report which pipeline steps and composed resources of each Composition and which spec fields of each XRD the test cases cover."                                            name:"coverage"`
+	CoverageFile       string              `default:"xprin-coverage.json"                                                                                                                                                    help:"File the machine-readable coverage report is written to with --coverage (default xprin-coverage.json)."                                                                                                            name:"coverage-file"                                                                                      placeholder:"PATH"`
+	Config             *internalcfg.Config `kong:"-"`
+	fs                 afero.Fs
+	comparison         *testexecutionUtils.Comparison
+	changedFiles       []string
 }
 
 // AfterApply implements kong.AfterApply.
@@ -49,6 +74,15 @@ func (c *Cmd) AfterApply() error {
 	return nil
 }
 
+// SearchTarget returns the target the project configuration is discovered from: the first target, if any.
+func (c *Cmd) SearchTarget() string {
+	if len(c.Targets) == 0 {
+		return ""
+	}
+
+	return c.Targets[0]
+}
+
 // Run executes the test subcommand.
 func (c *Cmd) Run(_ *kong.Context) error {
 	// Warn if render flag is used without -v
@@ -78,32 +112,254 @@ func (c *Cmd) Run(_ *kong.Context) error {
 		bunt.SetColorSettings(bunt.AUTO, bunt.AUTO)
 	}
 
+	if err := c.loadVarFiles(); err != nil {
+		return err
+	}
+
+	if err := c.checkCrossplaneDependencies(); err != nil {
+		return err
+	}
+
+	if err := c.Config.CheckValidation(); err != nil {
+		return err
+	}
+
+	if err := processor.CheckIncludePatterns(c.Include); err != nil {
+		return err
+	}
+
+	if err := c.checkShard(); err != nil {
+		return err
+	}
+
+	if err := c.parseComparison(); err != nil {
+		return err
+	}
+
+	if err := c.expandArtifactsDir(); err != nil {
+		return err
+	}
+
+	if err := c.expandCRDsCacheDir(); err != nil {
+		return err
+	}
+
+	if err := c.loadChangedFiles(); err != nil {
+		return err
+	}
+
 	options := c.newOptions(c.Config)
 
+	if c.Watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		return processor.Watch(ctx, c.fs, c.Targets, options)
+	}
+
 	// Process targets and run tests
 	return processor.ProcessTargets(c.fs, c.Targets, options)
 }
 
 // newOptions creates a testexecutionUtils.Options struct from a Command and Config.
 func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
-	var render, validate []string
+	var render, validate, include, exclude []string
 
 	if cfg.Subcommands != nil {
 		render = strings.Fields(cfg.Subcommands.Render)
 		validate = strings.Fields(cfg.Subcommands.Validate)
 	}
 
+	if cfg.Discovery != nil {
+		include = cfg.Discovery.Include
+		exclude = cfg.Discovery.Exclude
+	}
+
+	if len(c.Include) > 0 {
+		include = c.Include
+	}
+
 	return &testexecutionUtils.Options{
-		Dependencies:   cfg.Dependencies,
-		Repositories:   cfg.Repositories,
-		ShowRender:     c.ShowRender,
-		ShowValidate:   c.ShowValidate,
-		ShowHooks:      c.ShowHooks,
-		ShowAssertions: c.ShowAssertions,
-		Verbose:        c.Verbose,
-		Debug:          c.Debug,
-		Color:          bunt.UseColors(),
-		Render:         render,
-		Validate:       validate,
+		Dependencies:       cfg.Dependencies,
+		Repositories:       cfg.Repositories,
+		ShowRender:         c.ShowRender,
+		ShowValidate:       c.ShowValidate,
+		ShowHooks:          c.ShowHooks,
+		ShowAssertions:     c.ShowAssertions,
+		Verbose:            c.Verbose,
+		Debug:              c.Debug,
+		Color:              bunt.UseColors(),
+		Render:             render,
+		Validate:           validate,
+		BuiltinValidator:   cfg.Validation != nil && cfg.Validation.Validator == internalcfg.ValidatorBuiltin,
+		ConfigVars:         cfg.Vars,
+		Vars:               c.Vars,
+		CrossplaneRuns:     c.Crossplane,
+		CompareWith:        c.comparison,
+		ChangedSince:       c.ChangedSince,
+		ChangedFiles:       c.changedFiles,
+		List:               c.List,
+		DryRun:             c.DryRun,
+		Include:            include,
+		Exclude:            append(slices.Clone(exclude), c.Exclude...),
+		ShardIndex:         c.ShardIndex,
+		ShardTotal:         c.ShardTotal,
+		ArtifactsDir:       c.ArtifactsDir,
+		ArtifactsRetention: c.ArtifactsRetention,
+		CRDsCacheDir:       c.CRDsCacheDir,
+		InputWarningsFatal: c.InputWarningsFatal,
+		Coverage:           c.Coverage,
+		CoverageFile:       c.CoverageFile,
+	}
+}
+
+// expandArtifactsDir makes --artifacts-dir absolute, so that it does not depend on the directory hooks run in.
+func (c *Cmd) expandArtifactsDir() error {
+	if c.ArtifactsDir == "" {
+		return nil
+	}
+
+	path, err := utils.ExpandTildeAbs(c.ArtifactsDir)
+	if err != nil {
+		return fmt.Errorf("invalid --artifacts-dir %s: %w", c.ArtifactsDir, err)
+	}
+
+	c.ArtifactsDir = path
+
+	return nil
+}
+
+// expandCRDsCacheDir makes --crds-cache-dir absolute, so that it does not depend on the directory hooks run in.
+func (c *Cmd) expandCRDsCacheDir() error {
+	if c.CRDsCacheDir == "" {
+		return nil
+	}
+
+	path, err := utils.ExpandTildeAbs(c.CRDsCacheDir)
+	if err != nil {
+		return fmt.Errorf("invalid --crds-cache-dir %s: %w", c.CRDsCacheDir, err)
+	}
+
+	c.CRDsCacheDir = path
+
+	return nil
+}
+
+// loadChangedFiles finds the files changed since --changed-since in the git repository of the working directory.
+func (c *Cmd) loadChangedFiles() error {
+	if c.ChangedSince == "" {
+		return nil
 	}
+
+	files, err := testexecutionUtils.ChangedFiles(".", c.ChangedSince)
+	if err != nil {
+		return fmt.Errorf("failed to find files changed since %s: %w", c.ChangedSince, err)
+	}
+
+	if c.Debug {
+		utils.DebugPrintf("Found %d files changed since %s\n", len(files), c.ChangedSince)
+	}
+
+	c.changedFiles = files
+
+	return nil
+}
+
+// checkShard checks that --shard-index is a valid shard of --shard-total.
+func (c *Cmd) checkShard() error {
+	if c.ShardTotal < 0 {
+		return fmt.Errorf("invalid --shard-total %d: must be positive", c.ShardTotal)
+	}
+
+	if c.ShardTotal == 0 {
+		if c.ShardIndex != 0 {
+			return fmt.Errorf("--shard-index requires --shard-total")
+		}
+
+		return nil
+	}
+
+	if c.ShardIndex < 0 || c.ShardIndex >= c.ShardTotal {
+		return fmt.Errorf("invalid --shard-index %d: must be between 0 and %d", c.ShardIndex, c.ShardTotal-1)
+	}
+
+	return nil
+}
+
+// checkCrossplaneDependencies checks that every --crossplane value is a configured crossplane dependency.
+func (c *Cmd) checkCrossplaneDependencies() error {
+	var invalid []string
+
+	for _, name := range c.Crossplane {
+		if _, ok := c.Config.Dependencies[name]; !ok || !internalcfg.IsCrossplaneDependency(name) {
+			invalid = append(invalid, name)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("unknown crossplane dependencies: %s (configured: %s)", strings.Join(invalid, ", "), strings.Join(c.Config.CrossplaneDependencies(), ", "))
+	}
+
+	return nil
+}
+
+// parseComparison parses --compare-with and checks that it refers to a configured crossplane dependency,
+// an existing functions file or a configured repository. Relative functions paths are made absolute.
+func (c *Cmd) parseComparison() error {
+	if c.CompareWith == "" {
+		return nil
+	}
+
+	comparison, err := testexecutionUtils.ParseComparison(c.CompareWith)
+	if err != nil {
+		return fmt.Errorf("invalid --compare-with: %w", err)
+	}
+
+	switch comparison.Kind {
+	case testexecutionUtils.ComparisonDependency:
+		if _, ok := c.Config.Dependencies[comparison.Dependency]; !ok || !internalcfg.IsCrossplaneDependency(comparison.Dependency) {
+			return fmt.Errorf("unknown crossplane dependency for --compare-with: %s (configured: %s)", comparison.Dependency, strings.Join(c.Config.CrossplaneDependencies(), ", "))
+		}
+	case testexecutionUtils.ComparisonFunctions:
+		path, err := utils.ExpandTildeAbs(comparison.Functions)
+		if err != nil {
+			return fmt.Errorf("failed to expand functions path for --compare-with: %w", err)
+		}
+
+		if _, err := c.fs.Stat(path); err != nil {
+			return fmt.Errorf("functions file or dir for --compare-with not found: %w", err)
+		}
+
+		comparison.Functions = path
+	case testexecutionUtils.ComparisonGit:
+		if _, ok := c.Config.Repositories[comparison.Repository]; !ok {
+			return fmt.Errorf("unknown repository for --compare-with: %s", comparison.Repository)
+		}
+	}
+
+	c.comparison = comparison
+
+	return nil
+}
+
+// loadVarFiles merges the variables from --var-file into c.Vars; --var values take precedence over var files.
+func (c *Cmd) loadVarFiles() error {
+	if len(c.VarFiles) == 0 {
+		return nil
+	}
+
+	layers := make([]map[string]string, 0, len(c.VarFiles)+1)
+
+	for _, path := range c.VarFiles {
+		vars, err := testexecutionUtils.LoadVarsFile(c.fs, path)
+		if err != nil {
+			return err
+		}
+
+		layers = append(layers, vars)
+	}
+
+	c.Vars = testexecutionUtils.MergeVars(append(layers, c.Vars)...)
+
+	return nil
 }
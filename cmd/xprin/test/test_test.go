@@ -17,13 +17,17 @@ limitations under the License.
 package test
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/alecthomas/kong"
 	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
-	"github.com/stretchr/testify/assert" //nolint:depguard // testify is widely used for testing
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
 )
 
 // TestNewOperation tests that NewOperation correctly initializes an Operation struct with the given config.
@@ -122,6 +126,7 @@ func TestNewOptions(t *testing.T) {
 			"repo1": "path1",
 			"repo2": "path2",
 		},
+		Validation: &internalcfg.Validation{Validator: internalcfg.ValidatorBuiltin},
 	}
 
 	// Create a test command
@@ -132,6 +137,8 @@ func TestNewOptions(t *testing.T) {
 		ShowAssertions: true,
 		Verbose:        true,
 		Debug:          false,
+		Coverage:       true,
+		CoverageFile:   "coverage.json",
 	}
 
 	// Create options using the newOptions method
@@ -142,6 +149,7 @@ func TestNewOptions(t *testing.T) {
 	assert.Equal(t, strings.Fields("custom-validate --flag3"), options.Validate)
 	assert.Equal(t, cfg.Dependencies, options.Dependencies)
 	assert.Equal(t, cfg.Repositories, options.Repositories)
+	assert.True(t, options.BuiltinValidator)
 
 	// Verify other options were set from command
 	assert.Equal(t, cmd.ShowRender, options.ShowRender)
@@ -150,6 +158,8 @@ func TestNewOptions(t *testing.T) {
 	assert.Equal(t, cmd.ShowAssertions, options.ShowAssertions)
 	assert.Equal(t, cmd.Verbose, options.Verbose)
 	assert.Equal(t, cmd.Debug, options.Debug)
+	assert.True(t, options.Coverage)
+	assert.Equal(t, "coverage.json", options.CoverageFile)
 }
 
 // Test that NewOptions handles nil Subcommands gracefully.
@@ -179,6 +189,7 @@ func TestNewOptions_WithNilSubcommands(t *testing.T) {
 	// Verify options were set correctly
 	assert.Empty(t, options.Render)
 	assert.Empty(t, options.Validate)
+	assert.False(t, options.BuiltinValidator)
 	assert.Equal(t, cfg.Dependencies, options.Dependencies)
 	assert.Equal(t, cfg.Repositories, options.Repositories)
 	assert.Equal(t, cmd.ShowRender, options.ShowRender)
@@ -186,3 +197,332 @@ func TestNewOptions_WithNilSubcommands(t *testing.T) {
 	assert.Equal(t, cmd.ShowHooks, options.ShowHooks)
 	assert.Equal(t, cmd.ShowAssertions, options.ShowAssertions)
 }
+
+func TestCmd_VarFlags(t *testing.T) {
+	var cli struct {
+		Test Cmd `cmd:""`
+	}
+
+	parser, err := kong.New(&cli)
+	require.NoError(t, err)
+
+	_, err = parser.Parse([]string{"test", "--var", "branch=main", "--var", "query=a=b;c", "--var-file", "a.yaml", "--var-file", "b.yaml", "tests/"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"branch": "main", "query": "a=b;c"}, cli.Test.Vars)
+	assert.Equal(t, []string{"a.yaml", "b.yaml"}, cli.Test.VarFiles)
+}
+
+func TestCmd_LoadVarFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/a.yaml", []byte("branch: from-a\nregion: eu\nreplicas: 3\n"), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "/b.yaml", []byte("branch: from-b\nprovider: v1\n"), 0o600))
+
+	t.Run("later files override earlier ones and --var overrides files", func(t *testing.T) {
+		cmd := &Cmd{
+			Vars:     map[string]string{"provider": "v2"},
+			VarFiles: []string{"/a.yaml", "/b.yaml"},
+			fs:       fs,
+		}
+
+		require.NoError(t, cmd.loadVarFiles())
+		assert.Equal(t, map[string]string{"branch": "from-b", "region": "eu", "replicas": "3", "provider": "v2"}, cmd.Vars)
+
+		options := cmd.newOptions(&internalcfg.Config{Vars: map[string]string{"branch": "from-config"}})
+		assert.Equal(t, cmd.Vars, options.Vars)
+		assert.Equal(t, map[string]string{"branch": "from-config"}, options.ConfigVars)
+	})
+
+	t.Run("missing var file", func(t *testing.T) {
+		cmd := &Cmd{
+			VarFiles: []string{"/missing.yaml"},
+			fs:       fs,
+		}
+
+		err := cmd.loadVarFiles()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read vars file /missing.yaml")
+	})
+
+	t.Run("no var files keeps --var values", func(t *testing.T) {
+		cmd := &Cmd{Vars: map[string]string{"branch": "main"}}
+
+		require.NoError(t, cmd.loadVarFiles())
+		assert.Equal(t, map[string]string{"branch": "main"}, cmd.Vars)
+	})
+}
+
+func TestCmd_CheckCrossplaneDependencies(t *testing.T) {
+	cfg := &internalcfg.Config{
+		Dependencies: map[string]string{
+			"crossplane":      "crossplane",
+			"crossplane-1.20": "/opt/crossplane-1.20",
+			"crossplane-2.0":  "/opt/crossplane-2.0",
+			"kubectl":         "kubectl",
+		},
+	}
+
+	t.Run("configured crossplane dependencies", func(t *testing.T) {
+		cmd := &Cmd{Crossplane: []string{"crossplane-1.20", "crossplane-2.0"}, Config: cfg}
+
+		require.NoError(t, cmd.checkCrossplaneDependencies())
+
+		options := cmd.newOptions(cfg)
+		assert.Equal(t, []string{"crossplane-1.20", "crossplane-2.0"}, options.CrossplaneRuns)
+	})
+
+	t.Run("unknown or non-crossplane dependencies", func(t *testing.T) {
+		cmd := &Cmd{Crossplane: []string{"crossplane-3.0", "kubectl", "crossplane-2.0"}, Config: cfg}
+
+		err := cmd.checkCrossplaneDependencies()
+		require.Error(t, err)
+		assert.Equal(t, "unknown crossplane dependencies: crossplane-3.0, kubectl (configured: crossplane, crossplane-1.20, crossplane-2.0)", err.Error())
+	})
+
+	t.Run("flag is split on commas", func(t *testing.T) {
+		var cli struct {
+			Test Cmd `cmd:""`
+		}
+
+		parser, err := kong.New(&cli)
+		require.NoError(t, err)
+
+		_, err = parser.Parse([]string{"test", "--crossplane", "crossplane-1.20,crossplane-2.0", "tests/"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"crossplane-1.20", "crossplane-2.0"}, cli.Test.Crossplane)
+	})
+}
+
+func TestCmd_ParseComparison(t *testing.T) {
+	cfg := &internalcfg.Config{
+		Dependencies: map[string]string{
+			"crossplane":     "crossplane",
+			"crossplane-2.0": "/opt/crossplane-2.0",
+			"kubectl":        "kubectl",
+		},
+		Repositories: map[string]string{"compositions": "/repos/compositions"},
+	}
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/next/functions.yaml", []byte(""), 0o644))
+
+	tests := []struct {
+		name        string
+		compareWith string
+		want        *testexecutionUtils.Comparison
+		errContains string
+	}{
+		{
+			name: "no comparison",
+		},
+		{
+			name:        "crossplane dependency",
+			compareWith: "crossplane-2.0",
+			want:        &testexecutionUtils.Comparison{Kind: testexecutionUtils.ComparisonDependency, Dependency: "crossplane-2.0"},
+		},
+		{
+			name:        "non-crossplane dependency",
+			compareWith: "kubectl",
+			errContains: "unknown crossplane dependency for --compare-with: kubectl (configured: crossplane, crossplane-2.0)",
+		},
+		{
+			name:        "functions file",
+			compareWith: "functions:/next/functions.yaml",
+			want:        &testexecutionUtils.Comparison{Kind: testexecutionUtils.ComparisonFunctions, Functions: "/next/functions.yaml"},
+		},
+		{
+			name:        "missing functions file",
+			compareWith: "functions:/missing/functions.yaml",
+			errContains: "functions file or dir for --compare-with not found",
+		},
+		{
+			name:        "git ref of a configured repository",
+			compareWith: "git:compositions@v1.0.0",
+			want:        &testexecutionUtils.Comparison{Kind: testexecutionUtils.ComparisonGit, Repository: "compositions", Ref: "v1.0.0"},
+		},
+		{
+			name:        "git ref of an unknown repository",
+			compareWith: "git:other@v1.0.0",
+			errContains: "unknown repository for --compare-with: other",
+		},
+		{
+			name:        "invalid git comparison",
+			compareWith: "git:compositions",
+			errContains: "invalid --compare-with",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &Cmd{CompareWith: tt.compareWith, Config: cfg, fs: fs}
+
+			err := cmd.parseComparison()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cmd.newOptions(cfg).CompareWith)
+		})
+	}
+}
+
+func TestCmd_LoadChangedFiles(t *testing.T) {
+	t.Run("no --changed-since", func(t *testing.T) {
+		cmd := &Cmd{}
+
+		require.NoError(t, cmd.loadChangedFiles())
+		assert.Nil(t, cmd.changedFiles)
+	})
+
+	t.Run("working directory is not a git repository", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		cmd := &Cmd{ChangedSince: "main"}
+
+		err := cmd.loadChangedFiles()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to find files changed since main")
+	})
+
+	t.Run("changed files are passed to the options", func(t *testing.T) {
+		dir := t.TempDir()
+		unittestsUtils.CreateGitRepo(t, unittestsUtils.GitRepoOptions{Path: dir, CreateInitialCommit: true})
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "composition.yaml"), "kind: Composition\n")
+		t.Chdir(dir)
+
+		cmd := &Cmd{ChangedSince: "HEAD", List: true}
+		require.NoError(t, cmd.loadChangedFiles())
+
+		root, err := filepath.EvalSymlinks(dir)
+		require.NoError(t, err)
+
+		options := cmd.newOptions(&internalcfg.Config{})
+		assert.Equal(t, "HEAD", options.ChangedSince)
+		assert.Equal(t, []string{filepath.Join(root, "composition.yaml")}, options.ChangedFiles)
+		assert.True(t, options.List)
+	})
+}
+
+func TestNewOptions_DryRun(t *testing.T) {
+	assert.False(t, (&Cmd{}).newOptions(&internalcfg.Config{}).DryRun)
+	assert.True(t, (&Cmd{DryRun: true}).newOptions(&internalcfg.Config{}).DryRun)
+}
+
+func TestNewOptions_Discovery(t *testing.T) {
+	cfg := &internalcfg.Config{Discovery: &internalcfg.Discovery{Include: []string{"*.test.yaml"}, Exclude: []string{"drafts/"}}}
+
+	t.Run("configured patterns", func(t *testing.T) {
+		options := (&Cmd{}).newOptions(cfg)
+		assert.Equal(t, []string{"*.test.yaml"}, options.Include)
+		assert.Equal(t, []string{"drafts/"}, options.Exclude)
+	})
+
+	t.Run("--include replaces and --exclude adds to the configured patterns", func(t *testing.T) {
+		var cli struct {
+			Test Cmd `cmd:""`
+		}
+
+		parser, err := kong.New(&cli)
+		require.NoError(t, err)
+
+		_, err = parser.Parse([]string{"test", "--include", "*_xprin.yaml,*_xprin.yml", "--exclude", "legacy/", "tests/"})
+		require.NoError(t, err)
+
+		options := cli.Test.newOptions(cfg)
+		assert.Equal(t, []string{"*_xprin.yaml", "*_xprin.yml"}, options.Include)
+		assert.Equal(t, []string{"drafts/", "legacy/"}, options.Exclude)
+		assert.Equal(t, []string{"drafts/"}, cfg.Discovery.Exclude)
+	})
+
+	t.Run("nil discovery", func(t *testing.T) {
+		options := (&Cmd{}).newOptions(&internalcfg.Config{})
+		assert.Empty(t, options.Include)
+		assert.Empty(t, options.Exclude)
+	})
+}
+
+func TestCmd_CheckShard(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   int
+		total   int
+		wantErr string
+	}{
+		{name: "no sharding"},
+		{name: "first shard", index: 0, total: 3},
+		{name: "last shard", index: 2, total: 3},
+		{name: "index out of range", index: 3, total: 3, wantErr: "invalid --shard-index 3: must be between 0 and 2"},
+		{name: "negative index", index: -1, total: 3, wantErr: "invalid --shard-index -1"},
+		{name: "negative total", total: -1, wantErr: "invalid --shard-total -1"},
+		{name: "index without total", index: 1, wantErr: "--shard-index requires --shard-total"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &Cmd{ShardIndex: tt.index, ShardTotal: tt.total}
+
+			err := cmd.checkShard()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			options := cmd.newOptions(&internalcfg.Config{})
+			assert.Equal(t, tt.index, options.ShardIndex)
+			assert.Equal(t, tt.total, options.ShardTotal)
+		})
+	}
+}
+
+func TestCmd_ExpandArtifactsDir(t *testing.T) {
+	t.Run("no artifacts directory", func(t *testing.T) {
+		cmd := &Cmd{}
+		require.NoError(t, cmd.expandArtifactsDir())
+		assert.Empty(t, cmd.newOptions(&internalcfg.Config{}).ArtifactsDir)
+	})
+
+	t.Run("relative artifacts directory is made absolute", func(t *testing.T) {
+		cmd := &Cmd{ArtifactsDir: "artifacts", ArtifactsRetention: testexecutionUtils.ArtifactsRetentionOnFailure}
+		require.NoError(t, cmd.expandArtifactsDir())
+
+		want, err := filepath.Abs("artifacts")
+		require.NoError(t, err)
+
+		options := cmd.newOptions(&internalcfg.Config{})
+		assert.Equal(t, want, options.ArtifactsDir)
+		assert.Equal(t, testexecutionUtils.ArtifactsRetentionOnFailure, options.ArtifactsRetention)
+	})
+}
+
+func TestCmd_ExpandCRDsCacheDir(t *testing.T) {
+	t.Run("tilde is expanded to the home directory", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+
+		cmd := &Cmd{CRDsCacheDir: "~/.crossplane/cache"}
+		require.NoError(t, cmd.expandCRDsCacheDir())
+		assert.Equal(t, filepath.Join(home, ".crossplane", "cache"), cmd.newOptions(&internalcfg.Config{}).CRDsCacheDir)
+	})
+
+	t.Run("relative cache directory is made absolute", func(t *testing.T) {
+		cmd := &Cmd{CRDsCacheDir: "cache"}
+		require.NoError(t, cmd.expandCRDsCacheDir())
+
+		want, err := filepath.Abs("cache")
+		require.NoError(t, err)
+		assert.Equal(t, want, cmd.CRDsCacheDir)
+	})
+}
+
+func TestSearchTarget(t *testing.T) {
+	assert.Empty(t, (&Cmd{}).SearchTarget())
+	assert.Equal(t, "tests/...", (&Cmd{Targets: []string{"tests/...", "other/"}}).SearchTarget())
+}
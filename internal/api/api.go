@@ -20,13 +20,16 @@ package api
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 )
 
 // TestSuiteSpec represents the structure of a testsuite YAML file used by xprin.
 type TestSuiteSpec struct {
-	Common Common     `json:"common,omitempty"` // Common config for all tests (Optional)
-	Tests  []TestCase `json:"tests"`            // List of test cases (Required)
+	Name      string     `json:"name,omitempty"`       // Name other testsuites reference the results of this testsuite by, as {{ .Suites.<name> }} (Optional)
+	DependsOn []string   `json:"depends-on,omitempty"` // Testsuite files to run before this testsuite, relative to this testsuite file (Optional)
+	Common    Common     `json:"common,omitempty"`     // Common config for all tests (Optional)
+	Tests     []TestCase `json:"tests"`                // List of test cases (Required)
 }
 
 // Patches represents XR patching configuration.
@@ -73,22 +76,54 @@ type Assertions struct {
 	Dyff  []AssertionGoldenFile `json:"dyff,omitempty"`  // dyff assertions (dyff between expected and actual) (Optional)
 }
 
+// CrossplaneSelector restricts which named crossplane dependencies (e.g. crossplane-1.20, crossplane-2.0) run a testcase.
+type CrossplaneSelector struct {
+	Only    []string `json:"only,omitempty"`    // Run the testcase only with these crossplane dependencies (Optional)
+	Exclude []string `json:"exclude,omitempty"` // Never run the testcase with these crossplane dependencies (Optional)
+}
+
 // Common represents the common configuration for a testsuite file.
 type Common struct {
-	Inputs     Inputs     `json:"inputs,omitempty"`     // Common inputs (composition, Claim/XR, etc.) for all testcases (Optional)
-	Patches    Patches    `json:"patches,omitempty"`    // Common XR patching configuration for all testcases (Optional)
-	Hooks      Hooks      `json:"hooks,omitempty"`      // Common hooks for all testcases (Optional)
-	Assertions Assertions `json:"assertions,omitempty"` // Common assertions to validate rendered resources for all testcases (Optional)
+	Inputs     Inputs             `json:"inputs,omitempty"`     // Common inputs (composition, Claim/XR, etc.) for all testcases (Optional)
+	Patches    Patches            `json:"patches,omitempty"`    // Common XR patching configuration for all testcases (Optional)
+	Hooks      Hooks              `json:"hooks,omitempty"`      // Common hooks for all testcases (Optional)
+	Assertions Assertions         `json:"assertions,omitempty"` // Common assertions to validate rendered resources for all testcases (Optional)
+	Vars       map[string]string  `json:"vars,omitempty"`       // Template variables available as {{ .Vars.name }} in all testcases (Optional)
+	Crossplane CrossplaneSelector `json:"crossplane,omitempty"` // Common crossplane dependencies selection for all testcases (Optional)
+
+	positions map[string]Position // Positions of the fields in the testsuite file, by path relative to common (set by Decode)
 }
 
 // TestCase represents a single test case.
 type TestCase struct {
-	Name       string     `json:"name"`                 // Descriptive name for the testcase (Required)
-	ID         string     `json:"id,omitempty"`         // Unique identifier for the testcase (Optional)
-	Inputs     Inputs     `json:"inputs,omitempty"`     // Inputs of a testcase (Required unless specified in the common inputs)
-	Patches    Patches    `json:"patches,omitempty"`    // XR patching configuration (Optional)
-	Hooks      Hooks      `json:"hooks,omitempty"`      // Execution hooks (Optional)
-	Assertions Assertions `json:"assertions,omitempty"` // Assertions to validate rendered resources (Optional)
+	Name       string             `json:"name"`                  // Descriptive name for the testcase (Required)
+	ID         string             `json:"id,omitempty"`          // Unique identifier for the testcase (Optional)
+	DependsOn  []string           `json:"depends-on,omitempty"`  // IDs of the testcases to run before this testcase; it is skipped when any of them fails (Optional)
+	Inputs     Inputs             `json:"inputs,omitempty"`      // Inputs of a testcase (Required unless specified in the common inputs)
+	Patches    Patches            `json:"patches,omitempty"`     // XR patching configuration (Optional)
+	Hooks      Hooks              `json:"hooks,omitempty"`       // Execution hooks (Optional)
+	Assertions Assertions         `json:"assertions,omitempty"`  // Assertions to validate rendered resources (Optional)
+	Crossplane CrossplaneSelector `json:"crossplane,omitempty"`  // Crossplane dependencies to run or skip the testcase with (Optional)
+	XRVariants *XRVariants        `json:"xr-variants,omitempty"` // Run the testcase once per XR generated from an XRD, instead of with a claim or xr input (Optional)
+	Fuzz       *Fuzz              `json:"fuzz,omitempty"`        // Run the testcase with random XRs generated from an XRD, instead of with a claim or xr input (Optional)
+
+	positions map[string]Position // Positions of the fields in the testsuite file, by path relative to the testcase (set by Decode)
+}
+
+// XRVariants configures the XRs generated from the schema of an XRD that a testcase runs with.
+type XRVariants struct {
+	XRD     string   `json:"xrd"`                                                                    // Path to the XRD the XRs are generated from (Required)
+	Version string   `json:"version,omitempty"`                                                      // Version of the XRD the XRs are generated for (Optional, the referenceable version by default)
+	Kinds   []string `json:"kinds,omitempty"   jsonschema:"enum=minimal,enum=maximal,enum=boundary"` // Kinds of variants to generate: minimal, maximal and/or boundary (Optional, all of them by default)
+}
+
+// Fuzz configures the random XRs generated from the schema of an XRD that a testcase runs with.
+type Fuzz struct {
+	XRD      string `json:"xrd"`                // Path to the XRD the XRs are generated from (Required)
+	Version  string `json:"version,omitempty"`  // Version of the XRD the XRs are generated for (Optional, the referenceable version by default)
+	Runs     int    `json:"runs,omitempty"`     // Number of random XRs to run the testcase with (Optional, 10 by default)
+	Seed     int64  `json:"seed,omitempty"`     // Seed of the random XRs; the same seed generates the same XRs (Optional, 0 by default)
+	Fixtures string `json:"fixtures,omitempty"` // Directory to write the shrunk failing XR to, relative to the testsuite file (Optional, testdata/fuzz by default)
 }
 
 // Inputs represents the inputs for a test case or common configuration.
@@ -98,6 +133,7 @@ type Inputs struct {
 	Composition         string            `json:"composition,omitempty"`          // Path to composition file (Required unless specified in the common inputs)
 	Functions           string            `json:"functions,omitempty"`            // Path to functions file or directory (Required unless specified in the common inputs)
 	CRDs                []string          `json:"crds,omitempty"`                 // Paths to CRD files (Optional)
+	CRDsFrom            []string          `json:"crds-from,omitempty"`            // Package files (e.g. crossplane.yaml) or package references whose CRDs are loaded from the CRDs cache (Optional)
 	ContextFiles        map[string]string `json:"context-files,omitempty"`        // Map of context keys to file paths (Optional)
 	ContextValues       map[string]string `json:"context-values,omitempty"`       // Map of context keys to inline values (Optional)
 	ObservedResources   string            `json:"observed-resources,omitempty"`   // Path to observed resources file (Optional)
@@ -136,6 +172,20 @@ func (p *Patches) CheckConnectionSecret() error {
 	return nil
 }
 
+// HasSelection returns true if any crossplane dependencies are pinned or excluded.
+func (s *CrossplaneSelector) HasSelection() bool {
+	return len(s.Only) > 0 || len(s.Exclude) > 0
+}
+
+// Allows returns true if a testcase with this selector should run with the given crossplane dependency.
+func (s *CrossplaneSelector) Allows(dependency string) bool {
+	if len(s.Only) > 0 && !slices.Contains(s.Only, dependency) {
+		return false
+	}
+
+	return !slices.Contains(s.Exclude, dependency)
+}
+
 // HasPreTestHooks returns true if any pre-test hooks are set.
 func (h *Hooks) HasPreTestHooks() bool {
 	return len(h.PreTest) > 0
@@ -172,8 +222,10 @@ func (a *Assertions) HasAssertions() bool {
 }
 
 // CheckValidTestSuiteFile checks:
-// - if test case names are non-empty
+// - if the testsuite name and test case IDs contain only valid characters
+// - if test case names and depends-on entries are non-empty
 // - if test case IDs are unique (only for tests that have IDs)
+// - if test case depends-on entries are IDs of other test cases, without cycles
 // and returns a list of all validation errors found.
 func (ts *TestSuiteSpec) CheckValidTestSuiteFile() error {
 	var allErrors []string
@@ -193,6 +245,16 @@ func (ts *TestSuiteSpec) CheckValidTestSuiteFile() error {
 		return true
 	}
 
+	if ts.Name != "" && !hasValidID(ts.Name) {
+		allErrors = append(allErrors, fmt.Sprintf("testsuite name '%s' contains invalid characters (allowed: alphanumeric, underscore, hyphen)", ts.Name))
+	}
+
+	for _, dependency := range ts.DependsOn {
+		if dependency == "" {
+			allErrors = append(allErrors, "depends-on has empty testsuite file")
+		}
+	}
+
 	// Track used IDs to detect duplicates
 	usedIDs := make(map[string]bool)
 
@@ -204,6 +266,14 @@ func (ts *TestSuiteSpec) CheckValidTestSuiteFile() error {
 			allErrors = append(allErrors, "test case has empty name")
 		}
 
+		if test.XRVariants != nil {
+			allErrors = append(allErrors, test.XRVariants.check(test)...)
+		}
+
+		if test.Fuzz != nil {
+			allErrors = append(allErrors, test.Fuzz.check(test)...)
+		}
+
 		// Only validate and check uniqueness for IDs that are explicitly provided
 		if test.ID != "" {
 			// Validate test ID format
@@ -220,6 +290,23 @@ func (ts *TestSuiteSpec) CheckValidTestSuiteFile() error {
 		}
 	}
 
+	for i := range ts.Tests {
+		test := &ts.Tests[i]
+
+		for _, id := range test.DependsOn {
+			switch {
+			case test.ID != "" && id == test.ID:
+				allErrors = append(allErrors, fmt.Sprintf("test case '%s' depends on itself", test.Name))
+			case !usedIDs[id]:
+				allErrors = append(allErrors, fmt.Sprintf("test case '%s' depends on unknown test case ID '%s'", test.Name, id))
+			}
+		}
+	}
+
+	if cycle := ts.dependencyCycle(); len(cycle) > 0 {
+		allErrors = append(allErrors, fmt.Sprintf("test case dependency cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
 	if len(allErrors) > 0 {
 		return fmt.Errorf("invalid testsuite file:\n- %s", strings.Join(allErrors, "\n- "))
 	}
@@ -227,6 +314,199 @@ func (ts *TestSuiteSpec) CheckValidTestSuiteFile() error {
 	return nil
 }
 
+// CheckCrossplaneSelectors checks that the crossplane dependencies pinned or excluded in common and in the test cases
+// are among the given configured crossplane dependencies, so that a typo does not silently skip test cases.
+func (ts *TestSuiteSpec) CheckCrossplaneSelectors(dependencies []string) error {
+	var allErrors []string
+
+	check := func(where string, selector CrossplaneSelector) {
+		for _, name := range slices.Concat(selector.Only, selector.Exclude) {
+			if !slices.Contains(dependencies, name) {
+				allErrors = append(allErrors, fmt.Sprintf("%s selects unknown crossplane dependency '%s' (configured: %s)", where, name, strings.Join(dependencies, ", ")))
+			}
+		}
+	}
+
+	check("common", ts.Common.Crossplane)
+
+	for i := range ts.Tests {
+		check(fmt.Sprintf("test case '%s'", ts.Tests[i].Name), ts.Tests[i].Crossplane)
+	}
+
+	if len(allErrors) > 0 {
+		return fmt.Errorf("invalid testsuite file:\n- %s", strings.Join(allErrors, "\n- "))
+	}
+
+	return nil
+}
+
+// xrVariantKinds are the kinds of XR variants.
+var xrVariantKinds = []string{"minimal", "maximal", "boundary"} //nolint:gochecknoglobals // list of constants
+
+// check returns the validation errors of the xr-variants of a test case: the XRD is mandatory, the kinds must be
+// known, and the XR replaces the claim and xr inputs. The test cases of the variants cannot share an ID.
+func (v *XRVariants) check(test *TestCase) []string {
+	var allErrors []string
+
+	if v.XRD == "" {
+		allErrors = append(allErrors, fmt.Sprintf("test case '%s' has xr-variants without xrd", test.Name))
+	}
+
+	for _, kind := range v.Kinds {
+		if !slices.Contains(xrVariantKinds, kind) {
+			allErrors = append(allErrors, fmt.Sprintf("test case '%s' has unknown xr-variants kind '%s' (allowed: %s)", test.Name, kind, strings.Join(xrVariantKinds, ", ")))
+		}
+	}
+
+	if test.HasClaim() || test.HasXR() {
+		allErrors = append(allErrors, fmt.Sprintf("test case '%s' has both xr-variants and a claim or xr input", test.Name))
+	}
+
+	if test.ID != "" {
+		allErrors = append(allErrors, fmt.Sprintf("test case '%s' has both xr-variants and an ID", test.Name))
+	}
+
+	return allErrors
+}
+
+// check returns the validation errors of the fuzz configuration of a test case: the XRD is mandatory, the number of
+// runs cannot be negative, and the XR replaces the claim and xr inputs and the xr-variants.
+func (f *Fuzz) check(test *TestCase) []string {
+	var allErrors []string
+
+	if f.XRD == "" {
+		allErrors = append(allErrors, fmt.Sprintf("test case '%s' has fuzz without xrd", test.Name))
+	}
+
+	if f.Runs < 0 {
+		allErrors = append(allErrors, fmt.Sprintf("test case '%s' has negative fuzz runs %d", test.Name, f.Runs))
+	}
+
+	if test.HasClaim() || test.HasXR() {
+		allErrors = append(allErrors, fmt.Sprintf("test case '%s' has both fuzz and a claim or xr input", test.Name))
+	}
+
+	if test.XRVariants != nil {
+		allErrors = append(allErrors, fmt.Sprintf("test case '%s' has both fuzz and xr-variants", test.Name))
+	}
+
+	return allErrors
+}
+
+// dependencyCycle returns the IDs of the test cases forming a cycle through their depends-on entries, starting and
+// ending with the same ID, or nil when there is no cycle. Self-dependencies and unknown IDs are not cycles.
+func (ts *TestSuiteSpec) dependencyCycle() []string {
+	dependsOn := make(map[string][]string)
+
+	for _, test := range ts.Tests {
+		if test.ID != "" {
+			dependsOn[test.ID] = test.DependsOn
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := make(map[string]int)
+
+	var (
+		path  []string
+		visit func(id string) []string
+	)
+
+	visit = func(id string) []string {
+		state[id] = visiting
+
+		path = append(path, id)
+
+		for _, dependency := range dependsOn[id] {
+			if _, ok := dependsOn[dependency]; !ok || dependency == id {
+				continue
+			}
+
+			switch state[dependency] {
+			case visiting:
+				start := slices.Index(path, dependency)
+				return append(slices.Clone(path[start:]), dependency)
+			case 0:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+
+		return nil
+	}
+
+	for _, test := range ts.Tests {
+		if test.ID != "" && state[test.ID] == 0 {
+			if cycle := visit(test.ID); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// RunOrder returns the indexes of the test cases in the order they run: every test case runs after the test cases
+// in its depends-on, and otherwise as early as its position in the testsuite file allows.
+func (ts *TestSuiteSpec) RunOrder() []int {
+	tests := ts.Tests
+
+	indexByID := make(map[string]int)
+
+	for i, testCase := range tests {
+		if testCase.ID != "" {
+			indexByID[testCase.ID] = i
+		}
+	}
+
+	ready := func(testCase TestCase, done []bool) bool {
+		for _, id := range testCase.DependsOn {
+			if j, ok := indexByID[id]; ok && id != testCase.ID && !done[j] {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	order := make([]int, 0, len(tests))
+	done := make([]bool, len(tests))
+
+	for len(order) < len(tests) {
+		next := -1
+
+		for i, testCase := range tests {
+			if !done[i] && ready(testCase, done) {
+				next = i
+				break
+			}
+		}
+
+		// Cycles are rejected by CheckValidTestSuiteFile, the remaining test cases run in file order otherwise
+		if next < 0 {
+			for i := range done {
+				if !done[i] {
+					next = i
+					break
+				}
+			}
+		}
+
+		order = append(order, next)
+		done[next] = true
+	}
+
+	return order
+}
+
 // HasCommonPatches returns true if any common patches are set in the test suite.
 func (ts *TestSuiteSpec) HasCommonPatches() bool {
 	return ts.Common.Patches.HasPatches()
@@ -242,6 +522,11 @@ func (ts *TestSuiteSpec) HasCommonAssertions() bool {
 	return ts.Common.Assertions.HasAssertions()
 }
 
+// HasCommonVars returns true if any common template variables are set in the test suite.
+func (ts *TestSuiteSpec) HasCommonVars() bool {
+	return len(ts.Common.Vars) > 0
+}
+
 // HasCommon returns true if any common inputs are set in the test suite spec.
 func (ts *TestSuiteSpec) HasCommon() bool {
 	return ts.Common.Inputs.XR != "" ||
@@ -249,6 +534,7 @@ func (ts *TestSuiteSpec) HasCommon() bool {
 		ts.Common.Inputs.Composition != "" ||
 		ts.Common.Inputs.Functions != "" ||
 		len(ts.Common.Inputs.CRDs) > 0 ||
+		len(ts.Common.Inputs.CRDsFrom) > 0 ||
 		len(ts.Common.Inputs.ContextFiles) > 0 ||
 		len(ts.Common.Inputs.ContextValues) > 0 ||
 		ts.Common.Inputs.ObservedResources != "" ||
@@ -256,7 +542,9 @@ func (ts *TestSuiteSpec) HasCommon() bool {
 		ts.Common.Inputs.FunctionCredentials != "" ||
 		ts.HasCommonPatches() ||
 		ts.HasCommonHooks() ||
-		ts.HasCommonAssertions()
+		ts.HasCommonAssertions() ||
+		ts.HasCommonVars() ||
+		ts.Common.Crossplane.HasSelection()
 }
 
 // HasXR returns true if the TestCase has an XR field specified.
@@ -269,6 +557,11 @@ func (tc *TestCase) HasClaim() bool {
 	return tc.Inputs.Claim != ""
 }
 
+// HasCRDs returns true if the TestCase has CRDs or crds-from specified, i.e. if its inputs and renders are validated.
+func (tc *TestCase) HasCRDs() bool {
+	return len(tc.Inputs.CRDs) >= 1 || len(tc.Inputs.CRDsFrom) >= 1
+}
+
 // HasPatches checks if any patches are set in the test case.
 func (tc *TestCase) HasPatches() bool {
 	return tc.Patches.HasPatches()
@@ -313,65 +606,91 @@ func (tc *TestCase) HasAssertions() bool {
 //
 //nolint:gocognit // too many ifs, but not that complex
 func (tc *TestCase) MergeCommon(common Common) {
-	if tc.Inputs.XR == "" {
-		tc.Inputs.XR = common.Inputs.XR
-	}
+	// Copies of a testcase share its positions, so the inherited ones are set on a copy of them
+	tc.positions = maps.Clone(tc.positions)
+
+	// The XR of a testcase with xr-variants or fuzz is generated
+	if tc.XRVariants == nil && tc.Fuzz == nil {
+		if tc.Inputs.XR == "" {
+			tc.Inputs.XR = common.Inputs.XR
+			tc.inheritPositions(common, "inputs.xr")
+		}
 
-	if tc.Inputs.Claim == "" {
-		tc.Inputs.Claim = common.Inputs.Claim
+		if tc.Inputs.Claim == "" {
+			tc.Inputs.Claim = common.Inputs.Claim
+			tc.inheritPositions(common, "inputs.claim")
+		}
 	}
 
 	if tc.Inputs.Composition == "" {
 		tc.Inputs.Composition = common.Inputs.Composition
+		tc.inheritPositions(common, "inputs.composition")
 	}
 
 	if tc.Inputs.Functions == "" {
 		tc.Inputs.Functions = common.Inputs.Functions
+		tc.inheritPositions(common, "inputs.functions")
 	}
 
 	if len(tc.Inputs.CRDs) == 0 && len(common.Inputs.CRDs) > 0 {
 		tc.Inputs.CRDs = make([]string, len(common.Inputs.CRDs))
 		copy(tc.Inputs.CRDs, common.Inputs.CRDs)
+		tc.inheritPositions(common, "inputs.crds")
+	}
+
+	if len(tc.Inputs.CRDsFrom) == 0 && len(common.Inputs.CRDsFrom) > 0 {
+		tc.Inputs.CRDsFrom = make([]string, len(common.Inputs.CRDsFrom))
+		copy(tc.Inputs.CRDsFrom, common.Inputs.CRDsFrom)
+		tc.inheritPositions(common, "inputs.crds-from")
 	}
 
 	if len(tc.Inputs.ContextFiles) == 0 && len(common.Inputs.ContextFiles) > 0 {
 		tc.Inputs.ContextFiles = make(map[string]string)
 		maps.Copy(tc.Inputs.ContextFiles, common.Inputs.ContextFiles)
+		tc.inheritPositions(common, "inputs.context-files")
 	}
 
 	if len(tc.Inputs.ContextValues) == 0 && len(common.Inputs.ContextValues) > 0 {
 		tc.Inputs.ContextValues = make(map[string]string)
 		maps.Copy(tc.Inputs.ContextValues, common.Inputs.ContextValues)
+		tc.inheritPositions(common, "inputs.context-values")
 	}
 
 	if tc.Inputs.ObservedResources == "" {
 		tc.Inputs.ObservedResources = common.Inputs.ObservedResources
+		tc.inheritPositions(common, "inputs.observed-resources")
 	}
 
 	if tc.Inputs.ExtraResources == "" {
 		tc.Inputs.ExtraResources = common.Inputs.ExtraResources
+		tc.inheritPositions(common, "inputs.extra-resources")
 	}
 
 	if tc.Inputs.FunctionCredentials == "" {
 		tc.Inputs.FunctionCredentials = common.Inputs.FunctionCredentials
+		tc.inheritPositions(common, "inputs.function-credentials")
 	}
 
 	// Always merge patches if common has patches
 	if common.Patches.HasPatches() {
 		if tc.Patches.XRD == "" {
 			tc.Patches.XRD = common.Patches.XRD
+			tc.inheritPositions(common, "patches.xrd")
 		}
 
 		if tc.Patches.ConnectionSecret == nil {
 			tc.Patches.ConnectionSecret = common.Patches.ConnectionSecret
+			tc.inheritPositions(common, "patches.connection-secret")
 		}
 
 		if tc.Patches.ConnectionSecretName == "" {
 			tc.Patches.ConnectionSecretName = common.Patches.ConnectionSecretName
+			tc.inheritPositions(common, "patches.connection-secret-name")
 		}
 
 		if tc.Patches.ConnectionSecretNamespace == "" {
 			tc.Patches.ConnectionSecretNamespace = common.Patches.ConnectionSecretNamespace
+			tc.inheritPositions(common, "patches.connection-secret-namespace")
 		}
 	}
 
@@ -379,10 +698,12 @@ func (tc *TestCase) MergeCommon(common Common) {
 	if common.Hooks.HasHooks() {
 		if !tc.HasPreTestHooks() {
 			tc.Hooks.PreTest = common.Hooks.PreTest
+			tc.inheritPositions(common, "hooks.pre-test")
 		}
 
 		if !tc.HasPostTestHooks() {
 			tc.Hooks.PostTest = common.Hooks.PostTest
+			tc.inheritPositions(common, "hooks.post-test")
 		}
 	}
 
@@ -390,16 +711,51 @@ func (tc *TestCase) MergeCommon(common Common) {
 	if common.Assertions.HasAssertionsXprin() && !tc.HasAssertionsXprin() {
 		tc.Assertions.Xprin = make([]AssertionXprin, len(common.Assertions.Xprin))
 		copy(tc.Assertions.Xprin, common.Assertions.Xprin)
+		tc.inheritPositions(common, "assertions.xprin")
 	}
 
 	if common.Assertions.HasAssertionsDiff() && !tc.HasAssertionsDiff() {
 		tc.Assertions.Diff = make([]AssertionGoldenFile, len(common.Assertions.Diff))
 		copy(tc.Assertions.Diff, common.Assertions.Diff)
+		tc.inheritPositions(common, "assertions.diff")
 	}
 
 	if common.Assertions.HasAssertionsDyff() && !tc.HasAssertionsDyff() {
 		tc.Assertions.Dyff = make([]AssertionGoldenFile, len(common.Assertions.Dyff))
 		copy(tc.Assertions.Dyff, common.Assertions.Dyff)
+		tc.inheritPositions(common, "assertions.dyff")
+	}
+
+	// Use the common crossplane selection unless the test case has its own
+	if common.Crossplane.HasSelection() && !tc.Crossplane.HasSelection() {
+		tc.Crossplane = CrossplaneSelector{
+			Only:    slices.Clone(common.Crossplane.Only),
+			Exclude: slices.Clone(common.Crossplane.Exclude),
+		}
+		tc.inheritPositions(common, "crossplane")
+	}
+}
+
+// Position returns the position in the testsuite file of a field of the testcase, by path relative to the testcase
+// (e.g. inputs.xr or assertions.xprin[0]), or false when it is unknown. The fields the testcase uses from the common
+// config have their position in common.
+func (tc *TestCase) Position(path string) (Position, bool) {
+	position, ok := tc.positions[path]
+	return position, ok
+}
+
+// inheritPositions sets the positions of a field the testcase uses from the common config, and of the fields under it.
+func (tc *TestCase) inheritPositions(common Common, field string) {
+	for path, position := range common.positions {
+		if path != field && !strings.HasPrefix(path, field+".") && !strings.HasPrefix(path, field+"[") {
+			continue
+		}
+
+		if tc.positions == nil {
+			tc.positions = make(map[string]Position)
+		}
+
+		tc.positions[path] = position
 	}
 }
 
@@ -411,7 +767,7 @@ func (tc *TestCase) CheckMandatoryFields() error {
 		allErrors = append(allErrors, "conflicting fields: both 'claim' and 'xr' are specified, but only one is allowed")
 	}
 
-	if !tc.HasClaim() && !tc.HasXR() {
+	if !tc.HasClaim() && !tc.HasXR() && tc.XRVariants == nil && tc.Fuzz == nil {
 		allErrors = append(allErrors, "missing mandatory field: either 'claim' or 'xr' must be specified (it can be specified either in the test case or in the common inputs)")
 	}
 
@@ -243,6 +243,103 @@ func TestCheckValidTestSuiteFile(t *testing.T) {
 			wantErr:   true,
 			errSubstr: []string{"duplicate test case ID 'test1' found"},
 		},
+		{
+			name: "valid test case dependencies",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Upgrade", ID: "upgrade", DependsOn: []string{"setup"}, Inputs: Inputs{XR: "xr.yaml"}},
+					{Name: "Setup", ID: "setup", Inputs: Inputs{XR: "xr.yaml"}},
+					{Name: "Verify", DependsOn: []string{"setup", "upgrade"}, Inputs: Inputs{XR: "xr.yaml"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid test case dependencies",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Self", ID: "self", DependsOn: []string{"self"}, Inputs: Inputs{XR: "xr.yaml"}},
+					{Name: "Unknown", DependsOn: []string{"missing"}, Inputs: Inputs{XR: "xr.yaml"}},
+					{Name: "A", ID: "a", DependsOn: []string{"c"}, Inputs: Inputs{XR: "xr.yaml"}},
+					{Name: "B", ID: "b", DependsOn: []string{"a"}, Inputs: Inputs{XR: "xr.yaml"}},
+					{Name: "C", ID: "c", DependsOn: []string{"b"}, Inputs: Inputs{XR: "xr.yaml"}},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"test case 'Self' depends on itself",
+				"test case 'Unknown' depends on unknown test case ID 'missing'",
+				"test case dependency cycle: a -> c -> b -> a",
+			},
+		},
+		{
+			name: "valid name and dependencies",
+			spec: &TestSuiteSpec{
+				Name:      "network_v2",
+				DependsOn: []string{"../network/network_xprin.yaml"},
+				Tests:     []TestCase{{Name: "Test 1", Inputs: Inputs{XR: "xr.yaml"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid name and empty dependency",
+			spec: &TestSuiteSpec{
+				Name:      "network.v2",
+				DependsOn: []string{""},
+				Tests:     []TestCase{{Name: "Test 1", Inputs: Inputs{XR: "xr.yaml"}}},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"testsuite name 'network.v2' contains invalid characters (allowed: alphanumeric, underscore, hyphen)",
+				"depends-on has empty testsuite file",
+			},
+		},
+		{
+			name: "valid xr-variants",
+			spec: &TestSuiteSpec{
+				Common: Common{Inputs: Inputs{XR: "xr.yaml"}},
+				Tests:  []TestCase{{Name: "Test 1", XRVariants: &XRVariants{XRD: "xrd.yaml", Kinds: []string{"minimal", "boundary"}}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid xr-variants",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Test 1", ID: "test1", Inputs: Inputs{Claim: "claim.yaml"}, XRVariants: &XRVariants{Kinds: []string{"random"}}},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"test case 'Test 1' has xr-variants without xrd",
+				"test case 'Test 1' has unknown xr-variants kind 'random' (allowed: minimal, maximal, boundary)",
+				"test case 'Test 1' has both xr-variants and a claim or xr input",
+				"test case 'Test 1' has both xr-variants and an ID",
+			},
+		},
+		{
+			name: "valid fuzz",
+			spec: &TestSuiteSpec{
+				Common: Common{Inputs: Inputs{Claim: "claim.yaml"}},
+				Tests:  []TestCase{{Name: "Test 1", ID: "test1", Fuzz: &Fuzz{XRD: "xrd.yaml", Runs: 50, Seed: 7}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid fuzz",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Test 1", Inputs: Inputs{XR: "xr.yaml"}, XRVariants: &XRVariants{XRD: "xrd.yaml"}, Fuzz: &Fuzz{Runs: -1}},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"test case 'Test 1' has fuzz without xrd",
+				"test case 'Test 1' has negative fuzz runs -1",
+				"test case 'Test 1' has both fuzz and a claim or xr input",
+				"test case 'Test 1' has both fuzz and xr-variants",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -601,6 +698,47 @@ func TestTestCase_mergeCommon(t *testing.T) {
 		common   Common
 		expected TestCase
 	}{
+		{
+			name: "test case with xr-variants does not use the common claim or xr",
+			testCase: TestCase{
+				Name:       "test1",
+				XRVariants: &XRVariants{XRD: "xrd.yaml"},
+			},
+			common: Common{
+				Inputs: Inputs{
+					Claim:       "common-claim.yaml",
+					XR:          "common-xr.yaml",
+					Composition: "common-composition.yaml",
+				},
+			},
+			expected: TestCase{
+				Name:       "test1",
+				XRVariants: &XRVariants{XRD: "xrd.yaml"},
+				Inputs: Inputs{
+					Composition: "common-composition.yaml",
+				},
+			},
+		},
+		{
+			name: "test case with fuzz does not use the common claim or xr",
+			testCase: TestCase{
+				Name: "test1",
+				Fuzz: &Fuzz{XRD: "xrd.yaml"},
+			},
+			common: Common{
+				Inputs: Inputs{
+					Claim:       "common-claim.yaml",
+					Composition: "common-composition.yaml",
+				},
+			},
+			expected: TestCase{
+				Name: "test1",
+				Fuzz: &Fuzz{XRD: "xrd.yaml"},
+				Inputs: Inputs{
+					Composition: "common-composition.yaml",
+				},
+			},
+		},
 		{
 			name: "test case with empty inputs fields uses common inputs",
 			testCase: TestCase{
@@ -617,6 +755,7 @@ func TestTestCase_mergeCommon(t *testing.T) {
 					Composition: "common-composition.yaml",
 					Functions:   "common-functions.yaml",
 					CRDs:        []string{"common-crd1.yaml", "common-crd2.yaml"},
+					CRDsFrom:    []string{"xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"},
 				},
 			},
 			expected: TestCase{
@@ -626,6 +765,7 @@ func TestTestCase_mergeCommon(t *testing.T) {
 					Composition: "common-composition.yaml",
 					Functions:   "common-functions.yaml",
 					CRDs:        []string{"common-crd1.yaml", "common-crd2.yaml"},
+					CRDsFrom:    []string{"xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"},
 				},
 			},
 		},
@@ -638,6 +778,7 @@ func TestTestCase_mergeCommon(t *testing.T) {
 					Composition: "test-composition.yaml",
 					Functions:   "test-functions.yaml",
 					CRDs:        []string{"test-crd1.yaml"},
+					CRDsFrom:    []string{"test-crossplane.yaml"},
 				},
 			},
 			common: Common{
@@ -645,6 +786,7 @@ func TestTestCase_mergeCommon(t *testing.T) {
 					Composition: "common-composition.yaml",
 					Functions:   "common-functions.yaml",
 					CRDs:        []string{"common-crd1.yaml", "common-crd2.yaml"},
+					CRDsFrom:    []string{"xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"},
 				},
 			},
 			expected: TestCase{
@@ -654,6 +796,7 @@ func TestTestCase_mergeCommon(t *testing.T) {
 					Composition: "test-composition.yaml",
 					Functions:   "test-functions.yaml",
 					CRDs:        []string{"test-crd1.yaml"},
+					CRDsFrom:    []string{"test-crossplane.yaml"},
 				},
 			},
 		},
@@ -1260,6 +1403,33 @@ func TestTestCase_mergeCommon(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "test case without crossplane selection uses common selection",
+			testCase: TestCase{
+				Name: "test1",
+			},
+			common: Common{
+				Crossplane: CrossplaneSelector{Exclude: []string{"crossplane-1.20"}},
+			},
+			expected: TestCase{
+				Name:       "test1",
+				Crossplane: CrossplaneSelector{Exclude: []string{"crossplane-1.20"}},
+			},
+		},
+		{
+			name: "test case crossplane selection overrides common selection",
+			testCase: TestCase{
+				Name:       "test1",
+				Crossplane: CrossplaneSelector{Only: []string{"crossplane-2.0"}},
+			},
+			common: Common{
+				Crossplane: CrossplaneSelector{Exclude: []string{"crossplane-1.20"}},
+			},
+			expected: TestCase{
+				Name:       "test1",
+				Crossplane: CrossplaneSelector{Only: []string{"crossplane-2.0"}},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1272,14 +1442,105 @@ func TestTestCase_mergeCommon(t *testing.T) {
 	}
 }
 
+func TestCrossplaneSelector_allows(t *testing.T) {
+	tests := []struct {
+		name       string
+		selector   CrossplaneSelector
+		dependency string
+		expected   bool
+	}{
+		{
+			name:       "no selection allows everything",
+			selector:   CrossplaneSelector{},
+			dependency: "crossplane",
+			expected:   true,
+		},
+		{
+			name:       "only includes dependency",
+			selector:   CrossplaneSelector{Only: []string{"crossplane-2.0"}},
+			dependency: "crossplane-2.0",
+			expected:   true,
+		},
+		{
+			name:       "only does not include dependency",
+			selector:   CrossplaneSelector{Only: []string{"crossplane-2.0"}},
+			dependency: "crossplane-1.20",
+			expected:   false,
+		},
+		{
+			name:       "excluded dependency",
+			selector:   CrossplaneSelector{Exclude: []string{"crossplane-1.20"}},
+			dependency: "crossplane-1.20",
+			expected:   false,
+		},
+		{
+			name:       "exclude wins over only",
+			selector:   CrossplaneSelector{Only: []string{"crossplane-1.20"}, Exclude: []string{"crossplane-1.20"}},
+			dependency: "crossplane-1.20",
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.selector.Allows(tt.dependency))
+			assert.Equal(t, len(tt.selector.Only) > 0 || len(tt.selector.Exclude) > 0, tt.selector.HasSelection())
+		})
+	}
+}
+
+func TestCheckCrossplaneSelectors(t *testing.T) {
+	dependencies := []string{"crossplane", "crossplane-1.20", "crossplane-2.0"}
+
+	t.Run("configured dependencies", func(t *testing.T) {
+		ts := &TestSuiteSpec{
+			Common: Common{Crossplane: CrossplaneSelector{Exclude: []string{"crossplane-1.20"}}},
+			Tests:  []TestCase{{Name: "v2 only", Crossplane: CrossplaneSelector{Only: []string{"crossplane-2.0"}}}, {Name: "any"}},
+		}
+
+		require.NoError(t, ts.CheckCrossplaneSelectors(dependencies))
+	})
+
+	t.Run("unknown dependencies", func(t *testing.T) {
+		ts := &TestSuiteSpec{
+			Common: Common{Crossplane: CrossplaneSelector{Exclude: []string{"crossplane-1.2"}}},
+			Tests:  []TestCase{{Name: "v2 only", Crossplane: CrossplaneSelector{Only: []string{"crossplane-2.O"}}}},
+		}
+
+		require.EqualError(t, ts.CheckCrossplaneSelectors(dependencies), `invalid testsuite file:
+- common selects unknown crossplane dependency 'crossplane-1.2' (configured: crossplane, crossplane-1.20, crossplane-2.0)
+- test case 'v2 only' selects unknown crossplane dependency 'crossplane-2.O' (configured: crossplane, crossplane-1.20, crossplane-2.0)`)
+	})
+}
+
 func TestTestCase_checkMandatoryFields(t *testing.T) {
 	tests := []struct {
-		name    string
-		inputs  Inputs
-		patches Patches
-		wantErr bool
-		errMsg  string
+		name       string
+		inputs     Inputs
+		patches    Patches
+		xrVariants *XRVariants
+		fuzz       *Fuzz
+		wantErr    bool
+		errMsg     string
 	}{
+		{
+			name: "valid TestCase with xr-variants",
+			inputs: Inputs{
+				Composition: "composition.yaml",
+				Functions:   "functions.yaml",
+			},
+			xrVariants: &XRVariants{XRD: "xrd.yaml"},
+			wantErr:    false,
+		},
+		{
+			name: "valid TestCase with fuzz",
+			inputs: Inputs{
+				Composition: "composition.yaml",
+				Functions:   "functions.yaml",
+			},
+			fuzz:    &Fuzz{XRD: "xrd.yaml"},
+			wantErr: false,
+		},
 		{
 			name: "valid TestCase with Claim field",
 			inputs: Inputs{
@@ -1379,7 +1640,7 @@ func TestTestCase_checkMandatoryFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			testCase := TestCase{Inputs: tt.inputs}
+			testCase := TestCase{Inputs: tt.inputs, XRVariants: tt.xrVariants, Fuzz: tt.fuzz}
 
 			err := testCase.CheckMandatoryFields()
 			if tt.wantErr {
@@ -1710,6 +1971,41 @@ func TestTestSuiteSpec_hasCommonHooks(t *testing.T) {
 	}
 }
 
+func TestTestSuiteSpec_hasCommonVars(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     TestSuiteSpec
+		expected bool
+	}{
+		{
+			name:     "no common vars",
+			spec:     TestSuiteSpec{},
+			expected: false,
+		},
+		{
+			name: "empty common vars",
+			spec: TestSuiteSpec{
+				Common: Common{Vars: map[string]string{}},
+			},
+			expected: false,
+		},
+		{
+			name: "common vars set",
+			spec: TestSuiteSpec{
+				Common: Common{Vars: map[string]string{"branch": "main"}},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.spec.HasCommonVars())
+			assert.Equal(t, tt.expected, tt.spec.HasCommon())
+		})
+	}
+}
+
 func TestTestCase_hasPreTestHooks(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,371 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// Position is the line and column of a node in a testsuite file.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// String returns the position as "line:col".
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Kinds of field errors.
+const (
+	FieldErrorUnknown = "unknown" // The field is not part of the testsuite specification
+	FieldErrorType    = "type"    // The value has the wrong type
+	FieldErrorEnum    = "enum"    // The value is not one of the allowed values
+)
+
+// FieldError is a problem with a field of a testsuite file, found by CheckNode.
+type FieldError struct {
+	Position
+	Kind    string // Kind of the problem (see FieldErrorUnknown, FieldErrorType and FieldErrorEnum)
+	Field   string // Path of the field, e.g. tests[0].assertions.xprin[1].type
+	Message string // Description of the problem
+}
+
+// Error returns the field error as "line:col: message".
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Position, e.Message)
+}
+
+// DecodeError is returned by Decode when fields of a testsuite file do not match the testsuite specification.
+type DecodeError struct {
+	Errors []FieldError
+}
+
+// Error returns the field errors, one "line:col: message" per line.
+func (e *DecodeError) Error() string {
+	lines := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		lines = append(lines, err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Decode strictly decodes a testsuite file: unknown fields, values of the wrong type and values that are not allowed
+// are returned as a *DecodeError with their positions, instead of being ignored or failing without one. The decoded
+// testsuite keeps the positions of its test cases' fields (see TestCase.Position).
+//
+// Template variables must be replaced by placeholders first, since {{ }} is not valid YAML.
+func Decode(data []byte) (*TestSuiteSpec, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+
+	var testSuiteSpec TestSuiteSpec
+
+	if len(doc.Content) == 0 {
+		return &testSuiteSpec, nil
+	}
+
+	c := &checker{positions: make(map[string]Position)}
+	c.check(doc.Content[0], reflect.TypeFor[TestSuiteSpec](), "")
+
+	if len(c.errors) > 0 {
+		return nil, &DecodeError{Errors: c.errors}
+	}
+
+	// The values are decoded like the rest of xprin decodes YAML (e.g. scalars into strings), once they are known to fit
+	if err := sigsyaml.Unmarshal(data, &testSuiteSpec); err != nil {
+		return nil, err
+	}
+
+	testSuiteSpec.Common.positions = positionsUnder(c.positions, "common")
+
+	for i := range testSuiteSpec.Tests {
+		testSuiteSpec.Tests[i].positions = positionsUnder(c.positions, fmt.Sprintf("tests[%d]", i))
+	}
+
+	return &testSuiteSpec, nil
+}
+
+// CheckNode returns the problems of the fields of a parsed testsuite file: the fields that are not part of the
+// testsuite specification, the values of the wrong type, and the values that are not allowed.
+func CheckNode(node *yaml.Node) []FieldError {
+	c := &checker{positions: make(map[string]Position)}
+	c.check(node, reflect.TypeFor[TestSuiteSpec](), "")
+
+	return c.errors
+}
+
+// positionsUnder returns the positions of the fields under a path, by path relative to it.
+func positionsUnder(positions map[string]Position, path string) map[string]Position {
+	under := make(map[string]Position)
+
+	for p, position := range positions {
+		if p == path {
+			under[""] = position
+		} else if rest, ok := strings.CutPrefix(p, path+"."); ok {
+			under[rest] = position
+		}
+	}
+
+	return under
+}
+
+// checker checks the nodes of a testsuite file against the types of the testsuite specification, and records their
+// positions by field path.
+type checker struct {
+	errors    []FieldError
+	positions map[string]Position
+}
+
+// report adds a field error at the position of a node.
+func (c *checker) report(node *yaml.Node, kind, path, format string, args ...any) {
+	c.errors = append(c.errors, FieldError{
+		Position: Position{Line: node.Line, Column: node.Column},
+		Kind:     kind,
+		Field:    path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// check checks a node against the type t of the field at path.
+//
+//nolint:gocognit,gocyclo // one case per kind of type
+func (c *checker) check(node *yaml.Node, t reflect.Type, path string) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	c.positions[path] = Position{Line: node.Line, Column: node.Column}
+
+	// A null value leaves the field unset
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return
+	case reflect.Struct:
+		if !c.expectKind(node, yaml.MappingNode, path, "a mapping") {
+			return
+		}
+
+		fields := jsonFields(t)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if key.Tag == "!!merge" {
+				c.checkMerge(value, t, path)
+				continue
+			}
+
+			field, ok := fields[key.Value]
+			if !ok {
+				message := fmt.Sprintf("unknown field '%s'", joinPath(path, key.Value))
+
+				for name := range fields {
+					if strings.EqualFold(name, key.Value) {
+						message += fmt.Sprintf(" (did you mean '%s'?)", name)
+					}
+				}
+
+				c.report(key, FieldErrorUnknown, joinPath(path, key.Value), "%s", message)
+
+				continue
+			}
+
+			c.check(value, field.Type, joinPath(path, key.Value))
+			c.checkEnum(value, field, joinPath(path, key.Value))
+		}
+	case reflect.Slice:
+		if !c.expectKind(node, yaml.SequenceNode, path, "a sequence") {
+			return
+		}
+
+		for i, item := range node.Content {
+			c.check(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		if !c.expectKind(node, yaml.MappingNode, path, "a mapping") {
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c.check(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))
+		}
+	case reflect.String:
+		// Any scalar is decoded as a string
+		c.expectKind(node, yaml.ScalarNode, path, "a string")
+	case reflect.Bool:
+		c.expectTag(node, "!!bool", path, "a boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.expectTag(node, "!!int", path, "an integer")
+	default:
+		return
+	}
+}
+
+// checkMerge checks the mappings merged into a mapping with a << key. The mapping keeps its own position.
+func (c *checker) checkMerge(node *yaml.Node, t reflect.Type, path string) {
+	position := c.positions[path]
+	defer func() { c.positions[path] = position }()
+
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			c.check(item, t, path)
+		}
+
+		return
+	}
+
+	c.check(node, t, path)
+}
+
+// checkEnum reports the values of a field that are not among the values allowed by its jsonschema tag.
+func (c *checker) checkEnum(node *yaml.Node, field reflect.StructField, path string) {
+	allowed := enumValues(field)
+	if len(allowed) == 0 {
+		return
+	}
+
+	values := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		values = node.Content
+	}
+
+	for _, value := range values {
+		if value.Kind != yaml.ScalarNode || value.Tag == "!!null" || isTemplated(value.Value) {
+			continue
+		}
+
+		if !slices.Contains(allowed, value.Value) {
+			c.report(value, FieldErrorEnum, path, "invalid value '%s' for field '%s': must be one of %s", value.Value, path, strings.Join(allowed, ", "))
+		}
+	}
+}
+
+// expectKind reports a node that is not of a kind, and returns whether it is.
+func (c *checker) expectKind(node *yaml.Node, kind yaml.Kind, path, description string) bool {
+	if node.Kind == kind {
+		return true
+	}
+
+	c.report(node, FieldErrorType, path, "field '%s' must be %s, got %s", path, description, nodeType(node))
+
+	return false
+}
+
+// expectTag reports a node that is not a scalar of a tag.
+func (c *checker) expectTag(node *yaml.Node, tag, path, description string) {
+	if node.Kind == yaml.ScalarNode && node.Tag == tag {
+		return
+	}
+
+	got := nodeType(node)
+	if node.Kind == yaml.ScalarNode {
+		got += " " + strconv.Quote(node.Value)
+	}
+
+	c.report(node, FieldErrorType, path, "field '%s' must be %s, got %s", path, description, got)
+}
+
+// nodeType returns a description of the type of a node for field errors.
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a sequence"
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!bool":
+			return "a boolean"
+		case "!!int":
+			return "an integer"
+		case "!!float":
+			return "a number"
+		default:
+			return "a string"
+		}
+	default:
+		return "an unsupported value"
+	}
+}
+
+// jsonFields returns the fields of a struct type by JSON name.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = field
+		}
+	}
+
+	return fields
+}
+
+// enumValues returns the values allowed for a field by the enum entries of its jsonschema tag.
+func enumValues(field reflect.StructField) []string {
+	var values []string
+
+	for entry := range strings.SplitSeq(field.Tag.Get("jsonschema"), ",") {
+		if value, ok := strings.CutPrefix(entry, "enum="); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// isTemplated returns true if a value has a template variable, or the placeholder it is replaced with for decoding,
+// since its actual value is only known when the test runs.
+func isTemplated(value string) bool {
+	return strings.Contains(value, "{{") || strings.Contains(value, "__OPEN__")
+}
+
+// joinPath joins a field to the path of its parent.
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "valid",
+			content: `tests:
+- name: test
+  inputs:
+    xr: 2024-01-01
+    composition: 1
+  patches:
+    connection-secret: true
+  fuzz:
+    xrd: xrd.yaml
+    runs: 3
+  xr-variants:
+    xrd: xrd.yaml
+    kinds: [minimal, __OPEN__.Vars.kind__CLOSE__]
+  assertions:
+    xprin:
+    - name: value
+      type: FieldValue
+      operator: ==
+      value: {a: [1]}
+`,
+		},
+		{
+			name: "unknown fields",
+			content: `Tests:
+- name: test
+  assertion:
+    xprin: []
+`,
+			want: []string{"1:1: unknown field 'Tests' (did you mean 'tests'?)"},
+		},
+		{
+			name: "nested unknown field",
+			content: `tests:
+- name: test
+  assertion:
+    xprin: []
+  inputs:
+    compositon: comp.yaml
+`,
+			want: []string{
+				"3:3: unknown field 'tests[0].assertion'",
+				"6:5: unknown field 'tests[0].inputs.compositon'",
+			},
+		},
+		{
+			name: "type mismatches",
+			content: `common:
+  inputs:
+    crds: crd.yaml
+    context-values:
+      key: [a]
+tests:
+- name: test
+  patches:
+    connection-secret: "yes"
+  fuzz:
+    runs: 1.5
+`,
+			want: []string{
+				"3:11: field 'common.inputs.crds' must be a sequence, got a string",
+				"5:12: field 'common.inputs.context-values.key' must be a string, got a sequence",
+				`9:24: field 'tests[0].patches.connection-secret' must be a boolean, got a string "yes"`,
+				`11:11: field 'tests[0].fuzz.runs' must be an integer, got a number "1.5"`,
+			},
+		},
+		{
+			name: "enum values",
+			content: `tests:
+- name: test
+  xr-variants:
+    kinds: [minimal, max]
+  assertions:
+    xprin:
+    - name: value
+      type: FieldValue
+      operator: "!="
+`,
+			want: []string{
+				"4:22: invalid value 'max' for field 'tests[0].xr-variants.kinds': must be one of minimal, maximal, boundary",
+				"9:17: invalid value '!=' for field 'tests[0].assertions.xprin[0].operator': must be one of ==, is",
+			},
+		},
+		{
+			name: "merge keys",
+			content: `common:
+  inputs: &inputs
+    composition: comp.yaml
+tests:
+- name: test
+  inputs:
+    <<: *inputs
+    xr: xr.yaml
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.content))
+			if len(tt.want) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+
+			got := make([]string, 0, len(decodeErr.Errors))
+			for _, fieldErr := range decodeErr.Errors {
+				got = append(got, fieldErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecode_Positions(t *testing.T) {
+	testSuiteSpec, err := Decode([]byte(`common:
+  inputs:
+    composition: comp.yaml
+    crds:
+    - crd.yaml
+  assertions:
+    diff:
+    - name: render
+      expected: golden.yaml
+tests:
+- name: test
+  inputs:
+    xr: xr.yaml
+    crds:
+    - own-crd.yaml
+  assertions:
+    xprin:
+    - name: count
+      type: Count
+      value: 1
+`))
+	require.NoError(t, err)
+
+	testCase := testSuiteSpec.Tests[0]
+	testCase.MergeCommon(testSuiteSpec.Common)
+
+	want := map[string]Position{
+		"":                    {Line: 11, Column: 3},
+		"inputs.xr":           {Line: 13, Column: 9},
+		"inputs.composition":  {Line: 3, Column: 18},
+		"inputs.crds[0]":      {Line: 15, Column: 7},
+		"assertions.xprin[0]": {Line: 18, Column: 7},
+		"assertions.diff[0]":  {Line: 8, Column: 7},
+	}
+
+	for path, position := range want {
+		got, ok := testCase.Position(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, position, got, path)
+	}
+
+	_, ok := testCase.Position("inputs.functions")
+	assert.False(t, ok)
+
+	// The common positions are set on the merged copy only
+	_, ok = testSuiteSpec.Tests[0].Position("inputs.composition")
+	assert.False(t, ok)
+}
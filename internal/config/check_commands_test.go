@@ -147,3 +147,37 @@ func TestCheckSubcommands(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{name: "nil validation section", cfg: &Config{}},
+		{name: "crossplane validator", cfg: &Config{Validation: &Validation{Validator: ValidatorCrossplane}}},
+		{name: "builtin validator", cfg: &Config{Validation: &Validation{Validator: ValidatorBuiltin}}},
+		{
+			name:    "unknown validator",
+			cfg:     &Config{Validation: &Validation{Validator: "kubeconform"}},
+			wantErr: "validation.validator must be 'crossplane' or 'builtin', got 'kubeconform'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.CheckValidation()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckValidation() unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckValidation() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -131,6 +131,26 @@ func TestCheckDependencies(t *testing.T) {
 			},
 			wantErr: "missing mandatory dependencies",
 		},
+		{
+			name: "only named crossplane dependencies",
+			cfg: &Config{
+				Dependencies: map[string]string{
+					"crossplane-1.20": "go",
+					"crossplane-2.0":  executablePath,
+				},
+			},
+			wantErr: "missing mandatory dependencies: crossplane",
+		},
+		{
+			name: "no crossplane dependency",
+			cfg: &Config{
+				Dependencies: map[string]string{
+					"crossplanex": "go",
+					"crossplane-": "go",
+				},
+			},
+			wantErr: "missing mandatory dependencies: crossplane",
+		},
 		{
 			name: "invalid dependency command",
 			cfg: &Config{
@@ -195,6 +215,42 @@ func TestCheckDependencies(t *testing.T) {
 	}
 }
 
+func TestIsCrossplaneDependency(t *testing.T) {
+	tests := map[string]bool{
+		"crossplane":      true,
+		"crossplane-1.20": true,
+		"crossplane-2.0":  true,
+		"crossplane-":     false,
+		"crossplanex":     false,
+		"my-crossplane":   false,
+		"":                false,
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsCrossplaneDependency(name); got != want {
+				t.Errorf("IsCrossplaneDependency(%q) = %v, want %v", name, got, want)
+			}
+		})
+	}
+}
+
+func TestCrossplaneDependencies(t *testing.T) {
+	cfg := &Config{
+		Dependencies: map[string]string{
+			"crossplane-2.0":  "crossplane2",
+			"crossplane":      "crossplane",
+			"other":           "other",
+			"crossplane-1.20": "crossplane120",
+		},
+	}
+
+	got := strings.Join(cfg.CrossplaneDependencies(), ",")
+	if want := "crossplane,crossplane-1.20,crossplane-2.0"; got != want {
+		t.Errorf("CrossplaneDependencies() = %s, want %s", got, want)
+	}
+}
+
 func TestCheckDependencyWithNonExecutableFile(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -22,6 +22,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/crossplane-contrib/xprin/internal/utils"
@@ -70,21 +71,42 @@ func CheckDependency(dep string) error {
 	return nil
 }
 
+// IsCrossplaneDependency returns true if name is the default crossplane dependency or a named one (crossplane-<name>).
+func IsCrossplaneDependency(name string) bool {
+	return name == CrossplaneCmd || (strings.HasPrefix(name, CrossplaneCmd+"-") && len(name) > len(CrossplaneCmd)+1)
+}
+
+// CrossplaneDependencies returns the sorted names of all configured crossplane dependencies.
+func (c *Config) CrossplaneDependencies() []string {
+	return CrossplaneDependencies(c.Dependencies)
+}
+
+// CrossplaneDependencies returns the sorted names of the crossplane dependencies among dependencies.
+func CrossplaneDependencies(dependencies map[string]string) []string {
+	var names []string
+
+	for name := range dependencies {
+		if IsCrossplaneDependency(name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 // CheckDependencies checks if all required dependencies are present and valid.
 func (c *Config) CheckDependencies() error {
-	mandatoryDeps := []string{"crossplane"}
-
 	var (
 		missingDeps []string
 		invalidDeps []string
 	)
 
-	// Check for missing mandatory dependencies
-
-	for _, dep := range mandatoryDeps {
-		if _, exists := c.Dependencies[dep]; !exists {
-			missingDeps = append(missingDeps, dep)
-		}
+	// Check for missing mandatory dependencies: crossplane is the dependency of the default run, named ones
+	// (e.g. crossplane-2.0) are only used when selected with --crossplane or --compare-with
+	if _, exists := c.Dependencies[CrossplaneCmd]; !exists {
+		missingDeps = append(missingDeps, CrossplaneCmd)
 	}
 
 	// Check all configured dependencies
@@ -164,6 +186,20 @@ func (c *Config) CheckSubcommands() error {
 	return nil
 }
 
+// CheckValidation checks if validation.validator is a known validator.
+func (c *Config) CheckValidation() error {
+	if c.Validation == nil {
+		return nil // validation section is optional
+	}
+
+	switch c.Validation.Validator {
+	case "", ValidatorCrossplane, ValidatorBuiltin:
+		return nil
+	default:
+		return fmt.Errorf("invalid validation section:\nvalidation.validator must be '%s' or '%s', got '%s'", ValidatorCrossplane, ValidatorBuiltin, c.Validation.Validator)
+	}
+}
+
 // CheckRepositories checks if all configured repositories are valid
 //
 //nolint:gocognit // Complex validation logic with multiple conditions
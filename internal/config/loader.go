@@ -20,7 +20,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/crossplane-contrib/xprin/internal/utils"
@@ -33,6 +32,28 @@ type Config struct {
 	Dependencies map[string]string `yaml:"dependencies"`
 	Subcommands  *Subcommands      `yaml:"subcommands"`
 	Repositories map[string]string `yaml:"repositories"`
+	Vars         map[string]string `yaml:"vars"`
+	Discovery    *Discovery        `yaml:"discovery"`
+	Validation   *Validation       `yaml:"validation"`
+	// Files lists the configuration files that were layered into this config, from lowest to highest precedence.
+	Files []string `json:"-"`
+	// Sources maps "section.key" to where the effective value came from (file path, environment variable, PATH or default),
+	// or to the comma-separated sources of the discovery exclude patterns, which accumulate across the layers.
+	Sources map[string]string `json:"-"`
+}
+
+// Discovery holds the testsuite discovery patterns.
+type Discovery struct {
+	// Include lists the file name patterns of testsuite files; when empty, the default patterns are used.
+	Include []string `yaml:"include"`
+	// Exclude lists gitignore-style patterns of the paths skipped by discovery, in addition to .xprinignore files.
+	Exclude []string `yaml:"exclude"`
+}
+
+// Validation holds the schema validation settings.
+type Validation struct {
+	// Validator is the validator of the rendered resources: ValidatorCrossplane or ValidatorBuiltin.
+	Validator string `yaml:"validator"`
 }
 
 // Subcommands holds the subcommand configurations.
@@ -58,10 +79,16 @@ const (
 	ValidateFlags = "--error-on-missing-schemas"
 	// DefaultValidateCmd is the default command for the crossplane validate subcommand.
 	DefaultValidateCmd = ValidateSubcommand + " " + ValidateFlags
+
+	// ValidatorCrossplane validates the rendered resources with the crossplane validate subcommand.
+	ValidatorCrossplane = "crossplane"
+	// ValidatorBuiltin validates the rendered resources in-process, without running crossplane.
+	ValidatorBuiltin = "builtin"
 )
 
-// Load loads and validates an xprin configuration file.
-func Load(fs afero.Fs, configPath string) (*Config, error) {
+// parse reads and validates a single xprin configuration file without applying defaults,
+// so that the result can be layered over other configuration sources.
+func parse(fs afero.Fs, configPath string) (*Config, error) {
 	if !strings.HasSuffix(configPath, ".yaml") {
 		return nil, fmt.Errorf("Config file must have .yaml extension")
 	}
@@ -104,46 +131,44 @@ func Load(fs afero.Fs, configPath string) (*Config, error) {
 		cfg.Repositories = make(map[string]string)
 	}
 
-	// Set default subcommands if not provided
-	if cfg.Subcommands.Render == "" {
-		cfg.Subcommands.Render = DefaultRenderCmd
+	if cfg.Vars == nil {
+		cfg.Vars = make(map[string]string)
+	}
+
+	if cfg.Discovery == nil {
+		cfg.Discovery = &Discovery{}
 	}
 
-	if cfg.Subcommands.Validate == "" {
-		cfg.Subcommands.Validate = DefaultValidateCmd
+	if cfg.Validation == nil {
+		cfg.Validation = &Validation{}
 	}
 
 	return &cfg, nil
 }
 
-// Fallback returns a config that uses only binaries from PATH.
-func Fallback() (*Config, error) {
-	mandatoryDeps := []string{CrossplaneCmd}
-
-	foundDeps := make(map[string]string)
+// setDefaults sets the default subcommands if they are not provided.
+// When source is not empty, it is recorded as the source of the defaulted values.
+func (c *Config) setDefaults(source string) {
+	if c.Subcommands == nil {
+		c.Subcommands = &Subcommands{}
+	}
 
-	var missingMandatoryDeps []string
+	if c.Subcommands.Render == "" {
+		c.Subcommands.Render = DefaultRenderCmd
+		c.setSource(SectionSubcommands, "render", source)
+	}
 
-	// Check mandatory dependencies
-	for _, dep := range mandatoryDeps {
-		if _, err := exec.LookPath(dep); err != nil {
-			missingMandatoryDeps = append(missingMandatoryDeps, dep)
-		} else {
-			foundDeps[dep] = dep
-		}
+	if c.Subcommands.Validate == "" {
+		c.Subcommands.Validate = DefaultValidateCmd
+		c.setSource(SectionSubcommands, "validate", source)
 	}
 
-	// Fail only if mandatory dependencies are missing
-	if len(missingMandatoryDeps) > 0 {
-		return nil, fmt.Errorf("missing required dependencies from PATH (%s)", strings.Join(missingMandatoryDeps, ", "))
+	if c.Validation == nil {
+		c.Validation = &Validation{}
 	}
 
-	return &Config{
-		Dependencies: foundDeps,
-		Subcommands: &Subcommands{
-			Render:   DefaultRenderCmd,
-			Validate: DefaultValidateCmd,
-		},
-		Repositories: make(map[string]string),
-	}, nil
+	if c.Validation.Validator == "" {
+		c.Validation.Validator = ValidatorCrossplane
+		c.setSource(SectionValidation, "validator", source)
+	}
 }
@@ -17,8 +17,6 @@ limitations under the License.
 package config
 
 import (
-	"os"
-	"path/filepath"
 	"strings"
 	"testing"
 
@@ -27,8 +25,8 @@ import (
 	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
 )
 
-// TestLoad tests loading and validating configuration files.
-func TestLoad(t *testing.T) {
+// TestParse tests parsing and validating configuration files, with the defaults Resolve sets after layering them.
+func TestParse(t *testing.T) {
 	// Helper to create string pointer for test cases
 	strPtr := func(s string) *string { return &s }
 
@@ -65,6 +63,35 @@ func TestLoad(t *testing.T) {
 				if cfg.Repositories == nil {
 					t.Error("Repositories slice should be initialized")
 				}
+
+				if cfg.Vars == nil {
+					t.Error("Vars map should be initialized")
+				}
+			},
+		},
+		{
+			name:       "config with vars",
+			configPath: "/vars.yaml",
+			configData: strPtr(`dependencies:
+  crossplane: go
+vars:
+  branch: main
+  provider-version: "v1.2.3"
+`),
+			validate: func(t *testing.T, cfg *Config) {
+				t.Helper()
+
+				if len(cfg.Vars) != 2 {
+					t.Errorf("Expected 2 vars, got %d", len(cfg.Vars))
+				}
+
+				if v := cfg.Vars["branch"]; v != "main" {
+					t.Errorf("Expected var branch=main, got %q", v)
+				}
+
+				if v := cfg.Vars["provider-version"]; v != "v1.2.3" {
+					t.Errorf("Expected var provider-version=v1.2.3, got %q", v)
+				}
 			},
 		},
 		{
@@ -359,7 +386,7 @@ repositories: {}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// All tests can use in-memory filesystem
-			// (Load() only parses YAML, doesn't validate repositories)
+			// (parse() only parses YAML, doesn't validate repositories)
 			fs := afero.NewMemMapFs()
 
 			if tt.setupFunc != nil {
@@ -368,7 +395,7 @@ repositories: {}
 
 			// Write file if configData is provided (nil = don't write, empty string = write empty file)
 			// We expand the path here to write the file to the in-memory filesystem.
-			// Load() will expand it again when reading (this is expected behavior).
+			// parse() will expand it again when reading (this is expected behavior).
 			// Note: We're not testing tilde expansion of the config file path here - we're just
 			// using it to set up the test. Tilde expansion is tested in utils/pathutil_test.go.
 			if tt.configData != nil {
@@ -377,50 +404,19 @@ repositories: {}
 				require.NoError(t, afero.WriteFile(fs, expandedPath, []byte(*tt.configData), 0o644))
 			}
 
-			cfg, err := Load(fs, tt.configPath)
+			cfg, err := parse(fs, tt.configPath)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("parse() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
+			if err == nil {
+				cfg.setDefaults("")
+			}
+
 			if !tt.wantErr && tt.validate != nil {
 				tt.validate(t, cfg)
 			}
 		})
 	}
 }
-
-func TestFallback(t *testing.T) {
-	tmpDir := t.TempDir()
-	crossplanePath := filepath.Join(tmpDir, "crossplane")
-
-	createBin := func(path string) {
-		if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
-			t.Fatalf("failed to create binary: %v", err)
-		}
-	}
-
-	// Case 1: No mandatory dependencies -> fail
-	t.Setenv("PATH", tmpDir)
-
-	_, err := Fallback()
-	if err == nil {
-		t.Error("Expected error when no mandatory dependencies are present")
-	}
-
-	// Case 2: Only mandatory dependencies (crossplane) -> success
-	createBin(crossplanePath)
-
-	cfg, err := Fallback()
-	if err != nil {
-		t.Errorf("Expected no error when mandatory dependency is present, got: %v", err)
-	}
-
-	if cfg == nil || cfg.Dependencies["crossplane"] != "crossplane" {
-		t.Error("Fallback config did not set crossplane dependency correctly")
-	}
-	// Only crossplane should be in dependencies since convert-claim-to-xr and patch-xr are now library dependencies
-	if len(cfg.Dependencies) != 1 {
-		t.Errorf("Expected only 1 dependency (crossplane), got %d: %v", len(cfg.Dependencies), cfg.Dependencies)
-	}
-}
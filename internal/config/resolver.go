@@ -0,0 +1,361 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+const (
+	// ProjectConfigFileName is the name of the project-local configuration file.
+	ProjectConfigFileName = ".xprin.yaml"
+
+	// EnvPrefix is the prefix of environment variables that override configuration values
+	// (e.g. XPRIN_DEPENDENCIES_CROSSPLANE, XPRIN_SUBCOMMANDS_RENDER, XPRIN_REPOSITORIES_MYREPO, XPRIN_VARS_BRANCH,
+	// XPRIN_DISCOVERY_EXCLUDE, XPRIN_VALIDATION_VALIDATOR).
+	EnvPrefix = "XPRIN_"
+
+	// SourceDefault is the source of values set to their built-in defaults.
+	SourceDefault = "default"
+	// SourcePath is the source of dependencies detected in PATH.
+	SourcePath = "PATH"
+)
+
+// Configuration sections, used as the first part of the keys in Config.Sources.
+const (
+	SectionDependencies = "dependencies"
+	SectionSubcommands  = "subcommands"
+	SectionRepositories = "repositories"
+	SectionVars         = "vars"
+	SectionDiscovery    = "discovery"
+	SectionValidation   = "validation"
+)
+
+// ResolveOptions configures how the effective configuration is resolved.
+type ResolveOptions struct {
+	UserConfigPath string   // Path to the user configuration file (e.g. ~/.config/xprin.yaml); a missing file is not an error
+	StartDir       string   // Directory where the project configuration discovery starts
+	Environ        []string // Environment in "KEY=value" form, scanned for XPRIN_* overrides
+}
+
+// Resolve builds the effective configuration by layering, from lowest to highest precedence:
+// the user configuration file, the project configuration file (.xprin.yaml, discovered by walking up
+// from StartDir to the git root) and XPRIN_* environment variables. A crossplane dependency that is
+// still missing is looked up in PATH, and missing subcommands are set to their defaults.
+func Resolve(fs afero.Fs, opts ResolveOptions) (*Config, error) {
+	cfg := &Config{
+		Dependencies: make(map[string]string),
+		Subcommands:  &Subcommands{},
+		Repositories: make(map[string]string),
+		Vars:         make(map[string]string),
+		Discovery:    &Discovery{},
+		Validation:   &Validation{},
+		Sources:      make(map[string]string),
+	}
+
+	var userConfigPath string
+
+	if opts.UserConfigPath != "" {
+		userCfg, err := parse(fs, opts.UserConfigPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		if err == nil {
+			userConfigPath, _ = utils.ExpandTildeAbs(opts.UserConfigPath)
+			cfg.merge(userCfg, userConfigPath)
+		}
+	}
+
+	if opts.StartDir != "" {
+		projectConfigPath, err := FindProjectConfig(fs, opts.StartDir)
+		if err != nil {
+			return nil, err
+		}
+
+		if projectConfigPath != "" && projectConfigPath != userConfigPath {
+			projectCfg, err := parse(fs, projectConfigPath)
+			if err != nil {
+				return nil, err
+			}
+
+			projectCfg.resolveRelativePaths(filepath.Dir(projectConfigPath))
+			cfg.merge(projectCfg, projectConfigPath)
+		}
+	}
+
+	cfg.applyEnv(opts.Environ)
+
+	if _, ok := cfg.Dependencies[CrossplaneCmd]; !ok {
+		if _, err := exec.LookPath(CrossplaneCmd); err == nil {
+			cfg.Dependencies[CrossplaneCmd] = CrossplaneCmd
+			cfg.setSource(SectionDependencies, CrossplaneCmd, SourcePath)
+		} else if len(cfg.Files) == 0 {
+			// Without any configuration file, crossplane must be in PATH
+			return nil, fmt.Errorf("missing required dependencies from PATH (%s)", CrossplaneCmd)
+		}
+	}
+
+	cfg.setDefaults(SourceDefault)
+
+	return cfg, nil
+}
+
+// FindProjectConfig walks up from startDir looking for a project configuration file.
+// The search stops at the git root (the first directory containing .git) or at the filesystem root.
+// It returns an empty path if no project configuration file is found.
+func FindProjectConfig(fs afero.Fs, startDir string) (string, error) {
+	dir, err := utils.ExpandTildeAbs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand project config search path: %w", err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := fs.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+
+		if _, err := fs.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+
+		dir = parent
+	}
+}
+
+// SearchDirForTarget returns the directory where project configuration discovery starts for a test target:
+// the directory itself for directories and recursive targets (e.g. "tests/..."), or the parent directory for files.
+func SearchDirForTarget(fs afero.Fs, target string) string {
+	dir := strings.TrimSuffix(strings.TrimSuffix(target, "..."), string(filepath.Separator))
+	if dir == "" {
+		if filepath.IsAbs(target) {
+			return string(filepath.Separator)
+		}
+
+		return "."
+	}
+
+	if info, err := fs.Stat(dir); err == nil && info.IsDir() {
+		return dir
+	}
+
+	return filepath.Dir(dir)
+}
+
+// SourceOf returns where the effective value of section.key came from, or an empty string if unknown.
+func (c *Config) SourceOf(section, key string) string {
+	return c.Sources[section+"."+key]
+}
+
+// DescribeSource returns " (from <source>)" for section.key, or an empty string if the source is unknown.
+func (c *Config) DescribeSource(section, key string) string {
+	if source := c.SourceOf(section, key); source != "" {
+		return fmt.Sprintf(" (from %s)", source)
+	}
+
+	return ""
+}
+
+// setSource records the source of section.key. Empty sources are ignored.
+func (c *Config) setSource(section, key, source string) {
+	if source == "" {
+		return
+	}
+
+	if c.Sources == nil {
+		c.Sources = make(map[string]string)
+	}
+
+	c.Sources[section+"."+key] = source
+}
+
+// addSource records source as one more source of section.key, for the values that accumulate across the layers.
+func (c *Config) addSource(section, key, source string) {
+	if existing := c.SourceOf(section, key); existing != "" {
+		source = existing + ", " + source
+	}
+
+	c.setSource(section, key, source)
+}
+
+// merge layers the non-empty values of other over c and records source for each of them.
+func (c *Config) merge(other *Config, source string) {
+	for name, value := range other.Dependencies {
+		c.Dependencies[name] = value
+		c.setSource(SectionDependencies, name, source)
+	}
+
+	for name, value := range other.Repositories {
+		c.Repositories[name] = value
+		c.setSource(SectionRepositories, name, source)
+	}
+
+	for name, value := range other.Vars {
+		c.Vars[name] = value
+		c.setSource(SectionVars, name, source)
+	}
+
+	if other.Subcommands != nil {
+		if other.Subcommands.Render != "" {
+			c.Subcommands.Render = other.Subcommands.Render
+			c.setSource(SectionSubcommands, "render", source)
+		}
+
+		if other.Subcommands.Validate != "" {
+			c.Subcommands.Validate = other.Subcommands.Validate
+			c.setSource(SectionSubcommands, "validate", source)
+		}
+	}
+
+	if other.Discovery != nil {
+		if len(other.Discovery.Include) > 0 {
+			c.Discovery.Include = other.Discovery.Include
+			c.setSource(SectionDiscovery, "include", source)
+		}
+
+		// Exclude patterns accumulate, so that a project can exclude paths in addition to the user configuration
+		if len(other.Discovery.Exclude) > 0 {
+			c.Discovery.Exclude = append(c.Discovery.Exclude, other.Discovery.Exclude...)
+			c.addSource(SectionDiscovery, "exclude", source)
+		}
+	}
+
+	if other.Validation != nil && other.Validation.Validator != "" {
+		c.Validation.Validator = other.Validation.Validator
+		c.setSource(SectionValidation, "validator", source)
+	}
+
+	c.Files = append(c.Files, source)
+}
+
+// resolveRelativePaths makes relative repository paths, and relative dependency paths
+// (values containing a path separator), relative to baseDir instead of the working directory.
+func (c *Config) resolveRelativePaths(baseDir string) {
+	for name, path := range c.Repositories {
+		if path != "" && !filepath.IsAbs(path) && !strings.HasPrefix(path, "~") {
+			c.Repositories[name] = filepath.Join(baseDir, path)
+		}
+	}
+
+	for name, value := range c.Dependencies {
+		if strings.ContainsRune(value, filepath.Separator) && !filepath.IsAbs(value) && !strings.HasPrefix(value, "~") {
+			c.Dependencies[name] = filepath.Join(baseDir, value)
+		}
+	}
+}
+
+// applyEnv applies XPRIN_<SECTION>_<KEY>=value overrides from environ.
+// Keys are matched case-insensitively against existing keys, treating '-', '.' and '_' as equal.
+// New dependency and repository keys are lowercased with '_' replaced by '-'; new var keys are lowercased.
+// Discovery patterns are comma-separated: XPRIN_DISCOVERY_INCLUDE replaces the include patterns and
+// XPRIN_DISCOVERY_EXCLUDE adds exclude patterns. Variables with an unknown section or an empty key are ignored.
+func (c *Config) applyEnv(environ []string) {
+	for _, entry := range environ {
+		name, value, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(name, EnvPrefix) {
+			continue
+		}
+
+		section, key, found := strings.Cut(strings.TrimPrefix(name, EnvPrefix), "_")
+		if !found || key == "" {
+			continue
+		}
+
+		source := "env " + name
+
+		switch strings.ToLower(section) {
+		case SectionDependencies:
+			key = matchKey(c.Dependencies, key, strings.ReplaceAll(strings.ToLower(key), "_", "-"))
+			c.Dependencies[key] = value
+			c.setSource(SectionDependencies, key, source)
+		case SectionRepositories:
+			key = matchKey(c.Repositories, key, strings.ReplaceAll(strings.ToLower(key), "_", "-"))
+			c.Repositories[key] = value
+			c.setSource(SectionRepositories, key, source)
+		case SectionVars:
+			key = matchKey(c.Vars, key, strings.ToLower(key))
+			c.Vars[key] = value
+			c.setSource(SectionVars, key, source)
+		case SectionSubcommands:
+			switch strings.ToLower(key) {
+			case "render":
+				c.Subcommands.Render = value
+				c.setSource(SectionSubcommands, "render", source)
+			case "validate":
+				c.Subcommands.Validate = value
+				c.setSource(SectionSubcommands, "validate", source)
+			}
+		case SectionDiscovery:
+			switch strings.ToLower(key) {
+			case "include":
+				c.Discovery.Include = splitPatterns(value)
+				c.setSource(SectionDiscovery, "include", source)
+			case "exclude":
+				c.Discovery.Exclude = append(c.Discovery.Exclude, splitPatterns(value)...)
+				c.addSource(SectionDiscovery, "exclude", source)
+			}
+		case SectionValidation:
+			if strings.EqualFold(key, "validator") {
+				c.Validation.Validator = value
+				c.setSource(SectionValidation, "validator", source)
+			}
+		}
+	}
+}
+
+// splitPatterns splits a comma-separated list of patterns, ignoring empty entries.
+func splitPatterns(value string) []string {
+	var patterns []string
+
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+// matchKey returns the existing key in m that matches envKey, or fallback if there is none.
+func matchKey(m map[string]string, envKey, fallback string) string {
+	normalize := func(s string) string {
+		return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(s))
+	}
+
+	want := normalize(envKey)
+	for key := range m {
+		if normalize(key) == want {
+			return key
+		}
+	}
+
+	return fallback
+}
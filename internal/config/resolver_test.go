@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// setEmptyPath points PATH to an empty directory so that crossplane is never found in PATH.
+func setEmptyPath(t *testing.T) {
+	t.Helper()
+	t.Setenv("PATH", t.TempDir())
+}
+
+func TestResolve(t *testing.T) {
+	const (
+		userConfig    = "/home/user/.config/xprin.yaml"
+		projectConfig = "/repo/.xprin.yaml"
+	)
+
+	writeFiles := func(t *testing.T, files map[string]string) afero.Fs {
+		t.Helper()
+
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll("/repo/.git", 0o755))
+		require.NoError(t, fs.MkdirAll("/repo/tests/aws", 0o755))
+
+		for path, content := range files {
+			require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+		}
+
+		return fs
+	}
+
+	t.Run("project config is layered over user config", func(t *testing.T) {
+		setEmptyPath(t)
+
+		fs := writeFiles(t, map[string]string{
+			userConfig: `dependencies:
+  crossplane: /usr/local/bin/crossplane
+subcommands:
+  render: render --user
+repositories:
+  shared: /home/user/shared
+vars:
+  branch: user
+  region: eu
+`,
+			projectConfig: `dependencies:
+  crossplane: bin/crossplane
+subcommands:
+  validate: beta validate --project
+repositories:
+  local: ../local
+vars:
+  branch: project
+`,
+		})
+
+		cfg, err := Resolve(fs, ResolveOptions{UserConfigPath: userConfig, StartDir: "/repo/tests/aws"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{userConfig, projectConfig}, cfg.Files)
+		assert.Equal(t, map[string]string{"crossplane": "/repo/bin/crossplane"}, cfg.Dependencies)
+		assert.Equal(t, "render --user", cfg.Subcommands.Render)
+		assert.Equal(t, "beta validate --project", cfg.Subcommands.Validate)
+		assert.Equal(t, map[string]string{"shared": "/home/user/shared", "local": "/local"}, cfg.Repositories)
+		assert.Equal(t, map[string]string{"branch": "project", "region": "eu"}, cfg.Vars)
+
+		assert.Equal(t, projectConfig, cfg.SourceOf(SectionDependencies, "crossplane"))
+		assert.Equal(t, userConfig, cfg.SourceOf(SectionSubcommands, "render"))
+		assert.Equal(t, projectConfig, cfg.SourceOf(SectionSubcommands, "validate"))
+		assert.Equal(t, userConfig, cfg.SourceOf(SectionRepositories, "shared"))
+		assert.Equal(t, userConfig, cfg.SourceOf(SectionVars, "region"))
+		assert.Equal(t, projectConfig, cfg.SourceOf(SectionVars, "branch"))
+	})
+
+	t.Run("environment overrides files", func(t *testing.T) {
+		setEmptyPath(t)
+
+		fs := writeFiles(t, map[string]string{
+			projectConfig: `dependencies:
+  crossplane: crossplane
+repositories:
+  my-repo: /repo/my-repo
+vars:
+  Branch: project
+`,
+		})
+
+		cfg, err := Resolve(fs, ResolveOptions{
+			StartDir: "/repo",
+			Environ: []string{
+				"XPRIN_DEPENDENCIES_CROSSPLANE=/opt/crossplane",
+				"XPRIN_REPOSITORIES_MY_REPO=/env/my-repo",
+				"XPRIN_REPOSITORIES_NEW_REPO=/env/new-repo",
+				"XPRIN_SUBCOMMANDS_RENDER=render --env",
+				"XPRIN_VARS_BRANCH=env",
+				"XPRIN_VARS_PROVIDER_VERSION=v2",
+				"XPRIN_UNKNOWN_KEY=ignored",
+				"XPRIN_VARS_=ignored",
+				"HOME=/home/user",
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/opt/crossplane", cfg.Dependencies["crossplane"])
+		assert.Equal(t, "env XPRIN_DEPENDENCIES_CROSSPLANE", cfg.SourceOf(SectionDependencies, "crossplane"))
+		assert.Equal(t, map[string]string{"my-repo": "/env/my-repo", "new-repo": "/env/new-repo"}, cfg.Repositories)
+		assert.Equal(t, "render --env", cfg.Subcommands.Render)
+		assert.Equal(t, "env XPRIN_SUBCOMMANDS_RENDER", cfg.SourceOf(SectionSubcommands, "render"))
+		assert.Equal(t, DefaultValidateCmd, cfg.Subcommands.Validate)
+		assert.Equal(t, SourceDefault, cfg.SourceOf(SectionSubcommands, "validate"))
+		assert.Equal(t, map[string]string{"Branch": "env", "provider_version": "v2"}, cfg.Vars)
+	})
+
+	t.Run("discovery includes are replaced and excludes accumulate", func(t *testing.T) {
+		setEmptyPath(t)
+
+		fs := writeFiles(t, map[string]string{
+			userConfig: `dependencies:
+  crossplane: crossplane
+discovery:
+  include: ["*_xprin.yaml"]
+  exclude: [drafts/]
+`,
+			projectConfig: `discovery:
+  include: ["*.test.yaml"]
+  exclude: [/tests/legacy]
+`,
+		})
+
+		cfg, err := Resolve(fs, ResolveOptions{
+			UserConfigPath: userConfig,
+			StartDir:       "/repo",
+			Environ:        []string{"XPRIN_DISCOVERY_EXCLUDE=tmp/, *.bak"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"*.test.yaml"}, cfg.Discovery.Include)
+		assert.Equal(t, projectConfig, cfg.SourceOf(SectionDiscovery, "include"))
+		assert.Equal(t, []string{"drafts/", "/tests/legacy", "tmp/", "*.bak"}, cfg.Discovery.Exclude)
+		assert.Equal(t, userConfig+", "+projectConfig+", env XPRIN_DISCOVERY_EXCLUDE", cfg.SourceOf(SectionDiscovery, "exclude"))
+
+		cfg, err = Resolve(fs, ResolveOptions{
+			UserConfigPath: userConfig,
+			StartDir:       "/repo",
+			Environ:        []string{"XPRIN_DISCOVERY_INCLUDE=xprin.yaml,*_xprin.yml"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"xprin.yaml", "*_xprin.yml"}, cfg.Discovery.Include)
+	})
+
+	t.Run("validator", func(t *testing.T) {
+		setEmptyPath(t)
+
+		fs := writeFiles(t, map[string]string{
+			userConfig: `dependencies:
+  crossplane: crossplane
+`,
+			projectConfig: `validation:
+  validator: builtin
+`,
+		})
+
+		cfg, err := Resolve(fs, ResolveOptions{UserConfigPath: userConfig})
+		require.NoError(t, err)
+		assert.Equal(t, ValidatorCrossplane, cfg.Validation.Validator)
+		assert.Equal(t, SourceDefault, cfg.SourceOf(SectionValidation, "validator"))
+
+		cfg, err = Resolve(fs, ResolveOptions{UserConfigPath: userConfig, StartDir: "/repo"})
+		require.NoError(t, err)
+		assert.Equal(t, ValidatorBuiltin, cfg.Validation.Validator)
+		assert.Equal(t, projectConfig, cfg.SourceOf(SectionValidation, "validator"))
+
+		cfg, err = Resolve(fs, ResolveOptions{UserConfigPath: userConfig, StartDir: "/repo", Environ: []string{"XPRIN_VALIDATION_VALIDATOR=crossplane"}})
+		require.NoError(t, err)
+		assert.Equal(t, ValidatorCrossplane, cfg.Validation.Validator)
+		assert.Equal(t, "env XPRIN_VALIDATION_VALIDATOR", cfg.SourceOf(SectionValidation, "validator"))
+	})
+
+	t.Run("missing user config falls back to PATH", func(t *testing.T) {
+		binDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, "crossplane"), []byte("#!/bin/sh\nexit 0\n"), 0o755))
+		t.Setenv("PATH", binDir)
+
+		cfg, err := Resolve(afero.NewMemMapFs(), ResolveOptions{UserConfigPath: userConfig, StartDir: "/"})
+		require.NoError(t, err)
+
+		assert.Empty(t, cfg.Files)
+		assert.Equal(t, map[string]string{"crossplane": "crossplane"}, cfg.Dependencies)
+		assert.Equal(t, SourcePath, cfg.SourceOf(SectionDependencies, "crossplane"))
+		assert.Equal(t, DefaultRenderCmd, cfg.Subcommands.Render)
+	})
+
+	t.Run("no config and no crossplane in PATH", func(t *testing.T) {
+		setEmptyPath(t)
+
+		_, err := Resolve(afero.NewMemMapFs(), ResolveOptions{UserConfigPath: userConfig, StartDir: "/"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing required dependencies from PATH (crossplane)")
+	})
+
+	t.Run("invalid project config", func(t *testing.T) {
+		setEmptyPath(t)
+
+		fs := writeFiles(t, map[string]string{projectConfig: "dependencies: [a\n"})
+
+		_, err := Resolve(fs, ResolveOptions{StartDir: "/repo/tests"})
+		require.Error(t, err)
+	})
+}
+
+func TestFindProjectConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/outer/repo/.git", 0o755))
+	require.NoError(t, fs.MkdirAll("/outer/repo/tests/aws", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/outer/.xprin.yaml", []byte(""), 0o644))
+
+	t.Run("stops at git root", func(t *testing.T) {
+		path, err := FindProjectConfig(fs, "/outer/repo/tests/aws")
+		require.NoError(t, err)
+		assert.Empty(t, path)
+	})
+
+	t.Run("finds config in a parent directory", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "/outer/repo/.xprin.yaml", []byte(""), 0o644))
+
+		path, err := FindProjectConfig(fs, "/outer/repo/tests/aws")
+		require.NoError(t, err)
+		assert.Equal(t, "/outer/repo/.xprin.yaml", path)
+	})
+
+	t.Run("walks up to the filesystem root outside a git repository", func(t *testing.T) {
+		require.NoError(t, fs.MkdirAll("/outer/other/dir", 0o755))
+
+		path, err := FindProjectConfig(fs, "/outer/other/dir")
+		require.NoError(t, err)
+		assert.Equal(t, "/outer/.xprin.yaml", path)
+	})
+}
+
+func TestSearchDirForTarget(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/repo/tests", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/repo/tests/aws_xprin.yaml", []byte(""), 0o644))
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "/repo/tests", want: "/repo/tests"},
+		{target: "/repo/tests/", want: "/repo/tests"},
+		{target: "/repo/tests/...", want: "/repo/tests"},
+		{target: "/repo/tests/aws_xprin.yaml", want: "/repo/tests"},
+		{target: "...", want: "."},
+		{target: "/...", want: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.want, SearchDirForTarget(fs, tt.target))
+		})
+	}
+}
+
+func TestDescribeSource(t *testing.T) {
+	cfg := &Config{Sources: map[string]string{"vars.branch": "/repo/.xprin.yaml"}}
+
+	assert.Equal(t, " (from /repo/.xprin.yaml)", cfg.DescribeSource(SectionVars, "branch"))
+	assert.Empty(t, cfg.DescribeSource(SectionVars, "other"))
+	assert.Empty(t, (&Config{}).DescribeSource(SectionVars, "branch"))
+}
@@ -0,0 +1,487 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package coverage aggregates which parts of Compositions and XRDs the test cases exercise: the pipeline steps
+// and composed resources of each Composition, and the spec fields of each XRD set in test inputs.
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/spf13/afero"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// compositionResourceNameAnnotation names the composed resource a rendered resource was produced for.
+	compositionResourceNameAnnotation = "crossplane.io/composition-resource-name"
+
+	// patchAndTransformGroup is the API group of the input of function-patch-and-transform, which lists the
+	// composed resources of its step.
+	patchAndTransformGroup = "pt.fn.crossplane.io"
+)
+
+// Report aggregates the coverage of the test cases of a run. It is safe for concurrent use.
+type Report struct {
+	mu           sync.Mutex
+	compositions map[string]*composition
+	xrds         map[string]*xrd
+}
+
+// composition is the coverage of a Composition.
+type composition struct {
+	name      string
+	renders   int
+	steps     *items
+	resources *items
+}
+
+// xrd is the coverage of an XRD.
+type xrd struct {
+	inputs int
+	fields *items
+}
+
+// items tracks which of a set of names were covered, keeping the order they were added in.
+type items struct {
+	names   []string
+	covered map[string]bool
+}
+
+// NewReport returns an empty coverage report.
+func NewReport() *Report {
+	return &Report{
+		compositions: make(map[string]*composition),
+		xrds:         make(map[string]*xrd),
+	}
+}
+
+func newItems() *items {
+	return &items{covered: make(map[string]bool)}
+}
+
+// add adds a name that is not covered yet.
+func (i *items) add(name string) {
+	if !slices.Contains(i.names, name) {
+		i.names = append(i.names, name)
+	}
+}
+
+// cover adds a name and marks it as covered.
+func (i *items) cover(name string) {
+	i.add(name)
+	i.covered[name] = true
+}
+
+// AddRender records a render of the Composition in file, identified by path (the path of the Composition in the
+// testsuite, which is the same for every test case). When the render succeeded, every pipeline step ran (crossplane
+// render runs the whole pipeline) and the composed resources of the rendered resources were produced. The composed
+// resources listed in function-patch-and-transform inputs are tracked even when they are never produced.
+func (r *Report) AddRender(fs afero.Fs, path, file string, rendered []*unstructured.Unstructured, succeeded bool) error {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return fmt.Errorf("failed to read composition %s: %w", file, err)
+	}
+
+	comp := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &comp.Object); err != nil {
+		return fmt.Errorf("failed to parse composition %s: %w", file, err)
+	}
+
+	pipeline, _, err := unstructured.NestedSlice(comp.Object, "spec", "pipeline")
+	if err != nil {
+		return fmt.Errorf("failed to read the pipeline of composition %s: %w", file, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.compositions[path]
+	if !ok {
+		c = &composition{steps: newItems(), resources: newItems()}
+		r.compositions[path] = c
+	}
+
+	c.name = comp.GetName()
+
+	for _, s := range pipeline {
+		step, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		name, _, _ := unstructured.NestedString(step, "step")
+		if name == "" {
+			continue
+		}
+
+		if succeeded {
+			c.steps.cover(name)
+		} else {
+			c.steps.add(name)
+		}
+
+		for _, resource := range patchAndTransformResources(step) {
+			c.resources.add(resource)
+		}
+	}
+
+	if !succeeded {
+		return nil
+	}
+
+	c.renders++
+
+	// The first rendered resource is the XR
+	for i, resource := range rendered {
+		if i == 0 {
+			continue
+		}
+
+		if name := resource.GetAnnotations()[compositionResourceNameAnnotation]; name != "" {
+			c.resources.cover(name)
+		}
+	}
+
+	return nil
+}
+
+// patchAndTransformResources returns the names of the composed resources of a function-patch-and-transform step.
+func patchAndTransformResources(step map[string]any) []string {
+	apiVersion, _, _ := unstructured.NestedString(step, "input", "apiVersion")
+	if !strings.HasPrefix(apiVersion, patchAndTransformGroup+"/") {
+		return nil
+	}
+
+	resources, _, _ := unstructured.NestedSlice(step, "input", "resources")
+
+	names := make([]string, 0, len(resources))
+
+	for _, r := range resources {
+		resource, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if name, _, _ := unstructured.NestedString(resource, "name"); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// AddInput records the spec fields an input XR or Claim sets, for the XRDs that define it. XRDs that define
+// no input are still reported, with none of their spec fields covered.
+func (r *Report) AddInput(xrds []*apiextensionsv1.CompositeResourceDefinition, inputs []*unstructured.Unstructured) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, definition := range xrds {
+		x, ok := r.xrds[definition.GetName()]
+		if !ok {
+			x = &xrd{fields: newItems()}
+			r.xrds[definition.GetName()] = x
+		}
+
+		for _, version := range definition.Spec.Versions {
+			spec, err := specSchema(version)
+			if err != nil {
+				return fmt.Errorf("failed to read the schema of XRD %s version %s: %w", definition.GetName(), version.Name, err)
+			}
+
+			for _, field := range schemaFields(spec, "spec") {
+				x.fields.add(field)
+			}
+		}
+
+		for _, input := range inputs {
+			if !defines(definition, input) {
+				continue
+			}
+
+			version := slices.IndexFunc(definition.Spec.Versions, func(v apiextensionsv1.CompositeResourceDefinitionVersion) bool {
+				return v.Name == input.GroupVersionKind().Version
+			})
+			if version < 0 {
+				continue
+			}
+
+			spec, err := specSchema(definition.Spec.Versions[version])
+			if err != nil {
+				return fmt.Errorf("failed to read the schema of XRD %s: %w", definition.GetName(), err)
+			}
+
+			x.inputs++
+
+			for _, field := range setFields(spec, input.Object["spec"], "spec") {
+				x.fields.cover(field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// defines reports whether an XRD defines the kind of a resource, as its composite resource or its claim.
+func defines(definition *apiextensionsv1.CompositeResourceDefinition, resource *unstructured.Unstructured) bool {
+	gvk := resource.GroupVersionKind()
+	if gvk.Group != definition.Spec.Group {
+		return false
+	}
+
+	if gvk.Kind == definition.Spec.Names.Kind {
+		return true
+	}
+
+	return definition.Spec.ClaimNames != nil && gvk.Kind == definition.Spec.ClaimNames.Kind
+}
+
+// specSchema returns the schema of the spec of an XRD version.
+func specSchema(version apiextensionsv1.CompositeResourceDefinitionVersion) (*extv1.JSONSchemaProps, error) {
+	if version.Schema == nil {
+		return &extv1.JSONSchemaProps{}, nil
+	}
+
+	s := &extv1.JSONSchemaProps{}
+	if err := json.Unmarshal(version.Schema.OpenAPIV3Schema.Raw, s); err != nil {
+		return nil, err
+	}
+
+	spec := s.Properties["spec"]
+
+	return &spec, nil
+}
+
+// schemaFields returns the paths of the leaf fields of a schema: fields without properties, with the items of
+// arrays as [*].
+func schemaFields(s *extv1.JSONSchemaProps, path string) []string {
+	if len(s.Properties) > 0 {
+		var fields []string
+
+		for _, name := range sortedKeys(s.Properties) {
+			prop := s.Properties[name]
+			fields = append(fields, schemaFields(&prop, path+"."+name)...)
+		}
+
+		return fields
+	}
+
+	if s.Items != nil && s.Items.Schema != nil && len(s.Items.Schema.Properties) > 0 {
+		return schemaFields(s.Items.Schema, path+"[*]")
+	}
+
+	if path == "spec" {
+		return nil
+	}
+
+	return []string{path}
+}
+
+// setFields returns the paths of the leaf fields of a schema that a value sets.
+func setFields(s *extv1.JSONSchemaProps, value any, path string) []string {
+	if value == nil {
+		return nil
+	}
+
+	if len(s.Properties) > 0 {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		var fields []string
+
+		for _, name := range sortedKeys(obj) {
+			prop, ok := s.Properties[name]
+			if !ok {
+				continue
+			}
+
+			fields = append(fields, setFields(&prop, obj[name], path+"."+name)...)
+		}
+
+		return fields
+	}
+
+	if s.Items != nil && s.Items.Schema != nil && len(s.Items.Schema.Properties) > 0 {
+		list, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+
+		var fields []string
+		for _, item := range list {
+			fields = append(fields, setFields(s.Items.Schema, item, path+"[*]")...)
+		}
+
+		return fields
+	}
+
+	if path == "spec" {
+		return nil
+	}
+
+	return []string{path}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Summary is the machine-readable coverage of a run.
+type Summary struct {
+	Compositions []CompositionSummary `json:"compositions"`
+	XRDs         []XRDSummary         `json:"xrds"`
+}
+
+// CompositionSummary is the coverage of a Composition.
+type CompositionSummary struct {
+	Path      string `json:"path"`
+	Name      string `json:"name"`
+	Renders   int    `json:"renders"` // Number of successful renders
+	Steps     Items  `json:"steps"`
+	Resources Items  `json:"resources"`
+}
+
+// XRDSummary is the coverage of an XRD.
+type XRDSummary struct {
+	Name       string `json:"name"`
+	Inputs     int    `json:"inputs"` // Number of test inputs the XRD defines
+	SpecFields Items  `json:"specFields"`
+}
+
+// Items is the coverage of a set of names.
+type Items struct {
+	Covered int     `json:"covered"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+	Items   []Item  `json:"items"`
+}
+
+// Item is the coverage of a name.
+type Item struct {
+	Name    string `json:"name"`
+	Covered bool   `json:"covered"`
+}
+
+func (i *items) summary() Items {
+	s := Items{Total: len(i.names), Percent: 100, Items: make([]Item, 0, len(i.names))}
+
+	for _, name := range i.names {
+		if i.covered[name] {
+			s.Covered++
+		}
+
+		s.Items = append(s.Items, Item{Name: name, Covered: i.covered[name]})
+	}
+
+	if s.Total > 0 {
+		s.Percent = float64(s.Covered) * 100 / float64(s.Total)
+	}
+
+	return s
+}
+
+// Summary returns the coverage of the run, sorted by Composition path and XRD name.
+func (r *Report) Summary() Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := Summary{
+		Compositions: make([]CompositionSummary, 0, len(r.compositions)),
+		XRDs:         make([]XRDSummary, 0, len(r.xrds)),
+	}
+
+	for _, path := range sortedKeys(r.compositions) {
+		c := r.compositions[path]
+		s.Compositions = append(s.Compositions, CompositionSummary{
+			Path:      path,
+			Name:      c.name,
+			Renders:   c.renders,
+			Steps:     c.steps.summary(),
+			Resources: c.resources.summary(),
+		})
+	}
+
+	for _, name := range sortedKeys(r.xrds) {
+		x := r.xrds[name]
+		s.XRDs = append(s.XRDs, XRDSummary{Name: name, Inputs: x.inputs, SpecFields: x.fields.summary()})
+	}
+
+	return s
+}
+
+// WriteJSON writes the coverage of the run as indented JSON.
+func (s Summary) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(s)
+}
+
+// WriteText writes a text summary of the coverage of the run. Only the items that were not covered are listed,
+// unless verbose is set.
+func (s Summary) WriteText(w io.Writer, verbose bool) {
+	const spaces = "    "
+
+	if len(s.Compositions) == 0 && len(s.XRDs) == 0 {
+		fmt.Fprintf(w, "Coverage: no compositions rendered\n") //nolint:errcheck // output function, error handling not practical
+		return
+	}
+
+	fmt.Fprintf(w, "Coverage:\n") //nolint:errcheck // output function, error handling not practical
+
+	writeItems := func(label string, i Items) {
+		fmt.Fprintf(w, "%s%s: %d/%d (%.1f%%)\n", spaces+spaces, label, i.Covered, i.Total, i.Percent) //nolint:errcheck // output function, error handling not practical
+
+		for _, item := range i.Items {
+			switch {
+			case !item.Covered:
+				fmt.Fprintf(w, "%s[x] %s\n", spaces+spaces+spaces, item.Name) //nolint:errcheck // output function, error handling not practical
+			case verbose:
+				fmt.Fprintf(w, "%s[✓] %s\n", spaces+spaces+spaces, item.Name) //nolint:errcheck // output function, error handling not practical
+			}
+		}
+	}
+
+	for _, c := range s.Compositions {
+		fmt.Fprintf(w, "%sComposition %s (%s), %d renders:\n", spaces, c.Name, c.Path, c.Renders) //nolint:errcheck // output function, error handling not practical
+		writeItems("Pipeline steps", c.Steps)
+		writeItems("Composed resources", c.Resources)
+	}
+
+	for _, x := range s.XRDs {
+		fmt.Fprintf(w, "%sXRD %s, %d inputs:\n", spaces, x.Name, x.Inputs) //nolint:errcheck // output function, error handling not practical
+		writeItems("Spec fields", x.SpecFields)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const testComposition = `apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: xbuckets
+spec:
+  compositeTypeRef:
+    apiVersion: example.org/v1
+    kind: XBucket
+  mode: Pipeline
+  pipeline:
+    - step: patch-and-transform
+      functionRef:
+        name: function-patch-and-transform
+      input:
+        apiVersion: pt.fn.crossplane.io/v1beta1
+        kind: Resources
+        resources:
+          - name: bucket
+          - name: policy
+    - step: auto-ready
+      functionRef:
+        name: function-auto-ready
+`
+
+const testXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.org
+spec:
+  group: example.org
+  names:
+    kind: XBucket
+    plural: xbuckets
+  claimNames:
+    kind: Bucket
+    plural: buckets
+  versions:
+    - name: v1
+      served: true
+      referenceable: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                region:
+                  type: string
+                tags:
+                  type: object
+                  additionalProperties:
+                    type: string
+                rules:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      days:
+                        type: integer
+                      prefix:
+                        type: string
+                versioning:
+                  type: object
+                  properties:
+                    enabled:
+                      type: boolean
+`
+
+func toUnstructured(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+
+	u := &unstructured.Unstructured{}
+	require.NoError(t, yaml.Unmarshal([]byte(manifest), &u.Object))
+
+	return u
+}
+
+func composed(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("s3.aws.upbound.io/v1beta1")
+	u.SetKind("Bucket")
+	u.SetAnnotations(map[string]string{compositionResourceNameAnnotation: name})
+
+	return u
+}
+
+func testXRDs(t *testing.T) []*apiextensionsv1.CompositeResourceDefinition {
+	t.Helper()
+
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	require.NoError(t, yaml.Unmarshal([]byte(testXRD), xrd))
+
+	return []*apiextensionsv1.CompositeResourceDefinition{xrd}
+}
+
+func TestReport_AddRender(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/inputs/composition.yaml", []byte(testComposition), 0o600))
+
+	xr := toUnstructured(t, "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\n")
+
+	t.Run("steps and produced resources are covered", func(t *testing.T) {
+		r := NewReport()
+		require.NoError(t, r.AddRender(fs, "/suite/composition.yaml", "/inputs/composition.yaml", []*unstructured.Unstructured{xr, composed("bucket")}, true))
+
+		summary := r.Summary()
+		require.Len(t, summary.Compositions, 1)
+
+		c := summary.Compositions[0]
+		assert.Equal(t, "/suite/composition.yaml", c.Path)
+		assert.Equal(t, "xbuckets", c.Name)
+		assert.Equal(t, 1, c.Renders)
+		assert.Equal(t, Items{Covered: 2, Total: 2, Percent: 100, Items: []Item{{Name: "patch-and-transform", Covered: true}, {Name: "auto-ready", Covered: true}}}, c.Steps)
+		assert.Equal(t, Items{Covered: 1, Total: 2, Percent: 50, Items: []Item{{Name: "bucket", Covered: true}, {Name: "policy"}}}, c.Resources)
+	})
+
+	t.Run("coverage aggregates over renders", func(t *testing.T) {
+		r := NewReport()
+		require.NoError(t, r.AddRender(fs, "/suite/composition.yaml", "/inputs/composition.yaml", []*unstructured.Unstructured{xr, composed("bucket")}, true))
+		require.NoError(t, r.AddRender(fs, "/suite/composition.yaml", "/inputs/composition.yaml", []*unstructured.Unstructured{xr, composed("policy"), composed("extra")}, true))
+
+		c := r.Summary().Compositions[0]
+		assert.Equal(t, 2, c.Renders)
+		assert.Equal(t, Items{Covered: 3, Total: 3, Percent: 100, Items: []Item{{Name: "bucket", Covered: true}, {Name: "policy", Covered: true}, {Name: "extra", Covered: true}}}, c.Resources)
+	})
+
+	t.Run("failed renders cover nothing", func(t *testing.T) {
+		r := NewReport()
+		require.NoError(t, r.AddRender(fs, "/suite/composition.yaml", "/inputs/composition.yaml", nil, false))
+
+		c := r.Summary().Compositions[0]
+		assert.Equal(t, 0, c.Renders)
+		assert.Equal(t, 0, c.Steps.Covered)
+		assert.Equal(t, 2, c.Steps.Total)
+		assert.Equal(t, 0, c.Resources.Covered)
+		assert.Equal(t, 2, c.Resources.Total)
+	})
+
+	t.Run("missing composition", func(t *testing.T) {
+		require.ErrorContains(t, NewReport().AddRender(fs, "/missing.yaml", "/missing.yaml", nil, true), "failed to read composition /missing.yaml")
+	})
+}
+
+func TestReport_AddInput(t *testing.T) {
+	t.Run("set spec fields are covered", func(t *testing.T) {
+		r := NewReport()
+		xr := toUnstructured(t, `apiVersion: example.org/v1
+kind: XBucket
+metadata:
+  name: bucket
+spec:
+  region: eu-west-1
+  tags:
+    team: storage
+  rules:
+    - days: 30
+  unknown: true
+`)
+
+		require.NoError(t, r.AddInput(testXRDs(t), []*unstructured.Unstructured{xr}))
+
+		summary := r.Summary()
+		require.Len(t, summary.XRDs, 1)
+
+		x := summary.XRDs[0]
+		assert.Equal(t, "xbuckets.example.org", x.Name)
+		assert.Equal(t, 1, x.Inputs)
+		assert.Equal(t, Items{Covered: 3, Total: 5, Percent: 60, Items: []Item{
+			{Name: "spec.region", Covered: true},
+			{Name: "spec.rules[*].days", Covered: true},
+			{Name: "spec.rules[*].prefix"},
+			{Name: "spec.tags", Covered: true},
+			{Name: "spec.versioning.enabled"},
+		}}, x.SpecFields)
+	})
+
+	t.Run("claims are matched by their claim kind", func(t *testing.T) {
+		r := NewReport()
+		claim := toUnstructured(t, "apiVersion: example.org/v1\nkind: Bucket\nmetadata:\n  name: bucket\nspec:\n  versioning:\n    enabled: true\n")
+
+		require.NoError(t, r.AddInput(testXRDs(t), []*unstructured.Unstructured{claim}))
+
+		x := r.Summary().XRDs[0]
+		assert.Equal(t, 1, x.Inputs)
+		assert.Equal(t, 1, x.SpecFields.Covered)
+	})
+
+	t.Run("other kinds cover nothing", func(t *testing.T) {
+		r := NewReport()
+		other := toUnstructured(t, "apiVersion: other.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\nspec:\n  region: eu-west-1\n")
+
+		require.NoError(t, r.AddInput(testXRDs(t), []*unstructured.Unstructured{other}))
+
+		x := r.Summary().XRDs[0]
+		assert.Equal(t, 0, x.Inputs)
+		assert.Equal(t, 0, x.SpecFields.Covered)
+		assert.Equal(t, 5, x.SpecFields.Total)
+	})
+}
+
+func TestSummary_Write(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/composition.yaml", []byte(testComposition), 0o600))
+
+	r := NewReport()
+	xr := toUnstructured(t, "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\nspec:\n  region: eu-west-1\n")
+	require.NoError(t, r.AddRender(fs, "/composition.yaml", "/composition.yaml", []*unstructured.Unstructured{xr, composed("bucket")}, true))
+	require.NoError(t, r.AddInput(testXRDs(t), []*unstructured.Unstructured{xr}))
+
+	t.Run("text lists the uncovered items", func(t *testing.T) {
+		var buf bytes.Buffer
+		r.Summary().WriteText(&buf, false)
+
+		assert.Equal(t, `Coverage:
+    Composition xbuckets (/composition.yaml), 1 renders:
+        Pipeline steps: 2/2 (100.0%)
+        Composed resources: 1/2 (50.0%)
+            [x] policy
+    XRD xbuckets.example.org, 1 inputs:
+        Spec fields: 1/5 (20.0%)
+            [x] spec.rules[*].days
+            [x] spec.rules[*].prefix
+            [x] spec.tags
+            [x] spec.versioning.enabled
+`, buf.String())
+	})
+
+	t.Run("verbose text lists the covered items too", func(t *testing.T) {
+		var buf bytes.Buffer
+		r.Summary().WriteText(&buf, true)
+
+		assert.Contains(t, buf.String(), "            [✓] patch-and-transform\n")
+		assert.Contains(t, buf.String(), "            [✓] spec.region\n")
+	})
+
+	t.Run("empty report", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewReport().Summary().WriteText(&buf, false)
+
+		assert.Equal(t, "Coverage: no compositions rendered\n", buf.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, r.Summary().WriteJSON(&buf))
+
+		var got Summary
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, r.Summary(), got)
+		assert.Contains(t, buf.String(), `"specFields": {`)
+	})
+}
@@ -49,39 +49,63 @@ func indentMultilineBody(indent, body string) string {
 
 // TestCaseResult represents the result of a single test case.
 type TestCaseResult struct {
-	Name      string
-	ID        string // Test case ID for cross-test references
-	Duration  time.Duration
-	Error     error
-	Status    Status
-	StartTime time.Time
+	Name       string
+	ID         string // Test case ID for cross-test references
+	Label      string // Optional run label (e.g. the crossplane dependency of a matrix run), shown next to the name
+	SkipReason string // Why the test case was skipped (only set when Status is SKIP)
+	DryRun     bool   // Whether the test case was only prepared, without running its hooks, render and validate
+	DryRunNote string // What the dry run of the test case could not check (only set when DryRun is true)
+	Duration   time.Duration
+	Error      error
+	Status     Status
+	StartTime  time.Time
 
 	// Raw outputs (stored by runner)
-	RawRenderOutput     []byte
-	RawValidateOutput   []byte
-	RawAssertionsOutput string // Full assertion text, no indentation (from AssertionsResults)
+	RawInputValidateOutput []byte
+	RawRenderOutput        []byte
+	RawValidateOutput      []byte
+	RawAssertionsOutput    string // Full assertion text, no indentation (from AssertionsResults)
+	RawCompareOutput       string // Full comparison text, no indentation (from CompareResult)
 
 	// Parsed render resources (parsed once, used many times)
 	RenderedResources []*unstructured.Unstructured
 
 	// Formatted outputs (formatted once, displayed many times)
+	FormattedInputValidateOutput string
 	FormattedRenderOutput        string
 	FormattedValidateOutput      string
 	FormattedPreTestHooksOutput  string
 	FormattedPostTestHooksOutput string
 	FormattedAssertionsOutput    string
+	FormattedCompareOutput       string
+	FormattedFuzzOutput          string
 
 	PreTestHooksResults  []HookResult
 	PostTestHooksResults []HookResult
 
 	AssertionsResults []AssertionResult
 
+	// Per-resource results of validating the input XR/Claim against its XRD before render (nil when no XRD was found)
+	InputValidateResults []ValidateResult
+
+	// Per-resource results of the builtin validator (nil when crossplane validated the render)
+	ValidateResults []ValidateResult
+
+	// Result of comparing the render with the --compare-with baseline (nil when no comparison ran)
+	CompareResult *AssertionResult
+
+	// Result of the runs of a fuzz test case, the test case result being that of its last run or of its shrunk
+	// counterexample (nil for other test cases)
+	FuzzResult *AssertionResult
+
 	// Outputs for template variables in hooks
 	Outputs Outputs
 
+	HasFailedInputValidate bool
 	HasFailedRender        bool
 	HasFailedValidate      bool
 	HasFailedAssertions    bool
+	HasFailedCompare       bool
 	HasFailedPreTestHooks  bool
 	HasFailedPostTestHooks bool
 
@@ -117,6 +141,7 @@ type Outputs struct {
 	XR          string            // Path to xr.yaml
 	Validate    *string           // Path to validate.txt (nil if no CRDs)
 	Assertions  *string           // Path to assertions.txt (nil if no assertions)
+	Compare     *string           // Path to compare.txt (nil if no comparison)
 	RenderCount int               // Number of resources in render output
 	Rendered    map[string]string // Kind/Name -> file path for individual rendered resources
 }
@@ -129,9 +154,21 @@ func (tcr *TestCaseResult) Fail(err error) *TestCaseResult {
 	return tcr.Complete()
 }
 
-// Skip marks a test case as skipped.
-func (tcr *TestCaseResult) Skip() {
+// Skip marks a test case as skipped with the given reason and completes it, returning the result for chaining.
+func (tcr *TestCaseResult) Skip(reason string) *TestCaseResult {
 	tcr.Status = StatusSkip()
+	tcr.SkipReason = reason
+
+	return tcr.Complete()
+}
+
+// WouldRun marks a test case as prepared by a dry run, with a note on what could not be checked, and completes it,
+// returning the result for chaining.
+func (tcr *TestCaseResult) WouldRun(note string) *TestCaseResult {
+	tcr.DryRun = true
+	tcr.DryRunNote = note
+
+	return tcr.Complete()
 }
 
 // Complete finalizes a test case result with duration and returns the result for chaining.
@@ -149,10 +186,10 @@ func (tcr *TestCaseResult) FailRender() *TestCaseResult {
 	return tcr.Fail(nil)
 }
 
-// HasPipelineFailure returns true if validate, assertions, or post-test hooks failed.
+// HasPipelineFailure returns true if validate, compare, assertions, or post-test hooks failed.
 // Used by the runner to call Fail(nil) when no infrastructure error was collected.
 func (tcr *TestCaseResult) HasPipelineFailure() bool {
-	return tcr.HasFailedValidate || tcr.HasFailedAssertions || tcr.HasFailedPostTestHooks
+	return tcr.HasFailedValidate || tcr.HasFailedCompare || tcr.HasFailedAssertions || tcr.HasFailedPostTestHooks
 }
 
 // MarkValidateFailed marks the test as having failed validation and returns the formatted error.
@@ -174,22 +211,45 @@ func (tcr *TestCaseResult) MarkAssertionsFailed() error {
 
 // Print prints the test case result to the given writer.
 func (tcr *TestCaseResult) Print(w io.Writer) {
-	// In non-verbose mode, only print failures
+	name := tcr.Name
+	if tcr.Label != "" {
+		name = fmt.Sprintf("%s [%s]", tcr.Name, tcr.Label)
+	}
+
+	// A dry run reports every test case it prepared
+	if tcr.DryRun && tcr.Status == StatusPass() {
+		fmt.Fprintf(w, "--- WOULD RUN: %s\n", name) //nolint:errcheck // output function, error handling not practical
+
+		if tcr.DryRunNote != "" {
+			fmt.Fprintf(w, "%s%s\n", spaces, tcr.DryRunNote) //nolint:errcheck // output function, error handling not practical
+		}
+
+		return
+	}
+
+	// In non-verbose mode, only print failures and skipped test cases
 	if tcr.Status == StatusPass() && !tcr.Verbose {
 		return
 	}
 
 	// Print RUN message for this test (like go test)
 	if tcr.Verbose {
-		fmt.Fprintf(w, "=== RUN   %s\n", tcr.Name) //nolint:errcheck // output function, error handling not practical
+		fmt.Fprintf(w, "=== RUN   %s\n", name) //nolint:errcheck // output function, error handling not practical
 	}
 
 	// Print status line
-	fmt.Fprintf(w, "--- %s: %s (%.2fs)\n", tcr.Status, tcr.Name, tcr.Duration.Seconds()) //nolint:errcheck // output function, error handling not practical
+	fmt.Fprintf(w, "--- %s: %s (%.2fs)\n", tcr.Status, name, tcr.Duration.Seconds()) //nolint:errcheck // output function, error handling not practical
+
+	if tcr.Status == StatusSkip() && tcr.SkipReason != "" {
+		fmt.Fprintf(w, "%s%s\n", spaces, tcr.SkipReason) //nolint:errcheck // output function, error handling not practical
+	}
 
+	fmt.Fprint(w, tcr.FormattedFuzzOutput)          //nolint:errcheck // output function, error handling not practical
 	fmt.Fprint(w, tcr.FormattedPreTestHooksOutput)  //nolint:errcheck // output function, error handling not practical
+	fmt.Fprint(w, tcr.FormattedInputValidateOutput) //nolint:errcheck // output function, error handling not practical
 	fmt.Fprint(w, tcr.FormattedRenderOutput)        //nolint:errcheck // output function, error handling not practical
 	fmt.Fprint(w, tcr.FormattedValidateOutput)      //nolint:errcheck // output function, error handling not practical
+	fmt.Fprint(w, tcr.FormattedCompareOutput)       //nolint:errcheck // output function, error handling not practical
 	fmt.Fprint(w, tcr.FormattedAssertionsOutput)    //nolint:errcheck // output function, error handling not practical
 	fmt.Fprint(w, tcr.FormattedPostTestHooksOutput) //nolint:errcheck // output function, error handling not practical
 
@@ -289,8 +349,38 @@ func (tcr *TestCaseResult) formatValidateOutput() string {
 		return ""
 	}
 
-	outputStr := strings.TrimSpace(string(tcr.RawValidateOutput))
-	if tcr.HasFailedValidate && !tcr.ShowValidate {
+	return tcr.formatValidateSection(header, tcr.RawValidateOutput, tcr.HasFailedValidate)
+}
+
+// formatInputValidateOutput formats the input validation raw output for display, like formatValidateOutput.
+// Returns "" when the input validation passed and the section would not be shown: it is shown in verbose mode
+// when there are pruning warnings or with ShowValidate.
+func (tcr *TestCaseResult) formatInputValidateOutput() string {
+	const header = "Input Validation:"
+
+	if !tcr.HasFailedInputValidate && (!tcr.Verbose || (!tcr.ShowValidate && !tcr.hasInputValidateWarnings())) {
+		return ""
+	}
+
+	return tcr.formatValidateSection(header, tcr.RawInputValidateOutput, tcr.HasFailedInputValidate)
+}
+
+// hasInputValidateWarnings reports whether the input validation found pruning warnings.
+func (tcr *TestCaseResult) hasInputValidateWarnings() bool {
+	for _, result := range tcr.InputValidateResults {
+		if len(result.Warnings) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatValidateSection returns the header plus the indented raw validation output.
+// When failed and !ShowValidate, filters out "[✓] ... validated successfully" lines.
+func (tcr *TestCaseResult) formatValidateSection(header string, raw []byte, failed bool) string {
+	outputStr := strings.TrimSpace(string(raw))
+	if failed && !tcr.ShowValidate {
 		lines := strings.Split(outputStr, "\n")
 
 		filtered := lines[:0]
@@ -483,6 +573,57 @@ func (tcr *TestCaseResult) formatAssertionsOutput() (raw, formatted string) {
 	return raw, formatted
 }
 
+// formatCompareOutput builds both raw and formatted comparison output from CompareResult.
+// raw: no leading spaces (for compare.txt). formatted: "    Compare:" and the indented result; "" when the
+// comparison passed and the section would not be shown (!Verbose).
+func (tcr *TestCaseResult) formatCompareOutput() (raw, formatted string) {
+	if tcr.CompareResult == nil {
+		return "", ""
+	}
+
+	return formatResultSection("Compare:", tcr.CompareResult, tcr.HasFailedCompare || tcr.Verbose)
+}
+
+// formatFuzzOutput builds the formatted fuzz output from FuzzResult: "    Fuzz:" and the indented result; "" when
+// the fuzz runs passed and the section would not be shown (!Verbose).
+func (tcr *TestCaseResult) formatFuzzOutput() string {
+	if tcr.FuzzResult == nil {
+		return ""
+	}
+
+	_, formatted := formatResultSection("Fuzz:", tcr.FuzzResult, tcr.FuzzResult.Status != StatusPass() || tcr.Verbose)
+
+	return formatted
+}
+
+// formatResultSection builds both raw and formatted output of a section with a single result, with multiline
+// messages indented below the result. formatted is "" when show is false.
+func formatResultSection(header string, r *AssertionResult, show bool) (raw, formatted string) {
+	var line, body string
+	if strings.Contains(r.Message, "\n") {
+		line = fmt.Sprintf("%s %s", r.Status.Symbol, r.Name)
+		body = indentMultilineBody(multilineBodyIndent, r.Message)
+	} else {
+		line = fmt.Sprintf("%s %s - %s", r.Status.Symbol, r.Name, r.Message)
+	}
+
+	rawLines := []string{header, line}
+	formattedLines := []string{spaces + header, spaces + spaces + line}
+
+	if body != "" {
+		rawLines = append(rawLines, body)
+		formattedLines = append(formattedLines, body)
+	}
+
+	raw = strings.Join(rawLines, "\n") + "\n"
+
+	if show {
+		formatted = strings.Join(formattedLines, "\n") + "\n"
+	}
+
+	return raw, formatted
+}
+
 // parseRenderOutput parses the raw render output and returns the resources.
 func (tcr *TestCaseResult) parseRenderOutput(output []byte) ([]*unstructured.Unstructured, error) {
 	decoder := k8syaml.NewYAMLToJSONDecoder(bytes.NewReader(output))
@@ -528,6 +669,39 @@ func (tcr *TestCaseResult) ProcessValidateOutput() {
 	tcr.FormattedValidateOutput = tcr.formatValidateOutput()
 }
 
+// ProcessValidateResults stores the results of the builtin validator, sets RawValidateOutput from them and formats it.
+// Validation fails when a resource is invalid or, with errorOnMissingSchemas, when a resource has no schema.
+func (tcr *TestCaseResult) ProcessValidateResults(results []ValidateResult, errorOnMissingSchemas bool) {
+	tcr.ValidateResults = results
+	tcr.RawValidateOutput = formatValidateResults(results)
+
+	for _, result := range results {
+		if len(result.Errors) > 0 || (errorOnMissingSchemas && result.MissingSchema) {
+			tcr.HasFailedValidate = true
+			break
+		}
+	}
+
+	tcr.ProcessValidateOutput()
+}
+
+// ProcessInputValidateResults stores the results of validating the input XR/Claim, sets RawInputValidateOutput from
+// them and formats it. The input validation fails when a resource is invalid or, with warningsAsErrors, when a field
+// would be pruned.
+func (tcr *TestCaseResult) ProcessInputValidateResults(results []ValidateResult, warningsAsErrors bool) {
+	tcr.InputValidateResults = results
+	tcr.RawInputValidateOutput = formatInputValidateResults(results)
+
+	for _, result := range results {
+		if len(result.Errors) > 0 || (warningsAsErrors && len(result.Warnings) > 0) {
+			tcr.HasFailedInputValidate = true
+			break
+		}
+	}
+
+	tcr.FormattedInputValidateOutput = tcr.formatInputValidateOutput()
+}
+
 // ProcessPreTestHooksOutput formats the pre-test hooks results and sets hasFailedPreTestHooks.
 // It sets FormattedPreTestHooksOutput to the single string that will be printed (or "" when section not shown).
 func (tcr *TestCaseResult) ProcessPreTestHooksOutput() {
@@ -578,3 +752,20 @@ func (tcr *TestCaseResult) ProcessAssertionsOutput() {
 
 	tcr.RawAssertionsOutput, tcr.FormattedAssertionsOutput = tcr.formatAssertionsOutput()
 }
+
+// ProcessCompareOutput sets HasFailedCompare and, from CompareResult, both RawCompareOutput and FormattedCompareOutput.
+func (tcr *TestCaseResult) ProcessCompareOutput() {
+	if tcr.CompareResult == nil {
+		return
+	}
+
+	s := tcr.CompareResult.Status
+	tcr.HasFailedCompare = s == StatusFail() || s == StatusError()
+
+	tcr.RawCompareOutput, tcr.FormattedCompareOutput = tcr.formatCompareOutput()
+}
+
+// ProcessFuzzOutput sets FormattedFuzzOutput from FuzzResult.
+func (tcr *TestCaseResult) ProcessFuzzOutput() {
+	tcr.FormattedFuzzOutput = tcr.formatFuzzOutput()
+}
@@ -69,9 +69,30 @@ func TestTestCaseResult_Skip(t *testing.T) {
 	t.Run("sets status to SKIP", func(t *testing.T) {
 		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
 
-		result.Skip()
+		returned := result.Skip("not run with crossplane-1.20")
 
+		assert.Equal(t, result, returned) // Should return self for chaining
 		assert.Equal(t, StatusSkip(), result.Status)
+		assert.Equal(t, "not run with crossplane-1.20", result.SkipReason)
+		assert.Positive(t, result.Duration) // Should be completed
+	})
+}
+
+func TestTestCaseResult_WouldRun(t *testing.T) {
+	t.Run("marks a dry run and prints it with its note", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+
+		returned := result.WouldRun("not checked: templates")
+
+		assert.Equal(t, result, returned) // Should return self for chaining
+		assert.Equal(t, StatusPass(), result.Status)
+		assert.True(t, result.DryRun)
+		assert.Positive(t, result.Duration) // Should be completed
+
+		var buf bytes.Buffer
+
+		result.Print(&buf)
+		assert.Equal(t, "--- WOULD RUN: test\n    not checked: templates\n", buf.String())
 	})
 }
 
@@ -141,6 +162,39 @@ func TestTestCaseResult_Print(t *testing.T) {
 		assert.Contains(t, buf.String(), "--- PASS: test")
 	})
 
+	t.Run("prints label next to the name", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", true, false, false, false, false)
+		result.Label = "crossplane-2.0"
+		result.Complete()
+
+		var buf bytes.Buffer
+		result.Print(&buf)
+
+		assert.Contains(t, buf.String(), "=== RUN   test [crossplane-2.0]")
+		assert.Contains(t, buf.String(), "--- PASS: test [crossplane-2.0]")
+	})
+
+	t.Run("prints skip reason in non-verbose mode", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+		result.Skip("not run with crossplane-1.20")
+
+		var buf bytes.Buffer
+		result.Print(&buf)
+
+		assert.Equal(t, "--- SKIP: test (0.00s)\n    not run with crossplane-1.20\n", buf.String())
+	})
+
+	t.Run("prints skip reason in verbose mode", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", true, false, false, false, false)
+		result.Skip("not run with crossplane-1.20")
+
+		var buf bytes.Buffer
+		result.Print(&buf)
+
+		assert.Contains(t, buf.String(), "--- SKIP: test")
+		assert.Contains(t, buf.String(), "    not run with crossplane-1.20\n")
+	})
+
 	t.Run("prints error for failed test", func(t *testing.T) {
 		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
 		result.Fail(assert.AnError)
@@ -824,6 +878,200 @@ func TestTestCaseResult_ProcessAssertionsOutput_setsRawAndFormatted(t *testing.T
 	})
 }
 
+func TestTestCaseResult_ProcessCompareOutput(t *testing.T) {
+	t.Run("matching renders are shown only in verbose mode", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+		compareResult := NewAssertionResult("crossplane-2.0", StatusPass(), "renders match")
+		result.CompareResult = &compareResult
+
+		result.ProcessCompareOutput()
+
+		assert.False(t, result.HasFailedCompare)
+		assert.False(t, result.HasPipelineFailure())
+		assert.Equal(t, "Compare:\n[✓] crossplane-2.0 - renders match\n", result.RawCompareOutput)
+		assert.Empty(t, result.FormattedCompareOutput)
+
+		result.Verbose = true
+		result.ProcessCompareOutput()
+
+		assert.Equal(t, "    Compare:\n        [✓] crossplane-2.0 - renders match\n", result.FormattedCompareOutput)
+	})
+
+	t.Run("differing renders fail and show the report", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+		compareResult := NewAssertionResult("git:compositions@v1.0.0", StatusFail(), "spec.replicas\n  ± value change\n")
+		result.CompareResult = &compareResult
+
+		result.ProcessCompareOutput()
+
+		assert.True(t, result.HasFailedCompare)
+		assert.True(t, result.HasPipelineFailure())
+		assert.Equal(t, "Compare:\n[x] git:compositions@v1.0.0\n            spec.replicas\n              ± value change\n", result.RawCompareOutput)
+		assert.Equal(t, "    Compare:\n        [x] git:compositions@v1.0.0\n            spec.replicas\n              ± value change\n", result.FormattedCompareOutput)
+	})
+
+	t.Run("baseline render errors fail", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+		compareResult := NewAssertionResult("functions:next.yaml", StatusError(), "baseline render failed: exit status 1")
+		result.CompareResult = &compareResult
+
+		result.ProcessCompareOutput()
+
+		assert.True(t, result.HasFailedCompare)
+		assert.Equal(t, "    Compare:\n        [!] functions:next.yaml - baseline render failed: exit status 1\n", result.FormattedCompareOutput)
+	})
+
+	t.Run("no comparison", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", true, false, false, false, false)
+
+		result.ProcessCompareOutput()
+
+		assert.False(t, result.HasFailedCompare)
+		assert.Empty(t, result.RawCompareOutput)
+		assert.Empty(t, result.FormattedCompareOutput)
+	})
+}
+
+func TestTestCaseResult_ProcessFuzzOutput(t *testing.T) {
+	t.Run("passing runs are shown only in verbose mode", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+		fuzzResult := NewAssertionResult("seed 1", StatusPass(), "10 runs passed")
+		result.FuzzResult = &fuzzResult
+
+		result.ProcessFuzzOutput()
+
+		assert.Empty(t, result.FormattedFuzzOutput)
+
+		result.Verbose = true
+		result.ProcessFuzzOutput()
+
+		assert.Equal(t, "    Fuzz:\n        [✓] seed 1 - 10 runs passed\n", result.FormattedFuzzOutput)
+	})
+
+	t.Run("failing run is shown before the other sections", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+		fuzzResult := NewAssertionResult("seed 1", StatusFail(), "run 3 of 10 failed, shrunk in 2 steps, counterexample written to fuzz.yaml")
+		result.FuzzResult = &fuzzResult
+		result.FormattedAssertionsOutput = "    Assertions:\n"
+
+		result.ProcessFuzzOutput()
+		result.Fail(nil)
+
+		var buf bytes.Buffer
+		result.Print(&buf)
+
+		assert.Equal(t, "--- FAIL: test (0.00s)\n    Fuzz:\n        [x] seed 1 - run 3 of 10 failed, shrunk in 2 steps, counterexample written to fuzz.yaml\n    Assertions:\n", buf.String())
+	})
+}
+
+func TestTestCaseResult_ProcessValidateResults(t *testing.T) {
+	results := []ValidateResult{
+		{GroupVersionKind: "example.org/v1, Kind=XBucket", Name: "xbucket"},
+		{GroupVersionKind: "/v1, Kind=ConfigMap", Name: "config", MissingSchema: true},
+	}
+
+	t.Run("valid resources", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", true, false, true, false, false)
+
+		result.ProcessValidateResults(results, false)
+
+		assert.False(t, result.HasFailedValidate)
+		assert.Equal(t, results, result.ValidateResults)
+		assert.Equal(t, "[✓] example.org/v1, Kind=XBucket, xbucket validated successfully\n"+
+			"[!] could not find CRD/XRD for: /v1, Kind=ConfigMap\n"+
+			"Total 2 resources: 1 missing schemas, 1 success cases, 0 failure cases\n", string(result.RawValidateOutput))
+		assert.Contains(t, result.FormattedValidateOutput, "Validate:")
+	})
+
+	t.Run("missing schemas fail with errorOnMissingSchemas", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+
+		result.ProcessValidateResults(results, true)
+
+		assert.True(t, result.HasFailedValidate)
+	})
+
+	t.Run("invalid resources fail", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+
+		result.ProcessValidateResults([]ValidateResult{
+			results[0],
+			{GroupVersionKind: "example.org/v1, Kind=Bucket", Name: "bucket", Errors: []ValidateError{
+				{Type: ValidateErrorSchema, Field: "spec.region", Message: "Required value"},
+				{Type: ValidateErrorCEL, Field: "spec", Message: `Invalid value: "object": at most 3 replicas`},
+			}},
+		}, false)
+
+		assert.True(t, result.HasFailedValidate)
+		assert.Equal(t, "[✓] example.org/v1, Kind=XBucket, xbucket validated successfully\n"+
+			"[x] schema validation error example.org/v1, Kind=Bucket, bucket : spec.region: Required value\n"+
+			"[x] CEL validation error example.org/v1, Kind=Bucket, bucket : spec: Invalid value: \"object\": at most 3 replicas\n"+
+			"Total 2 resources: 0 missing schemas, 1 success cases, 1 failure cases\n", string(result.RawValidateOutput))
+		// Without --show-validate, only the failures are shown
+		assert.NotContains(t, result.FormattedValidateOutput, "validated successfully")
+		assert.Contains(t, result.FormattedValidateOutput, "spec.region: Required value")
+	})
+}
+
+func TestTestCaseResult_ProcessInputValidateResults(t *testing.T) {
+	warning := ValidateResult{GroupVersionKind: "example.org/v1, Kind=XBucket", Name: "xbucket", Warnings: []ValidateError{
+		{Type: ValidateWarningPruning, Field: "spec.regoin", Message: `unknown field "regoin" would be pruned`},
+	}}
+	missingSchema := ValidateResult{GroupVersionKind: "example.org/v1, Kind=Bucket", Name: "bucket", MissingSchema: true}
+
+	t.Run("pruning warnings do not fail", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", true, false, false, false, false)
+
+		result.ProcessInputValidateResults([]ValidateResult{warning, missingSchema}, false)
+
+		assert.False(t, result.HasFailedInputValidate)
+		assert.Equal(t, "[!] pruning warning example.org/v1, Kind=XBucket, xbucket : spec.regoin: unknown field \"regoin\" would be pruned\n"+
+			"Total 1 resources: 1 success cases, 0 failure cases, 1 warnings\n", string(result.RawInputValidateOutput))
+		// In verbose mode, warnings are shown without --show-validate
+		assert.Contains(t, result.FormattedInputValidateOutput, "Input Validation:")
+	})
+
+	t.Run("pruning warnings fail with warningsAsErrors", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+
+		result.ProcessInputValidateResults([]ValidateResult{warning}, true)
+
+		assert.True(t, result.HasFailedInputValidate)
+		assert.Contains(t, result.FormattedInputValidateOutput, "spec.regoin: unknown field")
+	})
+
+	t.Run("invalid resources fail", func(t *testing.T) {
+		result := NewTestCaseResult("test", "", false, false, false, false, false)
+
+		result.ProcessInputValidateResults([]ValidateResult{
+			{GroupVersionKind: "example.org/v1, Kind=XBucket", Name: "xbucket", Errors: []ValidateError{
+				{Type: ValidateErrorSchema, Field: "spec.region", Message: "Required value"},
+			}},
+		}, false)
+
+		assert.True(t, result.HasFailedInputValidate)
+		assert.Equal(t, "[x] schema validation error example.org/v1, Kind=XBucket, xbucket : spec.region: Required value\n"+
+			"Total 1 resources: 0 success cases, 1 failure cases, 0 warnings\n", string(result.RawInputValidateOutput))
+
+		var buf bytes.Buffer
+
+		result.Fail(nil).Print(&buf)
+		assert.Contains(t, buf.String(), "    Input Validation:\n        [x] schema validation error")
+	})
+
+	t.Run("valid resources are shown with show-validate only", func(t *testing.T) {
+		valid := []ValidateResult{{GroupVersionKind: "example.org/v1, Kind=XBucket", Name: "xbucket"}}
+
+		result := NewTestCaseResult("test", "", true, false, false, false, false)
+		result.ProcessInputValidateResults(valid, false)
+		assert.Empty(t, result.FormattedInputValidateOutput)
+
+		result = NewTestCaseResult("test", "", true, false, true, false, false)
+		result.ProcessInputValidateResults(valid, false)
+		assert.Contains(t, result.FormattedInputValidateOutput, "[✓] example.org/v1, Kind=XBucket, xbucket validated successfully")
+	})
+}
+
 func TestTestCaseResult_formatHooksOutput(t *testing.T) {
 	t.Run("formats hooks output with label", func(t *testing.T) {
 		result := NewTestCaseResult("test", "test-id", true, false, false, false, false)
@@ -28,6 +28,7 @@ import (
 // TestSuiteResult represents the result of running a test suite file.
 type TestSuiteResult struct {
 	FilePath  string
+	Label     string // Optional run label (e.g. the crossplane dependency of a matrix run), shown next to the path
 	Results   []TestCaseResult
 	Duration  time.Duration
 	Status    Status // StatusPass() or StatusFail() - overall status
@@ -71,6 +72,10 @@ func (tsr *TestSuiteResult) Print(w io.Writer) {
 		}
 	}
 
+	if tsr.Label != "" {
+		displayPath = fmt.Sprintf("%s [%s]", displayPath, tsr.Label)
+	}
+
 	if tsr.Status == StatusFail() {
 		fmt.Fprintf(w, "%s\n%s\t%s\t%.3fs\n", StatusFail().Value, StatusFail().Value, displayPath, tsr.Duration.Seconds()) //nolint:errcheck // output function, error handling not practical
 	} else {
@@ -141,6 +141,17 @@ func TestTestSuiteResult_Print(t *testing.T) {
 		assert.NotContains(t, output, "ok")
 	})
 
+	t.Run("prints label next to the path", func(t *testing.T) {
+		suite := NewTestSuiteResult("test.yaml", false)
+		suite.Label = "crossplane-2.0"
+		suite.Complete()
+
+		var buf bytes.Buffer
+		suite.Print(&buf)
+
+		assert.Contains(t, buf.String(), "ok\ttest.yaml [crossplane-2.0]\t")
+	})
+
 	t.Run("prints relative path when possible", func(t *testing.T) {
 		// This test might be flaky depending on the test environment
 		// but it tests the path conversion logic
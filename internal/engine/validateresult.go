@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Types of validation errors.
+const (
+	ValidateErrorSchema = "schema" // The field does not match the OpenAPI schema (including unknown fields)
+	ValidateErrorCEL    = "CEL"    // The resource breaks an x-kubernetes-validations rule
+
+	ValidateWarningPruning = "pruning" // The field is not in the schema, so the API server would prune it
+)
+
+// ValidateResult represents the result of validating a rendered resource against the schema of its CRD.
+type ValidateResult struct {
+	GroupVersionKind string          // e.g. "example.org/v1, Kind=XBucket"
+	Name             string          // Name of the resource
+	MissingSchema    bool            // No CRD defines the resource, so it was not validated
+	Errors           []ValidateError // Validation errors (empty when the resource is valid)
+	Warnings         []ValidateError // Pruning warnings of the input validation (unknown fields are errors otherwise)
+}
+
+// ValidateError represents a validation error of a field of a resource.
+type ValidateError struct {
+	Type    string // ValidateErrorSchema, ValidateErrorCEL or ValidateWarningPruning
+	Field   string // Path of the field, e.g. spec.parameters.region
+	Message string
+}
+
+// formatValidateResults returns the validation results as crossplane beta validate prints them,
+// so that the builtin validator and crossplane produce the same validate.txt.
+func formatValidateResults(results []ValidateResult) []byte {
+	var (
+		b                       strings.Builder
+		missingSchemas, failure int
+	)
+
+	for _, result := range results {
+		switch {
+		case result.MissingSchema:
+			missingSchemas++
+
+			fmt.Fprintf(&b, "[!] could not find CRD/XRD for: %s\n", result.GroupVersionKind)
+		case len(result.Errors) > 0:
+			failure++
+
+			for _, err := range result.Errors {
+				fmt.Fprintf(&b, "[x] %s validation error %s, %s : %s: %s\n", err.Type, result.GroupVersionKind, result.Name, err.Field, err.Message)
+			}
+		default:
+			fmt.Fprintf(&b, "[✓] %s, %s validated successfully\n", result.GroupVersionKind, result.Name)
+		}
+	}
+
+	fmt.Fprintf(&b, "Total %d resources: %d missing schemas, %d success cases, %d failure cases\n",
+		len(results), missingSchemas, len(results)-failure-missingSchemas, failure)
+
+	return []byte(b.String())
+}
+
+// formatInputValidateResults formats the results of the input validation like formatValidateResults, with a [!] line
+// for each pruning warning. Resources without a schema are not listed.
+func formatInputValidateResults(results []ValidateResult) []byte {
+	var (
+		b                        strings.Builder
+		total, failure, warnings int
+	)
+
+	for _, result := range results {
+		if result.MissingSchema {
+			continue
+		}
+
+		total++
+		warnings += len(result.Warnings)
+
+		for _, err := range result.Errors {
+			fmt.Fprintf(&b, "[x] %s validation error %s, %s : %s: %s\n", err.Type, result.GroupVersionKind, result.Name, err.Field, err.Message)
+		}
+
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(&b, "[!] %s warning %s, %s : %s: %s\n", warning.Type, result.GroupVersionKind, result.Name, warning.Field, warning.Message)
+		}
+
+		switch {
+		case len(result.Errors) > 0:
+			failure++
+		case len(result.Warnings) == 0:
+			fmt.Fprintf(&b, "[✓] %s, %s validated successfully\n", result.GroupVersionKind, result.Name)
+		}
+	}
+
+	fmt.Fprintf(&b, "Total %d resources: %d success cases, %d failure cases, %d warnings\n", total, total-failure, failure, warnings)
+
+	return []byte(b.String())
+}
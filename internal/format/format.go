@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package format provides the canonical formatting of testsuite files run by xprin fmt.
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// indent is the indentation of the formatted testsuite files.
+const indent = 2
+
+// templateExpressionRe matches the template expressions replaced by placeholders before parsing.
+//
+//nolint:gochecknoglobals // compiled once
+var templateExpressionRe = regexp.MustCompile(`\{\{.*?\}\}`)
+
+// Format returns a testsuite file in its canonical form:
+// - the fields are ordered as in the testsuite specification (see api.TestSuiteSpec), with unknown fields after them
+// in their original order, and the keys of maps (e.g. vars) in their original order
+// - mappings and sequences are in block style, indented by 2 spaces
+// - strings are quoted only when they have to be, and keep their literal or folded block style
+//
+// Comments are kept, and so are the {{ }} template expressions: they are replaced by placeholders to parse the file
+// (see testexecutionUtils.ReplaceTemplateVarsWithPlaceholders), and restored as written afterwards. Strings with
+// template expressions keep their quoting, since a {{ }} expression is not valid YAML unquoted.
+func Format(data []byte) ([]byte, error) {
+	content := testexecutionUtils.ReplaceTemplateVarsWithPlaceholders(string(data))
+
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(content), doc); err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+
+	formatNode(doc.Content[0], reflect.TypeFor[api.TestSuiteSpec]())
+
+	var b bytes.Buffer
+
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(indent)
+
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to format: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to format: %w", err)
+	}
+
+	// Reordering fields can move an alias before its anchor, or a merge key after the fields it sets
+	if err := checkSameContent(content, b.String()); err != nil {
+		return nil, err
+	}
+
+	return []byte(restoreTemplateExpressions(string(data), b.String())), nil
+}
+
+// TestCase formats a node holding a test case, and the nodes under it, the way Format formats the test cases of a
+// testsuite file.
+func TestCase(node *yaml.Node) {
+	formatNode(node, reflect.TypeFor[api.TestCase]())
+}
+
+// formatNode formats a node holding a value of the type t, and the nodes under it.
+func formatNode(node *yaml.Node, t reflect.Type) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		node.Style = 0
+
+		if t != nil && t.Kind() == reflect.Struct {
+			orderFields(node, t)
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			formatNode(node.Content[i], nil)
+			formatNode(node.Content[i+1], valueType(t, node.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		node.Style = 0
+
+		var elem reflect.Type
+		if t != nil && t.Kind() == reflect.Slice {
+			elem = t.Elem()
+		}
+
+		for _, item := range node.Content {
+			formatNode(item, elem)
+		}
+	case yaml.ScalarNode:
+		quoted := node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0
+		if quoted && !strings.Contains(node.Value, testexecutionUtils.PlaceholderOpen) {
+			node.Style &^= yaml.SingleQuotedStyle | yaml.DoubleQuotedStyle
+		}
+	case yaml.DocumentNode, yaml.AliasNode:
+		return
+	}
+}
+
+// valueType returns the type of the value of a key in a mapping holding a value of the type t, or nil when unknown.
+func valueType(t reflect.Type, key string) reflect.Type {
+	switch {
+	case t == nil:
+		return nil
+	case t.Kind() == reflect.Map:
+		return t.Elem()
+	case t.Kind() == reflect.Struct:
+		for _, field := range structFields(t) {
+			if field.name == key {
+				return field.t
+			}
+		}
+	}
+
+	return nil
+}
+
+// orderFields orders the keys of a mapping as the fields of the struct type t, followed by the unknown keys. The head
+// comment of the first key stays at the top of the mapping, as it usually is the header of the file (e.g. the
+// yaml-language-server modeline).
+func orderFields(node *yaml.Node, t reflect.Type) {
+	content := make([]*yaml.Node, 0, len(node.Content))
+	used := make([]bool, len(node.Content))
+
+	for _, field := range structFields(t) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if !used[i] && node.Content[i].Value == field.name {
+				content = append(content, node.Content[i], node.Content[i+1])
+				used[i] = true
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !used[i] {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+
+	if len(content) > 0 && content[0] != node.Content[0] && node.Content[0].HeadComment != "" {
+		content[0].HeadComment = strings.TrimSuffix(node.Content[0].HeadComment+"\n"+content[0].HeadComment, "\n")
+		node.Content[0].HeadComment = ""
+	}
+
+	node.Content = content
+}
+
+// field is a field of a struct type, by JSON name.
+type field struct {
+	name string
+	t    reflect.Type
+}
+
+// structFields returns the fields of a struct type in their order, by JSON name.
+func structFields(t reflect.Type) []field {
+	fields := make([]field, 0, t.NumField())
+
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, field{name: name, t: t.Field(i).Type})
+		}
+	}
+
+	return fields
+}
+
+// checkSameContent returns an error if the formatted content does not decode to the same values as the content.
+func checkSameContent(content, formatted string) error {
+	var before, after any
+
+	if err := yaml.Unmarshal([]byte(content), &before); err != nil {
+		return fmt.Errorf("failed to parse: %w", err)
+	}
+
+	if err := yaml.Unmarshal([]byte(formatted), &after); err != nil || !reflect.DeepEqual(before, after) {
+		return errors.New("formatting would change its content (e.g. an alias would come before its anchor)")
+	}
+
+	return nil
+}
+
+// restoreTemplateExpressions restores the template expressions of the original data in the formatted content, as they
+// were written (the placeholders do not keep the spaces inside the braces).
+func restoreTemplateExpressions(data, formatted string) string {
+	var replacements []string
+
+	seen := make(map[string]bool)
+
+	for _, expression := range templateExpressionRe.FindAllString(data, -1) {
+		placeholder := testexecutionUtils.ReplaceTemplateVarsWithPlaceholders(expression)
+		if !seen[placeholder] {
+			seen[placeholder] = true
+			replacements = append(replacements, placeholder, expression)
+		}
+	}
+
+	return testexecutionUtils.RestoreTemplateVars(strings.NewReplacer(replacements...).Replace(formatted))
+}
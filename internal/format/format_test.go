@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "orders the fields as the testsuite specification",
+			content: `tests:
+- inputs:
+    functions: functions.yaml
+    xr: xr.yaml
+    composition: composition.yaml
+  name: test
+  unknown: kept
+  id: test
+common:
+  vars:
+    zone: a
+    region: b
+`,
+			want: `common:
+  vars:
+    zone: a
+    region: b
+tests:
+  - name: test
+    id: test
+    inputs:
+      xr: xr.yaml
+      composition: composition.yaml
+      functions: functions.yaml
+    unknown: kept
+`,
+		},
+		{
+			name: "keeps the header comment at the top",
+			content: `# yaml-language-server: $schema=https://example.com/testsuite.schema.json
+tests:
+  # the test case
+  - name: test
+    inputs:
+      xr: xr.yaml
+# common inputs
+common:
+  inputs:
+    composition: composition.yaml
+`,
+			want: `# yaml-language-server: $schema=https://example.com/testsuite.schema.json
+# common inputs
+common:
+  inputs:
+    composition: composition.yaml
+tests:
+  # the test case
+  - name: test
+    inputs:
+      xr: xr.yaml
+`,
+		},
+		{
+			name: "normalizes indentation, flow style and quoting",
+			content: `tests:
+    -   name: "test"
+        crossplane: {only: ['crossplane-2.0']}
+        assertions:
+            xprin:
+            - {name: 'count', type: "Count", value: 1}
+            - name: "quoted when needed"
+              type: FieldValue
+              operator: "=="
+              value: "true"
+        hooks:
+          pre-test:
+          - run: |
+              echo one
+              echo two
+`,
+			want: `tests:
+  - name: test
+    hooks:
+      pre-test:
+        - run: |
+            echo one
+            echo two
+    assertions:
+      xprin:
+        - name: count
+          type: Count
+          value: 1
+        - name: quoted when needed
+          type: FieldValue
+          operator: ==
+          value: "true"
+    crossplane:
+      only:
+        - crossplane-2.0
+`,
+		},
+		{
+			name: "keeps comments and template expressions",
+			content: `# Testsuite of the AWS composition
+tests:
+# First test
+- name: test # inline
+  inputs:
+    composition: {{ .Repositories.myrepo }}/composition.yaml
+    xr: "{{ .Tests.base.Outputs.XR }}"
+  hooks:
+    pre-test:
+    - run: echo '{{.Inputs.XR}}' {{- .Vars.x -}}
+`,
+			want: `# Testsuite of the AWS composition
+tests:
+  # First test
+  - name: test # inline
+    inputs:
+      xr: "{{ .Tests.base.Outputs.XR }}"
+      composition: {{ .Repositories.myrepo }}/composition.yaml
+    hooks:
+      pre-test:
+        - run: echo '{{.Inputs.XR}}' {{- .Vars.x -}}
+`,
+		},
+		{
+			name:    "already formatted",
+			content: "tests:\n  - name: test\n",
+			want:    "tests:\n  - name: test\n",
+		},
+		{
+			name:    "empty file",
+			content: "",
+			want:    "",
+		},
+		{
+			name:    "invalid YAML",
+			content: "tests: [\n",
+			wantErr: "failed to parse: yaml: line 1: did not find expected node content",
+		},
+		{
+			name: "alias before its anchor",
+			content: `tests:
+- name: test
+  crossplane: &selector
+    only: [crossplane-2.0]
+  inputs:
+    xr: *selector
+`,
+			wantErr: "formatting would change its content (e.g. an alias would come before its anchor)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format([]byte(tt.content))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+
+			again, err := Format(got)
+			require.NoError(t, err)
+			assert.Equal(t, string(got), string(again), "formatting is not stable")
+		})
+	}
+}
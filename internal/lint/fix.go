@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// Fix fixes the fixable diagnostics of a testsuite file returned by Lint, and returns how many it fixed. The fields
+// of the problems are removed line by line, so the rest of the file keeps its formatting and comments. A mapping left
+// empty by the removals is removed as well.
+func Fix(fs afero.Fs, file string, diagnostics []Diagnostic) (int, error) {
+	var (
+		paths [][]string
+		fixed int
+	)
+
+	for _, d := range diagnostics {
+		if d.Fixable() && d.File == file {
+			paths = append(paths, d.fix)
+			fixed++
+		}
+	}
+
+	if fixed == 0 {
+		return 0, nil
+	}
+
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read testsuite file %s: %w", file, err)
+	}
+
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(testexecutionUtils.ReplaceTemplateVarsWithPlaceholders(string(data))), doc); err != nil || len(doc.Content) == 0 {
+		return 0, fmt.Errorf("failed to parse testsuite file %s: %w", file, err)
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	remove := make([]bool, len(lines))
+
+	for _, path := range removals(doc.Content[0], paths) {
+		key, _ := keyAt(doc.Content[0], path)
+		if key == nil {
+			continue
+		}
+
+		first, last := fieldLines(lines, key)
+		for i := first; i <= last; i++ {
+			remove[i] = true
+		}
+	}
+
+	var b strings.Builder
+
+	for i, line := range lines {
+		if !remove[i] {
+			b.WriteString(line)
+		}
+	}
+
+	info, err := fs.Stat(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat testsuite file %s: %w", file, err)
+	}
+
+	if err := afero.WriteFile(fs, file, []byte(b.String()), info.Mode()&os.ModePerm); err != nil {
+		return 0, fmt.Errorf("failed to write testsuite file %s: %w", file, err)
+	}
+
+	return fixed, nil
+}
+
+// removals returns the paths of the fields to remove: the paths of the fixes, replaced by the path of their parent
+// mapping when all its fields are removed.
+func removals(root *yaml.Node, paths [][]string) [][]string {
+	set := make(map[string][]string)
+	for _, path := range paths {
+		set[strings.Join(path, "\x00")] = path
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		children := make(map[string]int)
+		for _, path := range set {
+			if len(path) > 1 {
+				children[strings.Join(path[:len(path)-1], "\x00")]++
+			}
+		}
+
+		for parentKey, count := range children {
+			parent := strings.Split(parentKey, "\x00")
+
+			_, value := keyAt(root, parent)
+			if value == nil || value.Kind != yaml.MappingNode || count < len(value.Content)/2 {
+				continue
+			}
+
+			for key, path := range set {
+				if len(path) == len(parent)+1 && strings.Join(path[:len(parent)], "\x00") == parentKey {
+					delete(set, key)
+				}
+			}
+
+			set[parentKey] = parent
+			changed = true
+		}
+	}
+
+	result := make([][]string, 0, len(set))
+	for _, path := range set {
+		result = append(result, path)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.Join(result[i], "\x00") < strings.Join(result[j], "\x00")
+	})
+
+	return result
+}
+
+// keyAt returns the key and value nodes of a path of mapping keys under root, or nil nodes when it does not exist.
+func keyAt(root *yaml.Node, path []string) (*yaml.Node, *yaml.Node) {
+	var key *yaml.Node
+
+	value := root
+
+	for _, k := range path {
+		if value.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+
+		if key, value = mappingValue(value, k); value == nil {
+			return nil, nil
+		}
+	}
+
+	return key, value
+}
+
+// fieldLines returns the indexes of the first and last lines of the field of a key in a block mapping: the line of the
+// key and the lines of its value, which are more indented or sequence items at the indentation of the key. Comments
+// and blank lines after the value are kept, since they usually belong to the next field.
+func fieldLines(lines []string, key *yaml.Node) (int, int) {
+	first := key.Line - 1
+	last := first
+	indent := key.Column - 1
+
+	for i := first + 1; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if strings.TrimSpace(trimmed) == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		lineIndent := len(lines[i]) - len(trimmed)
+		item := strings.HasPrefix(trimmed, "-") && !strings.HasPrefix(trimmed, "---")
+		if lineIndent < indent || (lineIndent == indent && !item) {
+			break
+		}
+
+		last = i
+	}
+
+	return first, last
+}
@@ -0,0 +1,697 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint provides the static checks of testsuite files run by xprin lint, without rendering their test cases.
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// Severity is the severity of a diagnostic: errors make xprin test fail or misbehave, warnings do not.
+type Severity string
+
+// Severities of diagnostics.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Checks that report diagnostics.
+const (
+	CheckParse         = "parse"
+	CheckUnknownField  = "unknown-field"
+	CheckInvalidValue  = "invalid-value"
+	CheckTestSuite     = "testsuite"
+	CheckMandatory     = "mandatory-fields"
+	CheckMissingInput  = "missing-input"
+	CheckAssertion     = "assertion"
+	CheckGoldenFile    = "golden-file"
+	CheckTestReference = "test-reference"
+	CheckDuplicateName = "duplicate-name"
+	CheckUnusedCommon  = "unused-common"
+)
+
+// Diagnostic is a problem found in a testsuite file.
+type Diagnostic struct {
+	File     string   // Path of the testsuite file
+	Line     int      // Line of the problem in the testsuite file
+	Severity Severity // Severity of the problem
+	Check    string   // Check that found the problem
+	Message  string   // Description of the problem
+	fix      []string // Mapping keys of the field that Fix removes, or nil when the problem has no safe fix
+}
+
+// Fixable returns true if Fix can fix the problem.
+func (d Diagnostic) Fixable() bool {
+	return d.fix != nil
+}
+
+// String returns the diagnostic as "file:line: severity: message (check)".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s (%s)", d.File, d.Line, d.Severity, d.Message, d.Check)
+}
+
+// yamlErrorLineRe matches the line of YAML errors.
+var yamlErrorLineRe = regexp.MustCompile(`line (\d+)`) //nolint:gochecknoglobals // compiled once
+
+// linter collects the diagnostics of a testsuite file.
+type linter struct {
+	fs          afero.Fs
+	file        string
+	data        string     // Content of the file
+	content     string     // Content of the file, with template variables replaced by placeholders
+	root        *yaml.Node // Mapping node of the testsuite
+	spec        *api.TestSuiteSpec
+	hooks       string // Commands of the hooks of the testsuite
+	diagnostics []Diagnostic
+}
+
+// Lint statically checks a testsuite file and returns its problems, sorted by line:
+// - the file must parse, and have only fields of the testsuite specification, with values of their type and among
+// their allowed values (see api.CheckNode)
+// - the testsuite must be valid (see api.TestSuiteSpec.CheckValidTestSuiteFile), and the test cases must have their
+// mandatory fields (see api.TestCase.CheckMandatoryFields)
+// - the input files must exist, relative to the testsuite file
+// - xprin assertions must have the fields of their type, and golden-file assertions an existing expected file
+// - {{ .Tests.<id> }} references must be to test cases that run before the test case
+// - test case names should be unique, and common fields should be used by at least one test case
+//
+// Paths and values with template variables are not checked, since they are only known when the tests run, and neither
+// are the files named in the commands of the hooks, which can write them.
+func Lint(fs afero.Fs, file string) ([]Diagnostic, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read testsuite file %s: %w", file, err)
+	}
+
+	l := &linter{fs: fs, file: file, data: string(data), content: testexecutionUtils.ReplaceTemplateVarsWithPlaceholders(string(data))}
+
+	if l.parse() {
+		l.checkTestSuite()
+		l.checkInputFiles()
+		l.checkAssertions(l.spec.Common.Assertions, "common", "assertions")
+
+		for i := range l.spec.Tests {
+			l.checkAssertions(l.spec.Tests[i].Assertions, "tests", i, "assertions")
+		}
+
+		l.checkTestReferences()
+		l.checkUnusedCommon()
+	}
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		return l.diagnostics[i].Line < l.diagnostics[j].Line
+	})
+
+	return l.diagnostics, nil
+}
+
+// report adds a diagnostic at a line.
+func (l *linter) report(line int, severity Severity, check string, fix []string, format string, args ...any) {
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		File:     l.file,
+		Line:     line,
+		Severity: severity,
+		Check:    check,
+		Message:  fmt.Sprintf(format, args...),
+		fix:      fix,
+	})
+}
+
+// parse parses the testsuite file and returns true if it can be checked further.
+func (l *linter) parse() bool {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(l.content), doc); err != nil {
+		line := 1
+		if m := yamlErrorLineRe.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+
+		l.report(line, SeverityError, CheckParse, nil, "failed to parse: %v", err)
+
+		return false
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		l.report(1, SeverityError, CheckParse, nil, "the testsuite must be a mapping with a tests field")
+		return false
+	}
+
+	l.root = doc.Content[0]
+
+	// The fields are checked like xprin test decodes the testsuite (see api.Decode)
+	decodable := true
+
+	for _, fieldErr := range api.CheckNode(l.root) {
+		check := CheckInvalidValue
+
+		switch fieldErr.Kind {
+		case api.FieldErrorUnknown:
+			check = CheckUnknownField
+		case api.FieldErrorType:
+			decodable = false
+		}
+
+		l.report(fieldErr.Line, SeverityError, check, nil, "%s", testexecutionUtils.RestoreTemplateVars(fieldErr.Message))
+	}
+
+	if !decodable {
+		return false
+	}
+
+	l.spec = &api.TestSuiteSpec{}
+	if err := sigsyaml.Unmarshal([]byte(l.content), l.spec); err != nil {
+		l.report(1, SeverityError, CheckParse, nil, "failed to parse: %v", err)
+		return false
+	}
+
+	hooks := hookCommands(l.spec.Common.Hooks)
+
+	for _, testCase := range l.spec.Tests {
+		hooks = append(hooks, hookCommands(testCase.Hooks)...)
+	}
+
+	l.hooks = strings.Join(hooks, "\n")
+
+	return true
+}
+
+// hookCommands returns the commands of hooks.
+func hookCommands(hooks api.Hooks) []string {
+	var commands []string
+
+	for _, hook := range slices.Concat(hooks.PreTest, hooks.PostTest) {
+		commands = append(commands, hook.Run)
+	}
+
+	return commands
+}
+
+// writtenByHooks returns true if a file is named in the commands of the hooks of the testsuite, which can write it
+// before it is used (e.g. a golden file copied from a render).
+func (l *linter) writtenByHooks(path string) bool {
+	return strings.Contains(l.hooks, filepath.Base(path))
+}
+
+// checkTestSuite reports the problems xprin test reports when it loads the testsuite and runs its test cases.
+func (l *linter) checkTestSuite() {
+	if len(l.spec.Tests) == 0 {
+		l.report(l.line("tests"), SeverityError, CheckTestSuite, nil, "no test cases found")
+		return
+	}
+
+	if err := l.spec.CheckValidTestSuiteFile(); err != nil {
+		_, problems, _ := strings.Cut(err.Error(), "\n- ")
+		for problem := range strings.SplitSeq(problems, "\n- ") {
+			l.report(l.line("tests"), SeverityError, CheckTestSuite, nil, "%s", problem)
+		}
+	}
+
+	lines := make(map[string]int)
+
+	for i, testCase := range l.spec.Tests {
+		line := l.line("tests", i)
+
+		merged := testCase
+		merged.MergeCommon(l.spec.Common)
+
+		if err := merged.CheckMandatoryFields(); err != nil {
+			for problem := range strings.SplitSeq(err.Error(), "\n    ") {
+				l.report(line, SeverityError, CheckMandatory, nil, "test case '%s': %s", testCase.Name, problem)
+			}
+		}
+
+		if testCase.Name == "" {
+			continue
+		}
+
+		if first, ok := lines[testCase.Name]; ok {
+			l.report(line, SeverityWarning, CheckDuplicateName, nil, "duplicate test case name '%s' (first used on line %d)", testCase.Name, first)
+			continue
+		}
+
+		lines[testCase.Name] = line
+	}
+}
+
+// checkInputFiles reports the input files of the testsuite that do not exist.
+func (l *linter) checkInputFiles() {
+	for i, dependency := range l.spec.DependsOn {
+		l.checkFile(dependency, "depends-on testsuite file", "depends-on", i)
+	}
+
+	l.checkInputs(l.spec.Common.Inputs, "common", "inputs")
+	l.checkFile(l.spec.Common.Patches.XRD, "patches xrd", "common", "patches", "xrd")
+
+	for i, testCase := range l.spec.Tests {
+		l.checkInputs(testCase.Inputs, "tests", i, "inputs")
+		l.checkFile(testCase.Patches.XRD, "patches xrd", "tests", i, "patches", "xrd")
+
+		if testCase.XRVariants != nil {
+			l.checkFile(testCase.XRVariants.XRD, "xr-variants xrd", "tests", i, "xr-variants", "xrd")
+		}
+
+		if testCase.Fuzz != nil {
+			l.checkFile(testCase.Fuzz.XRD, "fuzz xrd", "tests", i, "fuzz", "xrd")
+		}
+	}
+}
+
+// checkInputs reports the files of inputs that do not exist. The crds-from entries are not checked, since they can be
+// package references.
+func (l *linter) checkInputs(inputs api.Inputs, path ...any) {
+	at := func(more ...any) []any {
+		return append(slices.Clone(path), more...)
+	}
+
+	l.checkFile(inputs.Claim, "claim", at("claim")...)
+	l.checkFile(inputs.XR, "xr", at("xr")...)
+	l.checkFile(inputs.Composition, "composition", at("composition")...)
+	l.checkFile(inputs.Functions, "functions", at("functions")...)
+	l.checkFile(inputs.ObservedResources, "observed-resources", at("observed-resources")...)
+	l.checkFile(inputs.ExtraResources, "extra-resources", at("extra-resources")...)
+	l.checkFile(inputs.FunctionCredentials, "function-credentials", at("function-credentials")...)
+
+	for i, crd := range inputs.CRDs {
+		l.checkFile(crd, "crds", at("crds", i)...)
+	}
+
+	for _, key := range sortedKeys(inputs.ContextFiles) {
+		l.checkFile(inputs.ContextFiles[key], "context-files", at("context-files", key)...)
+	}
+}
+
+// checkFile reports a file, relative to the testsuite file, that does not exist. Empty and templated paths, and files
+// the hooks can write, are not checked.
+func (l *linter) checkFile(path, description string, at ...any) {
+	if path == "" || isTemplated(path) || l.writtenByHooks(path) {
+		return
+	}
+
+	expanded, err := testexecutionUtils.ExpandPathRelativeToTestSuiteFile(l.file, path)
+	if err != nil {
+		l.report(l.line(at...), SeverityError, CheckMissingInput, nil, "invalid %s path %s: %v", description, path, err)
+		return
+	}
+
+	if exists, _ := afero.Exists(l.fs, expanded); !exists {
+		l.report(l.line(at...), SeverityError, CheckMissingInput, nil, "%s %s does not exist", description, path)
+	}
+}
+
+// checkAssertions reports the xprin assertions without the fields of their type, and the golden-file assertions
+// whose expected file does not exist or whose resource can never be in the rendered resources.
+func (l *linter) checkAssertions(assertions api.Assertions, path ...any) {
+	at := func(more ...any) int {
+		return l.line(append(slices.Clone(path), more...)...)
+	}
+
+	for i, assertion := range assertions.Xprin {
+		for _, problem := range xprinAssertionProblems(assertion) {
+			l.report(at("xprin", i), SeverityError, CheckAssertion, nil, "assertion '%s': %s", assertion.Name, problem)
+		}
+	}
+
+	golden := []struct {
+		engine     string
+		assertions []api.AssertionGoldenFile
+	}{{"diff", assertions.Diff}, {"dyff", assertions.Dyff}}
+
+	for _, g := range golden {
+		engine := g.engine
+
+		for i, assertion := range g.assertions {
+			line := at(engine, i)
+
+			if assertion.Name == "" {
+				l.report(line, SeverityError, CheckAssertion, nil, "%s assertion has no name", engine)
+			}
+
+			if assertion.Resource != "" && !isTemplated(assertion.Resource) && strings.Count(assertion.Resource, "/") != 1 {
+				l.report(line, SeverityError, CheckGoldenFile, nil, "%s assertion '%s': resource must be in format 'Kind/name', got '%s'", engine, assertion.Name, assertion.Resource)
+			}
+
+			if assertion.Expected == "" {
+				l.report(line, SeverityError, CheckAssertion, nil, "%s assertion '%s' has no expected file", engine, assertion.Name)
+				continue
+			}
+
+			if isTemplated(assertion.Expected) || l.writtenByHooks(assertion.Expected) {
+				continue
+			}
+
+			expanded, err := testexecutionUtils.ExpandPathRelativeToTestSuiteFile(l.file, assertion.Expected)
+			if exists, _ := afero.Exists(l.fs, expanded); err != nil || !exists {
+				l.report(at(engine, i, "expected"), SeverityError, CheckGoldenFile, nil, "%s assertion '%s': expected file %s does not exist", engine, assertion.Name, assertion.Expected)
+			}
+		}
+	}
+}
+
+// xprinAssertionProblems returns the problems of an xprin assertion that make it error when it runs. Unknown types
+// and operators are reported by api.CheckNode.
+func xprinAssertionProblems(a api.AssertionXprin) []string {
+	var problems []string
+
+	if a.Name == "" {
+		problems = append(problems, "has no name")
+	}
+
+	requires := func(field string, set bool) {
+		if !set {
+			problems = append(problems, fmt.Sprintf("%s assertion requires %s", a.Type, field))
+		}
+	}
+
+	resourceFormat := func(formats ...int) {
+		if a.Resource == "" || isTemplated(a.Resource) || slices.Contains(formats, len(strings.Split(a.Resource, "/"))) {
+			return
+		}
+
+		format := "'Kind/name'"
+		if slices.Contains(formats, 1) {
+			format = "'Kind' or 'Kind/name'"
+		}
+
+		problems = append(problems, fmt.Sprintf("%s assertion resource must be in format %s, got '%s'", a.Type, format, a.Resource))
+	}
+
+	switch a.Type {
+	case "Count":
+		if _, ok := a.Value.(float64); !ok && !isTemplatedValue(a.Value) {
+			problems = append(problems, fmt.Sprintf("Count assertion value must be a number, got %v", a.Value))
+		}
+	case "Exists":
+		requires("resource", a.Resource != "")
+		resourceFormat(2)
+	case "NotExists":
+		requires("resource", a.Resource != "")
+		resourceFormat(1, 2)
+	case "FieldType":
+		requires("resource", a.Resource != "")
+		requires("field", a.Field != "")
+		requires("value", a.Value != nil)
+		resourceFormat(2)
+
+		if _, ok := a.Value.(string); a.Value != nil && !ok {
+			problems = append(problems, fmt.Sprintf("FieldType assertion value must be a type name, got %v", a.Value))
+		}
+	case "FieldExists", "FieldNotExists":
+		requires("resource", a.Resource != "")
+		requires("field", a.Field != "")
+		resourceFormat(2)
+	case "FieldValue":
+		requires("resource", a.Resource != "")
+		requires("field", a.Field != "")
+		requires("operator", a.Operator != "")
+		requires("value", a.Value != nil)
+		resourceFormat(2)
+	case "":
+		problems = append(problems, "has no type")
+	}
+
+	return problems
+}
+
+// isTemplated returns true if a value has template variables.
+func isTemplated(value string) bool {
+	return strings.Contains(value, testexecutionUtils.PlaceholderOpen)
+}
+
+// isTemplatedValue returns true if an assertion value is a string with template variables.
+func isTemplatedValue(value any) bool {
+	s, ok := value.(string)
+	return ok && isTemplated(s)
+}
+
+// checkTestReferences reports the {{ .Tests.<id> }} references to test cases that do not exist, or that do not run
+// before the test case referencing them. Common fields are used by every test case, so they can only be checked for
+// unknown IDs.
+func (l *linter) checkTestReferences() {
+	indexByID := make(map[string]int)
+
+	for i, testCase := range l.spec.Tests {
+		if testCase.ID != "" {
+			indexByID[testCase.ID] = i
+		}
+	}
+
+	position := make(map[int]int, len(l.spec.Tests))
+	for p, i := range l.spec.RunOrder() {
+		position[i] = p
+	}
+
+	if common := l.node("common"); common != nil {
+		for _, ref := range testReferences(common) {
+			if _, ok := indexByID[ref.id]; !ok {
+				l.report(ref.line, SeverityError, CheckTestReference, nil, "common fields reference unknown test case ID '%s'", ref.id)
+			}
+		}
+	}
+
+	for i, testCase := range l.spec.Tests {
+		for _, ref := range testReferences(l.node("tests", i)) {
+			j, ok := indexByID[ref.id]
+
+			switch {
+			case !ok:
+				l.report(ref.line, SeverityError, CheckTestReference, nil, "test case '%s' references unknown test case ID '%s'", testCase.Name, ref.id)
+			case j == i:
+				l.report(ref.line, SeverityError, CheckTestReference, nil, "test case '%s' references its own ID '%s'", testCase.Name, ref.id)
+			case position[j] > position[i]:
+				l.report(ref.line, SeverityError, CheckTestReference, nil, "test case '%s' references test case ID '%s', which runs after it (add it to depends-on)", testCase.Name, ref.id)
+			}
+		}
+	}
+}
+
+// testReference is a reference to the results of a test case in a template.
+type testReference struct {
+	id   string
+	line int
+}
+
+// testReferences returns the references to test case results in the scalars under node.
+func testReferences(node *yaml.Node) []testReference {
+	if node == nil {
+		return nil
+	}
+
+	var refs []testReference
+
+	if node.Kind == yaml.ScalarNode {
+		value := testexecutionUtils.RestoreTemplateVars(node.Value)
+
+		for _, m := range testexecutionUtils.TestReferenceRe.FindAllStringSubmatchIndex(value, -1) {
+			var id string
+			if m[2] >= 0 {
+				id = value[m[2]:m[3]]
+			} else {
+				id = value[m[4]:m[5]]
+			}
+
+			line := node.Line
+			if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+				line += 1 + strings.Count(value[:m[0]], "\n")
+			}
+
+			refs = append(refs, testReference{id: id, line: line})
+		}
+	}
+
+	for _, child := range node.Content {
+		refs = append(refs, testReferences(child)...)
+	}
+
+	return refs
+}
+
+// checkUnusedCommon reports the common fields that no test case uses, because every test case sets its own, and the
+// common vars that are not referenced. Removing them does not change the tests, so they are fixable.
+func (l *linter) checkUnusedCommon() {
+	common := l.spec.Common
+	tests := l.spec.Tests
+
+	if len(tests) == 0 {
+		return
+	}
+
+	unused := func(set bool, overrides func(tc api.TestCase) bool, keys ...string) {
+		if !set {
+			return
+		}
+
+		for _, tc := range tests {
+			if !overrides(tc) {
+				return
+			}
+		}
+
+		path := append([]string{"common"}, keys...)
+		l.report(l.line(toAny(path)...), SeverityWarning, CheckUnusedCommon, l.fixPath(path), "common %s is not used: every test case sets its own", strings.Join(keys, "."))
+	}
+
+	generated := func(tc api.TestCase) bool {
+		return tc.XRVariants != nil || tc.Fuzz != nil
+	}
+
+	unused(common.Inputs.Claim != "", func(tc api.TestCase) bool { return tc.Inputs.Claim != "" || generated(tc) }, "inputs", "claim")
+	unused(common.Inputs.XR != "", func(tc api.TestCase) bool { return tc.Inputs.XR != "" || generated(tc) }, "inputs", "xr")
+	unused(common.Inputs.Composition != "", func(tc api.TestCase) bool { return tc.Inputs.Composition != "" }, "inputs", "composition")
+	unused(common.Inputs.Functions != "", func(tc api.TestCase) bool { return tc.Inputs.Functions != "" }, "inputs", "functions")
+	unused(len(common.Inputs.CRDs) > 0, func(tc api.TestCase) bool { return len(tc.Inputs.CRDs) > 0 }, "inputs", "crds")
+	unused(len(common.Inputs.CRDsFrom) > 0, func(tc api.TestCase) bool { return len(tc.Inputs.CRDsFrom) > 0 }, "inputs", "crds-from")
+	unused(len(common.Inputs.ContextFiles) > 0, func(tc api.TestCase) bool { return len(tc.Inputs.ContextFiles) > 0 }, "inputs", "context-files")
+	unused(len(common.Inputs.ContextValues) > 0, func(tc api.TestCase) bool { return len(tc.Inputs.ContextValues) > 0 }, "inputs", "context-values")
+	unused(common.Inputs.ObservedResources != "", func(tc api.TestCase) bool { return tc.Inputs.ObservedResources != "" }, "inputs", "observed-resources")
+	unused(common.Inputs.ExtraResources != "", func(tc api.TestCase) bool { return tc.Inputs.ExtraResources != "" }, "inputs", "extra-resources")
+	unused(common.Inputs.FunctionCredentials != "", func(tc api.TestCase) bool { return tc.Inputs.FunctionCredentials != "" }, "inputs", "function-credentials")
+	unused(common.Patches.XRD != "", func(tc api.TestCase) bool { return tc.Patches.XRD != "" }, "patches", "xrd")
+	unused(common.Patches.ConnectionSecret != nil, func(tc api.TestCase) bool { return tc.Patches.ConnectionSecret != nil }, "patches", "connection-secret")
+	unused(common.Patches.ConnectionSecretName != "", func(tc api.TestCase) bool { return tc.Patches.ConnectionSecretName != "" }, "patches", "connection-secret-name")
+	unused(common.Patches.ConnectionSecretNamespace != "", func(tc api.TestCase) bool { return tc.Patches.ConnectionSecretNamespace != "" }, "patches", "connection-secret-namespace")
+	unused(common.Hooks.HasPreTestHooks(), func(tc api.TestCase) bool { return tc.HasPreTestHooks() }, "hooks", "pre-test")
+	unused(common.Hooks.HasPostTestHooks(), func(tc api.TestCase) bool { return tc.HasPostTestHooks() }, "hooks", "post-test")
+	unused(common.Assertions.HasAssertionsXprin(), func(tc api.TestCase) bool { return tc.HasAssertionsXprin() }, "assertions", "xprin")
+	unused(common.Assertions.HasAssertionsDiff(), func(tc api.TestCase) bool { return tc.HasAssertionsDiff() }, "assertions", "diff")
+	unused(common.Assertions.HasAssertionsDyff(), func(tc api.TestCase) bool { return tc.HasAssertionsDyff() }, "assertions", "dyff")
+	unused(common.Crossplane.HasSelection(), func(tc api.TestCase) bool { return tc.Crossplane.HasSelection() }, "crossplane")
+
+	for _, name := range sortedKeys(common.Vars) {
+		quoted := regexp.QuoteMeta(name)
+		if regexp.MustCompile(`\.Vars\.` + quoted + `\b|index\s+\.Vars\s+"` + quoted + `"`).MatchString(l.data) {
+			continue
+		}
+
+		path := []string{"common", "vars", name}
+		l.report(l.line(toAny(path)...), SeverityWarning, CheckUnusedCommon, l.fixPath(path), "common var '%s' is not used", name)
+	}
+}
+
+// fixPath returns the path of a field for a fix that removes it, or nil when the field is in a flow-style mapping,
+// which the lines of the field cannot be removed from.
+func (l *linter) fixPath(path []string) []string {
+	node := l.root
+
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode || node.Style&yaml.FlowStyle != 0 {
+			return nil
+		}
+
+		_, node = mappingValue(node, key)
+		if node == nil {
+			return nil
+		}
+	}
+
+	return path
+}
+
+// node returns the node at a path of mapping keys (strings) and sequence indexes (ints) under the testsuite, or nil.
+func (l *linter) node(path ...any) *yaml.Node {
+	node := l.root
+
+	for _, p := range path {
+		if _, node = child(node, p); node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// line returns the line of the deepest node of a path under the testsuite that exists: the line of the key for
+// mapping keys, and of the item for sequence indexes.
+func (l *linter) line(path ...any) int {
+	line := 1
+	node := l.root
+
+	for _, p := range path {
+		var key *yaml.Node
+
+		key, node = child(node, p)
+		if node == nil {
+			break
+		}
+
+		line = key.Line
+	}
+
+	return line
+}
+
+// child returns the key and value nodes of a mapping key (string) or the item node (twice) of a sequence index (int)
+// of a node, or nil nodes when it does not exist.
+func child(node *yaml.Node, p any) (*yaml.Node, *yaml.Node) {
+	switch p := p.(type) {
+	case string:
+		if node.Kind == yaml.MappingNode {
+			return mappingValue(node, p)
+		}
+	case int:
+		if node.Kind == yaml.SequenceNode && p < len(node.Content) {
+			return node.Content[p], node.Content[p]
+		}
+	}
+
+	return nil, nil
+}
+
+// mappingValue returns the key and value nodes of a key of a mapping node, or nil nodes when it does not exist.
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// toAny converts a path of mapping keys to the path of node and line.
+func toAny(path []string) []any {
+	keys := make([]any, 0, len(path))
+	for _, key := range path {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// sortedKeys returns the keys of a map, sorted.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
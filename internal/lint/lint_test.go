@@ -0,0 +1,426 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// newTestFs returns a filesystem with the input files of the testsuites of the tests.
+func newTestFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for _, path := range []string{"/suite/xr.yaml", "/suite/composition.yaml", "/suite/functions/f.yaml", "/suite/golden.yaml"} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte("{}\n"), 0o600))
+	}
+
+	return fs
+}
+
+// lint writes a testsuite file and returns its diagnostics as strings.
+func lint(t *testing.T, fs afero.Fs, content string) []string {
+	t.Helper()
+
+	require.NoError(t, afero.WriteFile(fs, "/suite/xprin.yaml", []byte(content), 0o600))
+
+	diagnostics, err := Lint(fs, "/suite/xprin.yaml")
+	require.NoError(t, err)
+
+	lines := make([]string, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		lines = append(lines, d.String())
+	}
+
+	return lines
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "valid testsuite",
+			content: `common:
+  inputs:
+    composition: composition.yaml
+    functions: functions
+  vars:
+    region: eu-west-1
+tests:
+  - name: first
+    id: first
+    inputs:
+      xr: xr.yaml
+    patches:
+      xrd: "{{ .Vars.missing }}/xrd.yaml"
+    hooks:
+      post-test:
+        - run: cp "{{ .Outputs.Render }}" golden/render.yaml
+  - name: second
+    inputs:
+      xr: "{{ .Tests.first.Outputs.XR }}"
+    hooks:
+      post-test:
+        - run: echo {{ .Vars.region }}
+    assertions:
+      xprin:
+        - name: bucket region
+          type: FieldValue
+          resource: Bucket/bucket
+          field: spec.forProvider.region
+          operator: ==
+          value: eu-west-1
+      diff:
+        - name: render
+          expected: golden.yaml
+      dyff:
+        - name: render written by a hook
+          expected: golden/render.yaml
+`,
+		},
+		{
+			name:    "parse error",
+			content: "tests:\n  - name: a\n    inputs: [xr.yaml\n",
+			want:    []string{"/suite/xprin.yaml:2: error: failed to parse: yaml: line 2: did not find expected ',' or ']' (parse)"},
+		},
+		{
+			name:    "wrong type",
+			content: "tests: a\n",
+			want:    []string{"/suite/xprin.yaml:1: error: field 'tests' must be a sequence, got a string (invalid-value)"},
+		},
+		{
+			name: "unknown fields",
+			content: `Tests: []
+tests:
+  - name: a
+    inputs:
+      xr: xr.yaml
+      composition: composition.yaml
+      functions: functions
+      compositon: composition.yaml
+    assertions:
+      xprin:
+        - name: count
+          type: Count
+          value: 1
+          resources: 2
+`,
+			want: []string{
+				"/suite/xprin.yaml:1: error: unknown field 'Tests' (did you mean 'tests'?) (unknown-field)",
+				"/suite/xprin.yaml:8: error: unknown field 'tests[0].inputs.compositon' (unknown-field)",
+				"/suite/xprin.yaml:14: error: unknown field 'tests[0].assertions.xprin[0].resources' (unknown-field)",
+			},
+		},
+		{
+			name: "invalid testsuite and missing mandatory fields",
+			content: `tests:
+  - name: a
+    id: a
+    depends-on: [b]
+    inputs:
+      xr: xr.yaml
+`,
+			want: []string{
+				"/suite/xprin.yaml:1: error: test case 'a' depends on unknown test case ID 'b' (testsuite)",
+				"/suite/xprin.yaml:2: error: test case 'a': missing mandatory field: composition (it can be specified either in the test case or in the common inputs) (mandatory-fields)",
+				"/suite/xprin.yaml:2: error: test case 'a': missing mandatory field: functions (it can be specified either in the test case or in the common inputs) (mandatory-fields)",
+			},
+		},
+		{
+			name: "missing input files",
+			content: `depends-on: [other_xprin.yaml]
+common:
+  inputs:
+    composition: composition.yaml
+    functions: functions
+    crds: [crds/missing.yaml]
+tests:
+  - name: a
+    inputs:
+      xr: missing-xr.yaml
+      context-files:
+        apiextensions.crossplane.io/environment: environment.yaml
+    fuzz:
+      xrd: xrd.yaml
+`,
+			want: []string{
+				"/suite/xprin.yaml:1: error: depends-on testsuite file other_xprin.yaml does not exist (missing-input)",
+				"/suite/xprin.yaml:6: error: crds crds/missing.yaml does not exist (missing-input)",
+				"/suite/xprin.yaml:7: error: test case 'a' has both fuzz and a claim or xr input (testsuite)",
+				"/suite/xprin.yaml:10: error: xr missing-xr.yaml does not exist (missing-input)",
+				"/suite/xprin.yaml:12: error: context-files environment.yaml does not exist (missing-input)",
+				"/suite/xprin.yaml:14: error: fuzz xrd xrd.yaml does not exist (missing-input)",
+			},
+		},
+		{
+			name: "invalid assertions",
+			content: `common:
+  inputs:
+    xr: xr.yaml
+    composition: composition.yaml
+    functions: functions
+  assertions:
+    xprin:
+      - name: count
+        type: Count
+        value: many
+tests:
+  - name: a
+    assertions:
+      xprin:
+        - name: region
+          type: FieldValue
+          resource: Bucket/bucket
+          field: spec.region
+          value: eu-west-1
+        - name: exists
+          type: Exists
+          resource: Bucket
+        - type: Missing
+      diff:
+        - name: render
+          expected: missing.yaml
+      dyff:
+        - name: bucket
+          resource: Bucket/bucket/extra
+          expected: golden.yaml
+`,
+			want: []string{
+				"/suite/xprin.yaml:7: warning: common assertions.xprin is not used: every test case sets its own (unused-common)",
+				"/suite/xprin.yaml:8: error: assertion 'count': Count assertion value must be a number, got many (assertion)",
+				"/suite/xprin.yaml:15: error: assertion 'region': FieldValue assertion requires operator (assertion)",
+				"/suite/xprin.yaml:20: error: assertion 'exists': Exists assertion resource must be in format 'Kind/name', got 'Bucket' (assertion)",
+				"/suite/xprin.yaml:23: error: invalid value 'Missing' for field 'tests[0].assertions.xprin[2].type': must be one of Count, Exists, NotExists, FieldType, FieldExists, FieldNotExists, FieldValue (invalid-value)",
+				"/suite/xprin.yaml:23: error: assertion '': has no name (assertion)",
+				"/suite/xprin.yaml:26: error: diff assertion 'render': expected file missing.yaml does not exist (golden-file)",
+				"/suite/xprin.yaml:28: error: dyff assertion 'bucket': resource must be in format 'Kind/name', got 'Bucket/bucket/extra' (golden-file)",
+			},
+		},
+		{
+			name: "test references",
+			content: `common:
+  inputs:
+    xr: xr.yaml
+    composition: composition.yaml
+    functions: functions
+  hooks:
+    pre-test:
+      - run: echo {{ .Tests.unknown.Name }}
+tests:
+  - name: a
+    id: a
+    hooks:
+      post-test:
+        - run: |
+            echo {{ .Tests.a.Name }}
+            echo {{ .Tests.b.Name }}
+  - name: b
+    id: b
+    inputs:
+      observed-resources: '{{ index .Tests "c" }}'
+  - name: c
+    id: c
+    depends-on: [d]
+    inputs:
+      xr: "{{ .Tests.d.Outputs.XR }}"
+  - name: d
+    id: d
+    inputs:
+      xr: "{{ .Suites.other.Tests.x.Outputs.XR }}"
+`,
+			want: []string{
+				"/suite/xprin.yaml:8: error: common fields reference unknown test case ID 'unknown' (test-reference)",
+				"/suite/xprin.yaml:15: error: test case 'a' references its own ID 'a' (test-reference)",
+				"/suite/xprin.yaml:16: error: test case 'a' references test case ID 'b', which runs after it (add it to depends-on) (test-reference)",
+				"/suite/xprin.yaml:20: error: test case 'b' references test case ID 'c', which runs after it (add it to depends-on) (test-reference)",
+			},
+		},
+		{
+			name: "duplicate names and unused common fields",
+			content: `common:
+  inputs:
+    xr: xr.yaml
+    composition: composition.yaml
+    functions: functions
+  vars:
+    region: eu-west-1
+    size: "{{ .Vars.region }}"
+tests:
+  - name: a
+    inputs:
+      composition: composition.yaml
+  - name: a
+    inputs:
+      composition: composition.yaml
+`,
+			want: []string{
+				"/suite/xprin.yaml:4: warning: common inputs.composition is not used: every test case sets its own (unused-common)",
+				"/suite/xprin.yaml:8: warning: common var 'size' is not used (unused-common)",
+				"/suite/xprin.yaml:13: warning: duplicate test case name 'a' (first used on line 10) (duplicate-name)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := lint(t, newTestFs(t), tt.content)
+			if len(tt.want) == 0 {
+				assert.Empty(t, diagnostics)
+				return
+			}
+
+			assert.Equal(t, tt.want, diagnostics)
+		})
+	}
+}
+
+func TestDiagnosticFixable(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "/suite/xprin.yaml", []byte(`common:
+  inputs: {xr: xr.yaml, composition: composition.yaml, functions: functions}
+  vars:
+    region: eu-west-1
+tests:
+  - name: a
+    inputs:
+      xr: xr.yaml
+`), 0o600))
+
+	diagnostics, err := Lint(fs, "/suite/xprin.yaml")
+	require.NoError(t, err)
+	require.Len(t, diagnostics, 2)
+
+	assert.False(t, diagnostics[0].Fixable(), "fields of flow-style mappings are not fixable")
+	assert.True(t, diagnostics[1].Fixable())
+}
+
+func TestFix(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		fixed   int
+	}{
+		{
+			name: "unused common fields",
+			content: `# Buckets
+common:
+  inputs:
+    xr: xr.yaml
+    crds:
+      - crds/a.yaml
+    composition: composition.yaml
+    functions: functions
+  vars:
+    region: eu-west-1
+    # Unused
+    size: large
+tests:
+  - name: a # first
+    inputs:
+      crds:
+      - functions/f.yaml
+      xr: "{{ .Vars.region }}.yaml"
+`,
+			want: `# Buckets
+common:
+  inputs:
+    composition: composition.yaml
+    functions: functions
+  vars:
+    region: eu-west-1
+    # Unused
+tests:
+  - name: a # first
+    inputs:
+      crds:
+      - functions/f.yaml
+      xr: "{{ .Vars.region }}.yaml"
+`,
+			fixed: 3,
+		},
+		{
+			name: "mappings left empty",
+			content: `common:
+  hooks:
+    pre-test:
+      - name: setup
+        run: |
+          echo setup
+
+          echo done
+  vars:
+    unused: "true"
+tests:
+  - name: a
+    inputs:
+      xr: xr.yaml
+      composition: composition.yaml
+      functions: functions
+    hooks:
+      pre-test:
+        - run: echo a
+`,
+			want: `tests:
+  - name: a
+    inputs:
+      xr: xr.yaml
+      composition: composition.yaml
+      functions: functions
+    hooks:
+      pre-test:
+        - run: echo a
+`,
+			fixed: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newTestFs(t)
+			require.NoError(t, afero.WriteFile(fs, "/suite/xprin.yaml", []byte(tt.content), 0o600))
+
+			diagnostics, err := Lint(fs, "/suite/xprin.yaml")
+			require.NoError(t, err)
+
+			fixed, err := Fix(fs, "/suite/xprin.yaml", diagnostics)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fixed, fixed)
+
+			data, err := afero.ReadFile(fs, "/suite/xprin.yaml")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+
+			remaining, err := Lint(fs, "/suite/xprin.yaml")
+			require.NoError(t, err)
+			assert.Empty(t, remaining)
+		})
+	}
+
+	t.Run("nothing to fix", func(t *testing.T) {
+		fixed, err := Fix(afero.NewMemMapFs(), "/suite/xprin.yaml", []Diagnostic{{File: "/suite/xprin.yaml", Line: 1}})
+		require.NoError(t, err)
+		assert.Zero(t, fixed)
+	})
+}
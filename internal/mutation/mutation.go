@@ -0,0 +1,286 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mutation mutates Compositions systematically for mutation testing: a mutation of a Composition that the
+// test cases using it still pass with "survives", which shows a change to the Composition the test cases do not
+// detect.
+package mutation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Kinds of mutations.
+const (
+	KindRemoveStep        = "remove-step"          // Remove a step of the pipeline
+	KindRemoveResource    = "remove-resource"      // Remove a resource template from the input of a step
+	KindChangeToFieldPath = "change-to-field-path" // Change the toFieldPath of a patch of a resource template
+)
+
+// mutatedSuffix is appended to the last segment of the toFieldPath of a mutated patch.
+const mutatedSuffix = "Mutated"
+
+// Mutation is a single change to a Composition. Steps, resources and patches are identified by their index, which
+// is only meaningful for the Composition the mutation was found in.
+type Mutation struct {
+	Kind     string
+	Step     int    // Index of the pipeline step
+	Resource int    // Index of the resource template in the resources of the step input
+	Patch    int    // Index of the patch in the patches of the resource template
+	desc     string // Description of the mutation, returned by String
+}
+
+// String describes the mutation.
+func (m Mutation) String() string {
+	return m.desc
+}
+
+// Load reads and parses a Composition.
+func Load(fs afero.Fs, path string) (map[string]any, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read composition %s: %w", path, err)
+	}
+
+	composition := map[string]any{}
+	if err := yaml.Unmarshal(data, &composition); err != nil {
+		return nil, fmt.Errorf("failed to parse composition %s: %w", path, err)
+	}
+
+	return composition, nil
+}
+
+// Mutations returns the mutations of a Composition, in order: removing each pipeline step (unless it is the only
+// one), removing each resource template from the resources of the step inputs (as in function-patch-and-transform),
+// and changing the toFieldPath of each patch of the resource templates.
+func Mutations(composition map[string]any) []Mutation {
+	pipeline, _, _ := unstructured.NestedSlice(composition, "spec", "pipeline")
+
+	var mutations []Mutation
+
+	if len(pipeline) > 1 {
+		for i, s := range pipeline {
+			mutations = append(mutations, Mutation{Kind: KindRemoveStep, Step: i, desc: fmt.Sprintf("remove pipeline step %s", stepName(s, i))})
+		}
+	}
+
+	for i, s := range pipeline {
+		for j, r := range stepResources(s) {
+			mutations = append(mutations, Mutation{
+				Kind:     KindRemoveResource,
+				Step:     i,
+				Resource: j,
+				desc:     fmt.Sprintf("remove resource %s of step %s", resourceName(r, j), stepName(s, i)),
+			})
+		}
+	}
+
+	for i, s := range pipeline {
+		for j, r := range stepResources(s) {
+			patches, _, _ := unstructured.NestedSlice(utils.AsMap(r), "patches")
+
+			for k, p := range patches {
+				path, _, _ := unstructured.NestedString(utils.AsMap(p), "toFieldPath")
+				if path == "" {
+					continue
+				}
+
+				mutations = append(mutations, Mutation{
+					Kind:     KindChangeToFieldPath,
+					Step:     i,
+					Resource: j,
+					Patch:    k,
+					desc: fmt.Sprintf("change toFieldPath of patch %d of resource %s of step %s from %s to %s",
+						k, resourceName(r, j), stepName(s, i), path, mutateFieldPath(path)),
+				})
+			}
+		}
+	}
+
+	return mutations
+}
+
+// Apply applies a mutation to a Composition in place.
+func (m Mutation) Apply(composition map[string]any) error {
+	pipeline, _, err := unstructured.NestedSlice(composition, "spec", "pipeline")
+	if err != nil || m.Step >= len(pipeline) {
+		return fmt.Errorf("composition has no pipeline step %d", m.Step)
+	}
+
+	if m.Kind == KindRemoveStep {
+		return unstructured.SetNestedSlice(composition, slices.Delete(pipeline, m.Step, m.Step+1), "spec", "pipeline")
+	}
+
+	step := utils.AsMap(pipeline[m.Step])
+
+	resources, _, _ := unstructured.NestedSlice(step, "input", "resources")
+	if m.Resource >= len(resources) {
+		return fmt.Errorf("pipeline step %d has no resource %d", m.Step, m.Resource)
+	}
+
+	switch m.Kind {
+	case KindRemoveResource:
+		resources = slices.Delete(resources, m.Resource, m.Resource+1)
+	case KindChangeToFieldPath:
+		resource := utils.AsMap(resources[m.Resource])
+
+		patches, _, _ := unstructured.NestedSlice(resource, "patches")
+		if m.Patch >= len(patches) {
+			return fmt.Errorf("resource %d of pipeline step %d has no patch %d", m.Resource, m.Step, m.Patch)
+		}
+
+		patch := utils.AsMap(patches[m.Patch])
+
+		path, _, _ := unstructured.NestedString(patch, "toFieldPath")
+		if path == "" {
+			return fmt.Errorf("patch %d of resource %d of pipeline step %d has no toFieldPath", m.Patch, m.Resource, m.Step)
+		}
+
+		patch["toFieldPath"] = mutateFieldPath(path)
+		patches[m.Patch] = patch
+		resource["patches"] = patches
+		resources[m.Resource] = resource
+	default:
+		return fmt.Errorf("unknown mutation kind %q", m.Kind)
+	}
+
+	if err := unstructured.SetNestedSlice(step, resources, "input", "resources"); err != nil {
+		return fmt.Errorf("failed to set the resources of pipeline step %d: %w", m.Step, err)
+	}
+
+	pipeline[m.Step] = step
+
+	return unstructured.SetNestedSlice(composition, pipeline, "spec", "pipeline")
+}
+
+// ApplyFile applies a mutation to the Composition in a file, replacing the file.
+func ApplyFile(fs afero.Fs, path string, m Mutation) error {
+	composition, err := Load(fs, path)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Apply(composition); err != nil {
+		return fmt.Errorf("failed to mutate composition %s: %w", path, err)
+	}
+
+	data, err := yaml.Marshal(composition)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mutated composition: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write mutated composition %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Mutant is a Composition with a mutation applied, with the test cases that ran with it. It is safe for concurrent
+// use.
+type Mutant struct {
+	Composition string // Absolute path of the mutated Composition
+	Mutation    Mutation
+
+	mu       sync.Mutex
+	ran      []string
+	killedBy []string
+}
+
+// NewMutant returns the mutant of a Composition with a mutation, with no test case run yet.
+func NewMutant(composition string, m Mutation) *Mutant {
+	return &Mutant{Composition: composition, Mutation: m}
+}
+
+// AddResult records a test case that ran with the mutant, and whether it failed (killing the mutant).
+func (m *Mutant) AddResult(testCase string, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ran = append(m.ran, testCase)
+	if failed {
+		m.killedBy = append(m.killedBy, testCase)
+	}
+}
+
+// Ran returns the test cases that ran with the mutant.
+func (m *Mutant) Ran() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.ran)
+}
+
+// KilledBy returns the test cases that failed with the mutant.
+func (m *Mutant) KilledBy() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.killedBy)
+}
+
+// stepResources returns the resource templates in the input of a pipeline step.
+func stepResources(step any) []any {
+	resources, _, _ := unstructured.NestedSlice(utils.AsMap(step), "input", "resources")
+	return resources
+}
+
+// stepName returns the quoted name of a pipeline step, or its index when it has no name.
+func stepName(step any, i int) string {
+	if name, _, _ := unstructured.NestedString(utils.AsMap(step), "step"); name != "" {
+		return fmt.Sprintf("%q", name)
+	}
+
+	return fmt.Sprintf("%d", i)
+}
+
+// resourceName returns the quoted name of a resource template, or its index when it has no name.
+func resourceName(resource any, i int) string {
+	if name, _, _ := unstructured.NestedString(utils.AsMap(resource), "name"); name != "" {
+		return fmt.Sprintf("%q", name)
+	}
+
+	return fmt.Sprintf("%d", i)
+}
+
+// mutateFieldPath returns a field path next to a field path, by changing its last segment: a field name
+// (spec.size becomes spec.sizeMutated), a map key (metadata.labels[app] becomes metadata.labels[appMutated]) or an
+// array index (spec.ports[0] becomes spec.ports[1]).
+func mutateFieldPath(path string) string {
+	if open := strings.LastIndex(path, "["); open >= 0 && strings.HasSuffix(path, "]") {
+		if index, err := strconv.Atoi(path[open+1 : len(path)-1]); err == nil {
+			return fmt.Sprintf("%s[%d]", path[:open], index+1)
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(path, `"]`), strings.HasSuffix(path, `']`):
+		return path[:len(path)-2] + mutatedSuffix + path[len(path)-2:]
+	case strings.HasSuffix(path, "]"):
+		return path[:len(path)-1] + mutatedSuffix + "]"
+	default:
+		return path + mutatedSuffix
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const testComposition = `apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: xbuckets
+spec:
+  compositeTypeRef:
+    apiVersion: example.org/v1
+    kind: XBucket
+  mode: Pipeline
+  pipeline:
+    - step: patch-and-transform
+      functionRef:
+        name: function-patch-and-transform
+      input:
+        apiVersion: pt.fn.crossplane.io/v1beta1
+        kind: Resources
+        resources:
+          - name: bucket
+            patches:
+              - type: FromCompositeFieldPath
+                fromFieldPath: spec.region
+                toFieldPath: spec.forProvider.region
+              - type: FromCompositeFieldPath
+                fromFieldPath: spec.team
+                toFieldPath: metadata.labels[team]
+          - name: policy
+    - step: auto-ready
+      functionRef:
+        name: function-auto-ready
+`
+
+func loadTestComposition(t *testing.T) map[string]any {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/composition.yaml", []byte(testComposition), 0o600))
+
+	composition, err := Load(fs, "/composition.yaml")
+	require.NoError(t, err)
+
+	return composition
+}
+
+func TestMutations(t *testing.T) {
+	mutations := Mutations(loadTestComposition(t))
+
+	descriptions := make([]string, 0, len(mutations))
+	for _, m := range mutations {
+		descriptions = append(descriptions, m.String())
+	}
+
+	assert.Equal(t, []string{
+		`remove pipeline step "patch-and-transform"`,
+		`remove pipeline step "auto-ready"`,
+		`remove resource "bucket" of step "patch-and-transform"`,
+		`remove resource "policy" of step "patch-and-transform"`,
+		`change toFieldPath of patch 0 of resource "bucket" of step "patch-and-transform" from spec.forProvider.region to spec.forProvider.regionMutated`,
+		`change toFieldPath of patch 1 of resource "bucket" of step "patch-and-transform" from metadata.labels[team] to metadata.labels[teamMutated]`,
+	}, descriptions)
+
+	t.Run("single step is not removed", func(t *testing.T) {
+		composition := loadTestComposition(t)
+		pipeline, _, _ := unstructured.NestedSlice(composition, "spec", "pipeline")
+		require.NoError(t, unstructured.SetNestedSlice(composition, pipeline[1:], "spec", "pipeline"))
+
+		assert.Empty(t, Mutations(composition))
+	})
+}
+
+func TestMutationApply(t *testing.T) {
+	mutations := Mutations(loadTestComposition(t))
+
+	steps := func(composition map[string]any) []string {
+		pipeline, _, _ := unstructured.NestedSlice(composition, "spec", "pipeline")
+
+		names := make([]string, 0, len(pipeline))
+		for _, s := range pipeline {
+			name, _, _ := unstructured.NestedString(s.(map[string]any), "step")
+			names = append(names, name)
+		}
+
+		return names
+	}
+
+	resources := func(composition map[string]any) []any {
+		pipeline, _, _ := unstructured.NestedSlice(composition, "spec", "pipeline")
+		resources, _, _ := unstructured.NestedSlice(pipeline[0].(map[string]any), "input", "resources")
+
+		return resources
+	}
+
+	t.Run("remove step", func(t *testing.T) {
+		composition := loadTestComposition(t)
+		require.NoError(t, mutations[0].Apply(composition))
+
+		assert.Equal(t, []string{"auto-ready"}, steps(composition))
+	})
+
+	t.Run("remove resource", func(t *testing.T) {
+		composition := loadTestComposition(t)
+		require.NoError(t, mutations[2].Apply(composition))
+
+		assert.Len(t, resources(composition), 1)
+		assert.Equal(t, "policy", resources(composition)[0].(map[string]any)["name"])
+		assert.Equal(t, []string{"patch-and-transform", "auto-ready"}, steps(composition))
+	})
+
+	t.Run("change toFieldPath", func(t *testing.T) {
+		composition := loadTestComposition(t)
+		require.NoError(t, mutations[5].Apply(composition))
+
+		patches, _, _ := unstructured.NestedSlice(resources(composition)[0].(map[string]any), "patches")
+		assert.Equal(t, "spec.forProvider.region", patches[0].(map[string]any)["toFieldPath"])
+		assert.Equal(t, "metadata.labels[teamMutated]", patches[1].(map[string]any)["toFieldPath"])
+	})
+
+	t.Run("mutation of another composition", func(t *testing.T) {
+		composition := loadTestComposition(t)
+		require.NoError(t, unstructured.SetNestedSlice(composition, []any{}, "spec", "pipeline"))
+
+		require.ErrorContains(t, mutations[5].Apply(composition), "composition has no pipeline step 0")
+	})
+}
+
+func TestApplyFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/composition.yaml", []byte(testComposition), 0o600))
+
+	composition, err := Load(fs, "/composition.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, ApplyFile(fs, "/composition.yaml", Mutations(composition)[1]))
+
+	mutated, err := Load(fs, "/composition.yaml")
+	require.NoError(t, err)
+
+	pipeline, _, _ := unstructured.NestedSlice(mutated, "spec", "pipeline")
+	assert.Len(t, pipeline, 1)
+	assert.Equal(t, "xbuckets", mutated["metadata"].(map[string]any)["name"])
+
+	require.ErrorContains(t, ApplyFile(fs, "/missing.yaml", Mutations(composition)[1]), "failed to read composition /missing.yaml")
+}
+
+func TestMutateFieldPath(t *testing.T) {
+	cases := map[string]string{
+		"spec.size":                    "spec.sizeMutated",
+		"metadata.labels[app]":         "metadata.labels[appMutated]",
+		`metadata.annotations["a/b"]`:  `metadata.annotations["a/bMutated"]`,
+		"spec.forProvider.ports[0]":    "spec.forProvider.ports[1]",
+		"spec.forProvider.rules[1].to": "spec.forProvider.rules[1].toMutated",
+	}
+
+	for path, want := range cases {
+		assert.Equal(t, want, mutateFieldPath(path), path)
+	}
+}
+
+func TestMutant(t *testing.T) {
+	m := NewMutant("/composition.yaml", Mutation{Kind: KindRemoveStep})
+
+	assert.Empty(t, m.Ran())
+
+	m.AddResult("first", false)
+	m.AddResult("second", true)
+
+	assert.Equal(t, []string{"first", "second"}, m.Ran())
+	assert.Equal(t, []string{"second"}, m.KilledBy())
+}
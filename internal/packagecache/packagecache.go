@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package packagecache resolves the CRDs of Crossplane packages (providers, functions and configurations) from a
+// local cache of their extracted package layers, with the layout of the crossplane beta validate cache.
+package packagecache
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/cmd/crank/beta/validate"
+	regv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// DefaultDir is the default cache directory, shared with crossplane beta validate.
+	DefaultDir = "~/.crossplane/cache"
+
+	// packageFileName is the file of a cached package that holds the objects of its package layer.
+	packageFileName = "package.yaml"
+
+	metaGroup = "meta.pkg.crossplane.io"
+	pkgGroup  = "pkg.crossplane.io"
+)
+
+// IsPackageFile reports whether a crds-from entry is a file (e.g. crossplane.yaml) rather than a package reference.
+func IsPackageFile(entry string) bool {
+	switch strings.ToLower(filepath.Ext(entry)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// Packages returns the package references of crds-from entries, in order and without duplicates: package references
+// are kept as they are, and files contribute the dependencies of package metadata (crossplane.yaml) and the packages
+// of Provider, Function and Configuration resources.
+func Packages(fs afero.Fs, entries []string) ([]string, error) {
+	var packages []string
+
+	for _, entry := range entries {
+		if !IsPackageFile(entry) {
+			packages = append(packages, entry)
+			continue
+		}
+
+		filePackages, err := filePackages(fs, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read packages from %s: %w", entry, err)
+		}
+
+		packages = append(packages, filePackages...)
+	}
+
+	var unique []string
+
+	for _, pkg := range packages {
+		if !slices.Contains(unique, pkg) {
+			unique = append(unique, pkg)
+		}
+	}
+
+	return unique, nil
+}
+
+// filePackages returns the packages referenced by the objects of a (multi-document) YAML file.
+func filePackages(fs afero.Fs, file string) ([]string, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []string
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		switch obj.GroupVersionKind().Group {
+		case metaGroup:
+			var meta struct {
+				Spec pkgmetav1.MetaSpec `json:"spec"`
+			}
+
+			raw, err := json.Marshal(obj.Object)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := json.Unmarshal(raw, &meta); err != nil {
+				return nil, fmt.Errorf("failed to decode the dependencies of %s: %w", obj.GetName(), err)
+			}
+
+			for _, dependency := range meta.Spec.DependsOn {
+				if image := dependencyImage(dependency); image != "" {
+					packages = append(packages, image)
+				}
+			}
+		case pkgGroup:
+			image, _, err := unstructured.NestedString(obj.Object, "spec", "package")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the package of %s: %w", obj.GetName(), err)
+			}
+
+			if image != "" {
+				packages = append(packages, image)
+			}
+		}
+	}
+
+	return packages, nil
+}
+
+// dependencyImage returns the package reference of a dependency, like crossplane beta validate: the version is a
+// digest or a tag (possibly a version constraint, which is resolved when the package is pulled).
+func dependencyImage(dependency pkgmetav1.Dependency) string {
+	var image string
+
+	for _, ref := range []*string{dependency.Package, dependency.Configuration, dependency.Provider, dependency.Function} {
+		if ref != nil && *ref != "" {
+			image = *ref
+			break
+		}
+	}
+
+	if image == "" {
+		return ""
+	}
+
+	if _, err := regv1.NewHash(dependency.Version); err == nil {
+		return image + "@" + dependency.Version
+	}
+
+	return image + ":" + dependency.Version
+}
+
+// Path returns the path of the cached package file of a package reference.
+func Path(cacheDir, pkg string) string {
+	return filepath.Join(cacheDir, strings.ReplaceAll(pkg, ":", "@"), packageFileName)
+}
+
+// Lookup returns the paths of the cached package files of the packages. It fails when a package is not in the cache.
+func Lookup(fs afero.Fs, cacheDir string, packages []string) ([]string, error) {
+	paths := make([]string, 0, len(packages))
+
+	var missing []string
+
+	for _, pkg := range packages {
+		path := Path(cacheDir, pkg)
+		if _, err := fs.Stat(path); err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("failed to check the cache of package %s: %w", pkg, err)
+			}
+
+			missing = append(missing, pkg)
+
+			continue
+		}
+
+		paths = append(paths, path)
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("packages not found in the CRDs cache %s (run xprin crds pull): %s", cacheDir, strings.Join(missing, ", "))
+	}
+
+	return paths, nil
+}
+
+// Pull downloads the package layers of the packages that are not in the cache yet, with the package manager of
+// crossplane beta validate, and writes its progress to w.
+func Pull(fs afero.Fs, cacheDir string, packages []string, w io.Writer) error {
+	extensions := make([]*unstructured.Unstructured, 0, len(packages))
+
+	for _, pkg := range packages {
+		// Every package is pulled as a plain dependency, so that the cache holds exactly the packages Lookup needs
+		extensions = append(extensions, &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": pkgGroup + "/v1",
+			"kind":       "Provider",
+			"spec":       map[string]any{"package": pkg},
+		}})
+	}
+
+	manager := validate.NewManager(cacheDir, fs, w)
+	if err := manager.PrepExtensions(extensions); err != nil {
+		return err
+	}
+
+	return manager.CacheAndLoad(false)
+}
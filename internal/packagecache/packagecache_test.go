@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package packagecache
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const (
+	s3Provider = "xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"
+	patchAndTf = "xpkg.crossplane.io/crossplane-contrib/function-patch-and-transform@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	bucketCRDs = "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: buckets.s3.aws.upbound.io\n"
+	crossplane = `apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: platform
+spec:
+  dependsOn:
+    - provider: xpkg.crossplane.io/crossplane-contrib/provider-aws-s3
+      version: v2.0.0
+    - function: xpkg.crossplane.io/crossplane-contrib/function-patch-and-transform
+      version: sha256:0000000000000000000000000000000000000000000000000000000000000000
+`
+	providers = `apiVersion: pkg.crossplane.io/v1
+kind: Provider
+metadata:
+  name: provider-aws-s3
+spec:
+  package: xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0
+---
+apiVersion: pkg.crossplane.io/v1
+kind: Function
+metadata:
+  name: function-go-templating
+spec:
+  package: xpkg.crossplane.io/crossplane-contrib/function-go-templating:v0.11.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+)
+
+func TestIsPackageFile(t *testing.T) {
+	assert.True(t, IsPackageFile("crossplane.yaml"))
+	assert.True(t, IsPackageFile("deps/providers.YML"))
+	assert.False(t, IsPackageFile(s3Provider))
+	assert.False(t, IsPackageFile(patchAndTf))
+}
+
+func TestPackages(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/pkg/crossplane.yaml", []byte(crossplane), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "/pkg/providers.yaml", []byte(providers), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "/pkg/invalid.yaml", []byte("kind: [unclosed"), 0o600))
+
+	t.Run("package metadata dependencies", func(t *testing.T) {
+		packages, err := Packages(fs, []string{"/pkg/crossplane.yaml"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{s3Provider, patchAndTf}, packages)
+	})
+
+	t.Run("package resources and references without duplicates", func(t *testing.T) {
+		packages, err := Packages(fs, []string{s3Provider, "/pkg/providers.yaml", "/pkg/crossplane.yaml"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			s3Provider,
+			"xpkg.crossplane.io/crossplane-contrib/function-go-templating:v0.11.0",
+			patchAndTf,
+		}, packages)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		_, err := Packages(fs, []string{"/pkg/invalid.yaml"})
+		require.ErrorContains(t, err, "failed to read packages from /pkg/invalid.yaml")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Packages(fs, []string{"/pkg/missing.yaml"})
+		require.ErrorContains(t, err, "failed to read packages from /pkg/missing.yaml")
+	})
+}
+
+func TestLookup(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, Path("/cache", s3Provider), []byte(bucketCRDs), 0o600))
+
+	assert.Equal(t, "/cache/xpkg.crossplane.io/crossplane-contrib/provider-aws-s3@v2.0.0/package.yaml", Path("/cache", s3Provider))
+
+	t.Run("cached packages", func(t *testing.T) {
+		paths, err := Lookup(fs, "/cache", []string{s3Provider})
+		require.NoError(t, err)
+		assert.Equal(t, []string{Path("/cache", s3Provider)}, paths)
+	})
+
+	t.Run("missing packages", func(t *testing.T) {
+		_, err := Lookup(fs, "/cache", []string{s3Provider, patchAndTf})
+		require.EqualError(t, err, "packages not found in the CRDs cache /cache (run xprin crds pull): "+patchAndTf)
+	})
+}
+
+func TestPull(t *testing.T) {
+	// Packages that are already cached are not downloaded, so this runs offline
+	cacheDir := t.TempDir()
+	fs := afero.NewOsFs()
+	require.NoError(t, fs.MkdirAll(filepath.Dir(Path(cacheDir, s3Provider)), 0o750))
+	require.NoError(t, afero.WriteFile(fs, Path(cacheDir, s3Provider), []byte(bucketCRDs), 0o600))
+
+	var out bytes.Buffer
+
+	require.NoError(t, Pull(fs, cacheDir, []string{s3Provider}, &out))
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,461 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scaffold generates a starter testsuite for a directory of Crossplane manifests: it detects the
+// Compositions, XRDs, CRDs, functions files and example XRs and Claims of the directory and wires them up as the
+// inputs of a testsuite with one test case per example.
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/crossplane-contrib/xprin/internal/validator"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// SchemaHeader points editors at the JSON schema of testsuite files (see cmd/schema-gen).
+const SchemaHeader = "# yaml-language-server: $schema=https://raw.githubusercontent.com/crossplane-contrib/xprin/main/data/xprin-testsuite.json\n"
+
+// Manifest groups of the detected files.
+const (
+	apiextensionsGroup = "apiextensions.crossplane.io"
+	pkgGroup           = "pkg.crossplane.io"
+	crdGroup           = "apiextensions.k8s.io"
+)
+
+// Example is an example XR or Claim of a directory.
+type Example struct {
+	Path       string // Path of the file, relative to the directory
+	Name       string // Name of the resource
+	APIVersion string // API version of the XR, or of the XR of the Claim
+	Kind       string // Kind of the XR, or of the XR of the Claim
+	Claim      bool   // Whether the example is a Claim
+}
+
+// Composition is a Composition of a directory.
+type Composition struct {
+	Path       string // Path of the file, relative to the directory
+	Name       string // Name of the Composition
+	APIVersion string // API version of the composite type
+	Kind       string // Kind of the composite type
+}
+
+// Project is what Detect found in a directory. Paths are relative to the directory, with forward slashes.
+type Project struct {
+	Dir          string
+	Compositions []Composition
+	XRDs         []string
+	CRDs         []string
+	Functions    []string
+	Examples     []Example
+}
+
+// file is a manifest file of a directory with its resources.
+type file struct {
+	path      string
+	resources []*unstructured.Unstructured
+}
+
+// Detect finds the Compositions, XRDs, CRDs, functions files and example XRs and Claims in a directory and its
+// subdirectories, in lexical order. Hidden directories and files that are not YAML manifests are skipped. Example
+// XRs and Claims are the single-resource files of the kinds of the XRDs and of the composite types of the
+// Compositions.
+func Detect(fs afero.Fs, dir string) (*Project, error) {
+	files, err := manifestFiles(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Project{Dir: dir}
+
+	// Kinds of the XRs and of the Claims (to the kind of the XR they claim), by API version and kind
+	xrKinds := map[string]bool{}
+	claimKinds := map[string][2]string{}
+
+	for _, f := range files {
+		for _, r := range f.resources {
+			group := apiGroup(r.GetAPIVersion())
+
+			switch {
+			case group == apiextensionsGroup && r.GetKind() == "Composition":
+				c := Composition{Path: f.path, Name: r.GetName()}
+				c.APIVersion, _, _ = unstructured.NestedString(r.Object, "spec", "compositeTypeRef", "apiVersion")
+				c.Kind, _, _ = unstructured.NestedString(r.Object, "spec", "compositeTypeRef", "kind")
+				p.Compositions = append(p.Compositions, c)
+				xrKinds[c.APIVersion+"/"+c.Kind] = true
+			case group == apiextensionsGroup && r.GetKind() == "CompositeResourceDefinition":
+				p.XRDs = appendUnique(p.XRDs, f.path)
+				addXRDKinds(r, xrKinds, claimKinds)
+			case group == crdGroup && r.GetKind() == "CustomResourceDefinition":
+				p.CRDs = appendUnique(p.CRDs, f.path)
+			case group == pkgGroup && r.GetKind() == "Function":
+				p.Functions = appendUnique(p.Functions, f.path)
+			}
+		}
+	}
+
+	for _, f := range files {
+		if len(f.resources) != 1 {
+			continue
+		}
+
+		r := f.resources[0]
+
+		if xrKinds[r.GetAPIVersion()+"/"+r.GetKind()] {
+			p.Examples = append(p.Examples, Example{Path: f.path, Name: r.GetName(), APIVersion: r.GetAPIVersion(), Kind: r.GetKind()})
+			continue
+		}
+
+		if xr, ok := claimKinds[r.GetAPIVersion()+"/"+r.GetKind()]; ok {
+			p.Examples = append(p.Examples, Example{Path: f.path, Name: r.GetName(), APIVersion: xr[0], Kind: xr[1], Claim: true})
+		}
+	}
+
+	return p, nil
+}
+
+// manifestFiles returns the YAML manifest files of a directory and its subdirectories that can be parsed.
+func manifestFiles(fs afero.Fs, dir string) ([]file, error) {
+	var files []file
+
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if ext := strings.ToLower(filepath.Ext(path)); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		// Files that are not manifests (e.g. configuration files) are not inputs
+		resources, err := validator.LoadResources(fs, path)
+		if err != nil || len(resources) == 0 {
+			return nil //nolint:nilerr // unparsable files are skipped
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fmt.Errorf("failed to make %s relative to %s: %w", path, dir, err)
+		}
+
+		files = append(files, file{path: filepath.ToSlash(rel), resources: resources})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find manifests in %s: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+// addXRDKinds adds the kinds of the XRs and Claims of every version of an XRD.
+func addXRDKinds(xrd *unstructured.Unstructured, xrKinds map[string]bool, claimKinds map[string][2]string) {
+	group, _, _ := unstructured.NestedString(xrd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(xrd.Object, "spec", "names", "kind")
+	claimKind, _, _ := unstructured.NestedString(xrd.Object, "spec", "claimNames", "kind")
+	versions, _, _ := unstructured.NestedSlice(xrd.Object, "spec", "versions")
+
+	for _, v := range versions {
+		version, _, _ := unstructured.NestedString(utils.AsMap(v), "name")
+		apiVersion := group + "/" + version
+
+		xrKinds[apiVersion+"/"+kind] = true
+
+		if claimKind != "" {
+			claimKinds[apiVersion+"/"+claimKind] = [2]string{apiVersion, kind}
+		}
+	}
+}
+
+// Options selects what the testsuite of a Project is generated with.
+type Options struct {
+	Composition string // Path of the Composition to test, relative to the directory; empty means the first one
+	Functions   string // Path of the functions file or directory, relative to the directory; empty means the first one
+	Base        string // Directory the paths of the testsuite are relative to (that of the testsuite file); empty means the directory
+}
+
+// TestSuite returns the testsuite of a Project: common inputs with the Composition, the functions and the XRDs and
+// CRDs as crds, and one test case per example XR or Claim of the composite type of the Composition (or per example
+// when the Composition has no composite type that an example matches).
+func (p *Project) TestSuite(o Options) (*api.TestSuiteSpec, error) {
+	if len(p.Compositions) == 0 {
+		return nil, fmt.Errorf("no compositions found in %s", p.Dir)
+	}
+
+	composition := p.Compositions[0]
+
+	if o.Composition != "" {
+		i := slices.IndexFunc(p.Compositions, func(c Composition) bool { return c.Path == filepath.ToSlash(o.Composition) })
+		if i < 0 {
+			return nil, fmt.Errorf("composition %s not found in %s", o.Composition, p.Dir)
+		}
+
+		composition = p.Compositions[i]
+	}
+
+	functions := o.Functions
+	if functions == "" {
+		if len(p.Functions) == 0 {
+			return nil, fmt.Errorf("no functions files found in %s", p.Dir)
+		}
+
+		functions = p.Functions[0]
+	}
+
+	examples := p.examplesOf(composition)
+	if len(examples) == 0 {
+		return nil, fmt.Errorf("no example XRs or claims found in %s", p.Dir)
+	}
+
+	rel := func(path string) (string, error) {
+		if o.Base == "" {
+			return filepath.ToSlash(path), nil
+		}
+
+		rel, err := filepath.Rel(o.Base, filepath.Join(p.Dir, path))
+		if err != nil {
+			return "", fmt.Errorf("failed to make %s relative to %s: %w", path, o.Base, err)
+		}
+
+		return filepath.ToSlash(rel), nil
+	}
+
+	spec := &api.TestSuiteSpec{}
+
+	var err error
+
+	if spec.Common.Inputs.Composition, err = rel(composition.Path); err != nil {
+		return nil, err
+	}
+
+	if spec.Common.Inputs.Functions, err = rel(functions); err != nil {
+		return nil, err
+	}
+
+	for _, crd := range append(slices.Clone(p.XRDs), p.CRDs...) {
+		path, err := rel(crd)
+		if err != nil {
+			return nil, err
+		}
+
+		spec.Common.Inputs.CRDs = append(spec.Common.Inputs.CRDs, path)
+	}
+
+	names := map[string]int{}
+
+	for _, e := range examples {
+		name := e.Name
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(e.Path), filepath.Ext(e.Path))
+		}
+
+		// Examples with the same name are told apart by a number
+		names[name]++
+		if names[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, names[name])
+		}
+
+		path, err := rel(e.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		testCase := api.TestCase{Name: name}
+		if e.Claim {
+			testCase.Inputs.Claim = path
+		} else {
+			testCase.Inputs.XR = path
+		}
+
+		spec.Tests = append(spec.Tests, testCase)
+	}
+
+	return spec, nil
+}
+
+// examplesOf returns the examples of the composite type of a Composition, or all of them when none is.
+func (p *Project) examplesOf(c Composition) []Example {
+	var examples []Example
+
+	for _, e := range p.Examples {
+		if e.APIVersion == c.APIVersion && e.Kind == c.Kind {
+			examples = append(examples, e)
+		}
+	}
+
+	if len(examples) == 0 {
+		return p.Examples
+	}
+
+	return examples
+}
+
+// Render renders every test case of a testsuite, as the testsuite file would run them, and returns the rendered
+// resources by test case name. Test cases that fail to render have no rendered resources. The test results are
+// written to output.
+func Render(options *testexecutionUtils.Options, testSuiteFile string, spec *api.TestSuiteSpec, output io.Writer) (map[string][]*unstructured.Unstructured, error) {
+	renderOptions := *options
+	renderOptions.Output = output
+
+	// The runner resolves the inputs of the test cases in place, so it runs a copy of the testsuite
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy testsuite: %w", err)
+	}
+
+	runSpec := &api.TestSuiteSpec{}
+	if err := json.Unmarshal(data, runSpec); err != nil {
+		return nil, fmt.Errorf("failed to copy testsuite: %w", err)
+	}
+
+	r := runner.NewRunner(&renderOptions, testSuiteFile, runSpec)
+	if err := r.RunTests(); err != nil && !strings.Contains(err.Error(), "tests failed in testsuite") {
+		return nil, err
+	}
+
+	rendered := map[string][]*unstructured.Unstructured{}
+
+	if result := r.TestSuiteResult(); result != nil {
+		for _, testCase := range result.Results {
+			if !testCase.HasFailedRender && testCase.Status != engine.StatusSkip() {
+				rendered[testCase.Name] = testCase.RenderedResources
+			}
+		}
+	}
+
+	return rendered, nil
+}
+
+// StarterAssertions returns the starter assertions of a render: the Count of the rendered resources and an Exists
+// assertion per composed resource. The first rendered resource is the XR.
+func StarterAssertions(rendered []*unstructured.Unstructured) api.Assertions {
+	var (
+		assertions api.Assertions
+		keys       []string
+	)
+
+	for _, r := range rendered {
+		key := r.GetKind() + "/" + r.GetName()
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	assertions.Xprin = append(assertions.Xprin, api.AssertionXprin{
+		Name:  fmt.Sprintf("renders %d resources", len(keys)),
+		Type:  "Count",
+		Value: len(keys),
+	})
+
+	for i, r := range rendered {
+		if i == 0 || r.GetName() == "" {
+			continue
+		}
+
+		resource := r.GetKind() + "/" + r.GetName()
+		assertions.Xprin = append(assertions.Xprin, api.AssertionXprin{
+			Name:     resource + " exists",
+			Type:     "Exists",
+			Resource: resource,
+		})
+	}
+
+	return assertions
+}
+
+// Marshal returns a testsuite as YAML, starting with SchemaHeader and without the empty fields of the testsuite
+// specification.
+func Marshal(spec *api.TestSuiteSpec) ([]byte, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal testsuite: %w", err)
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to marshal testsuite: %w", err)
+	}
+
+	out, err := yaml.Marshal(pruneEmpty(obj))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal testsuite: %w", err)
+	}
+
+	return append([]byte(SchemaHeader), out...), nil
+}
+
+// pruneEmpty removes the empty objects of a value recursively, e.g. the hooks of a testsuite without hooks.
+func pruneEmpty(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			value = pruneEmpty(value)
+			if m, ok := value.(map[string]any); ok && len(m) == 0 {
+				delete(v, key)
+				continue
+			}
+
+			v[key] = value
+		}
+
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = pruneEmpty(item)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// apiGroup returns the group of an API version.
+func apiGroup(apiVersion string) string {
+	group, _, _ := strings.Cut(apiVersion, "/")
+	return group
+}
+
+// appendUnique appends a value to a list unless it is in the list already.
+func appendUnique(list []string, value string) []string {
+	if slices.Contains(list, value) {
+		return list
+	}
+
+	return append(list, value)
+}
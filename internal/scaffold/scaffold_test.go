@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	testXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.org
+spec:
+  group: example.org
+  names:
+    kind: XBucket
+    plural: xbuckets
+  claimNames:
+    kind: Bucket
+    plural: buckets
+  versions:
+    - name: v1
+      served: true
+      referenceable: true
+`
+	testComposition = `apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: xbuckets
+spec:
+  compositeTypeRef:
+    apiVersion: example.org/v1
+    kind: XBucket
+  mode: Pipeline
+  pipeline: []
+`
+	testFunctions = `apiVersion: pkg.crossplane.io/v1beta1
+kind: Function
+metadata:
+  name: function-patch-and-transform
+---
+apiVersion: pkg.crossplane.io/v1beta1
+kind: Function
+metadata:
+  name: function-auto-ready
+`
+)
+
+// newTestProject writes a directory with a composition, an XRD, a CRD, functions, an XR and a claim.
+func newTestProject(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"/project/apis/xrd.yaml":              testXRD,
+		"/project/apis/composition.yaml":      testComposition,
+		"/project/functions.yaml":             testFunctions,
+		"/project/crds/bucket.yaml":           "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: buckets.s3.aws.upbound.io\n",
+		"/project/examples/xr.yaml":           "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\n---\n",
+		"/project/examples/claim.yaml":        "apiVersion: example.org/v1\nkind: Bucket\nmetadata:\n  name: bucket\n",
+		"/project/examples/other.yaml":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n",
+		"/project/.git/xr.yaml":               "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: hidden\n",
+		"/project/xprin.yaml.example":         "tests: []\n",
+		"/project/not-a-manifest.yaml":        "- a\n- b\n",
+		"/project/examples/two-buckets.yaml":  "apiVersion: example.org/v1\nkind: XBucket\n---\napiVersion: example.org/v1\nkind: XBucket\n",
+		"/project/examples/nested/named.yaml": "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: named\n",
+	}
+
+	for path, content := range files {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o600))
+	}
+
+	return fs
+}
+
+func TestDetect(t *testing.T) {
+	p, err := Detect(newTestProject(t), "/project")
+	require.NoError(t, err)
+
+	assert.Equal(t, []Composition{{Path: "apis/composition.yaml", Name: "xbuckets", APIVersion: "example.org/v1", Kind: "XBucket"}}, p.Compositions)
+	assert.Equal(t, []string{"apis/xrd.yaml"}, p.XRDs)
+	assert.Equal(t, []string{"crds/bucket.yaml"}, p.CRDs)
+	assert.Equal(t, []string{"functions.yaml"}, p.Functions)
+	assert.Equal(t, []Example{
+		{Path: "examples/claim.yaml", Name: "bucket", APIVersion: "example.org/v1", Kind: "XBucket", Claim: true},
+		{Path: "examples/nested/named.yaml", Name: "named", APIVersion: "example.org/v1", Kind: "XBucket"},
+		{Path: "examples/xr.yaml", Name: "bucket", APIVersion: "example.org/v1", Kind: "XBucket"},
+	}, p.Examples)
+}
+
+func TestProjectTestSuite(t *testing.T) {
+	p, err := Detect(newTestProject(t), "/project")
+	require.NoError(t, err)
+
+	t.Run("common inputs and a test case per example", func(t *testing.T) {
+		spec, err := p.TestSuite(Options{})
+		require.NoError(t, err)
+
+		assert.Equal(t, api.Inputs{
+			Composition: "apis/composition.yaml",
+			Functions:   "functions.yaml",
+			CRDs:        []string{"apis/xrd.yaml", "crds/bucket.yaml"},
+		}, spec.Common.Inputs)
+		assert.Equal(t, []api.TestCase{
+			{Name: "bucket", Inputs: api.Inputs{Claim: "examples/claim.yaml"}},
+			{Name: "named", Inputs: api.Inputs{XR: "examples/nested/named.yaml"}},
+			{Name: "bucket-2", Inputs: api.Inputs{XR: "examples/xr.yaml"}},
+		}, spec.Tests)
+	})
+
+	t.Run("paths relative to the testsuite file", func(t *testing.T) {
+		spec, err := p.TestSuite(Options{Base: "/project/tests"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "../apis/composition.yaml", spec.Common.Inputs.Composition)
+		assert.Equal(t, "../examples/claim.yaml", spec.Tests[0].Inputs.Claim)
+	})
+
+	t.Run("selected functions", func(t *testing.T) {
+		spec, err := p.TestSuite(Options{Functions: "functions"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "functions", spec.Common.Inputs.Functions)
+	})
+
+	t.Run("unknown composition", func(t *testing.T) {
+		_, err := p.TestSuite(Options{Composition: "missing.yaml"})
+		require.ErrorContains(t, err, "composition missing.yaml not found in /project")
+	})
+
+	t.Run("no compositions", func(t *testing.T) {
+		_, err := (&Project{Dir: "/empty"}).TestSuite(Options{})
+		require.ErrorContains(t, err, "no compositions found in /empty")
+	})
+
+	t.Run("no examples", func(t *testing.T) {
+		empty := &Project{Dir: "/project", Compositions: p.Compositions, Functions: p.Functions}
+
+		_, err := empty.TestSuite(Options{})
+		require.ErrorContains(t, err, "no example XRs or claims found in /project")
+	})
+}
+
+func TestStarterAssertions(t *testing.T) {
+	resource := func(kind, name string) *unstructured.Unstructured {
+		r := &unstructured.Unstructured{}
+		r.SetKind(kind)
+		r.SetName(name)
+
+		return r
+	}
+
+	assertions := StarterAssertions([]*unstructured.Unstructured{
+		resource("XBucket", "bucket"),
+		resource("Bucket", "bucket-data"),
+		resource("BucketPolicy", "bucket-data"),
+		resource("Object", ""),
+	})
+
+	assert.Equal(t, []api.AssertionXprin{
+		{Name: "renders 4 resources", Type: "Count", Value: 4},
+		{Name: "Bucket/bucket-data exists", Type: "Exists", Resource: "Bucket/bucket-data"},
+		{Name: "BucketPolicy/bucket-data exists", Type: "Exists", Resource: "BucketPolicy/bucket-data"},
+	}, assertions.Xprin)
+}
+
+func TestMarshal(t *testing.T) {
+	p, err := Detect(newTestProject(t), "/project")
+	require.NoError(t, err)
+
+	spec, err := p.TestSuite(Options{})
+	require.NoError(t, err)
+
+	spec.Tests[0].Assertions = api.Assertions{Xprin: []api.AssertionXprin{{Name: "renders 2 resources", Type: "Count", Value: 2}}}
+
+	data, err := Marshal(spec)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(data), SchemaHeader))
+	assert.NotContains(t, string(data), "{}", "empty fields are left out")
+
+	// The testsuite only has fields of the testsuite specification
+	decoded := &api.TestSuiteSpec{}
+	require.NoError(t, yaml.UnmarshalStrict(data, decoded))
+	require.NoError(t, decoded.CheckValidTestSuiteFile())
+
+	for _, testCase := range decoded.Tests {
+		testCase.MergeCommon(decoded.Common)
+		require.NoError(t, testCase.CheckMandatoryFields())
+	}
+
+	assert.Equal(t, float64(2), decoded.Tests[0].Assertions.Xprin[0].Value)
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "composition.yaml"), testComposition)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "functions.yaml"), testFunctions)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "xr.yaml"), "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\n")
+
+	spec := &api.TestSuiteSpec{
+		Common: api.Common{Inputs: api.Inputs{Composition: "composition.yaml", Functions: "functions.yaml"}},
+		Tests:  []api.TestCase{{Name: "bucket", Inputs: api.Inputs{XR: "xr.yaml"}}},
+	}
+
+	// Without a crossplane dependency, the test case fails to render
+	rendered, err := Render(&testexecutionUtils.Options{}, filepath.Join(dir, "xprin.yaml"), spec, io.Discard)
+	require.NoError(t, err)
+	assert.Empty(t, rendered)
+
+	assert.Equal(t, "composition.yaml", spec.Common.Inputs.Composition, "the testsuite is not modified")
+}
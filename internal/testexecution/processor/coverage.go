@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/crossplane-contrib/xprin/internal/coverage"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+)
+
+// withCoverage returns a copy of options that collects the coverage of a new run, or options itself when coverage
+// is not requested or no test case runs.
+func withCoverage(options *testexecutionUtils.Options) *testexecutionUtils.Options {
+	if !options.Coverage || options.List || options.DryRun {
+		return options
+	}
+
+	coverageOptions := *options
+	coverageOptions.CoverageReport = coverage.NewReport()
+
+	return &coverageOptions
+}
+
+// writeCoverage prints the text summary of the coverage of the run and writes its machine-readable report to
+// options.CoverageFile.
+func writeCoverage(fs afero.Fs, options *testexecutionUtils.Options) error {
+	summary := options.CoverageReport.Summary()
+
+	summary.WriteText(os.Stdout, options.Verbose)
+
+	if options.CoverageFile == "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := summary.WriteJSON(&buf); err != nil {
+		return fmt.Errorf("failed to encode coverage report: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, options.CoverageFile, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write coverage report: %w", err)
+	}
+
+	return nil
+}
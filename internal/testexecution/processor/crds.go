@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+)
+
+// CRDsPackages returns the packages referenced by the crds-from inputs of the test cases of the testsuite files found
+// in the targets, in order and without duplicates. Unlike a test run, it fails on the first testsuite file that
+// cannot be loaded or resolved, so that no package is silently left out of the CRDs cache.
+func CRDsPackages(fs afero.Fs, targets []string, options *testexecutionUtils.Options) ([]string, error) {
+	d := newDiscovery(fs, options)
+
+	var packages []string
+
+	for _, testSuiteFile := range d.testSuiteFiles(targets) {
+		testSuiteSpec, err := load(d.fs, testSuiteFile)
+		if err != nil {
+			return nil, err
+		}
+
+		testSuitePackages, err := runner.NewRunner(options, testSuiteFile, testSuiteSpec).CRDsPackages()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", testSuiteFile, err)
+		}
+
+		for _, pkg := range testSuitePackages {
+			if !slices.Contains(packages, pkg) {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+
+	return packages, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"path/filepath"
+	"testing"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCRDsPackages(t *testing.T) {
+	const (
+		provider = "xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"
+		function = "xpkg.crossplane.io/crossplane-contrib/function-go-templating:v0.11.0"
+	)
+
+	dir := t.TempDir()
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), `common:
+  inputs:
+    composition: composition.yaml
+    functions: functions
+tests:
+  - name: aws
+    inputs:
+      xr: xr.yaml
+      crds-from:
+        - `+provider+`
+        - `+function+`
+`)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "gcp", "gcp_xprin.yaml"), `tests:
+  - name: gcp
+    inputs:
+      xr: xr.yaml
+      composition: composition.yaml
+      functions: functions
+      crds-from: [`+provider+`]
+  - name: no-crds
+    inputs:
+      xr: xr.yaml
+      composition: composition.yaml
+      functions: functions
+`)
+
+	t.Run("packages of every testsuite without duplicates", func(t *testing.T) {
+		packages, err := CRDsPackages(afero.NewOsFs(), []string{dir + "/..."}, &testexecutionUtils.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{provider, function}, packages)
+	})
+
+	t.Run("excluded testsuites", func(t *testing.T) {
+		packages, err := CRDsPackages(afero.NewOsFs(), []string{dir + "/..."}, &testexecutionUtils.Options{Exclude: []string{"aws_xprin.yaml"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{provider}, packages)
+	})
+
+	t.Run("invalid testsuite files fail", func(t *testing.T) {
+		invalid := t.TempDir()
+		unittestsUtils.WriteTestFile(t, filepath.Join(invalid, "xprin.yaml"), "tests: []\n")
+
+		_, err := CRDsPackages(afero.NewOsFs(), []string{invalid}, &testexecutionUtils.Options{})
+		require.ErrorContains(t, err, "no test cases found")
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// testSuiteRuns runs the testsuite files of a run, each at most once and after the testsuites it depends on.
+type testSuiteRuns struct {
+	fs        afero.Fs
+	options   *testexecutionUtils.Options
+	discovery *discovery
+	done      map[string]*testSuiteRun // Testsuites that ran, by resolved path
+	running   []string                 // Resolved paths of the testsuites being run, each depending on the next one
+	files     map[string]string        // Testsuite files as they are reported, by resolved path
+	runners   []runnerInterface        // Runners whose testsuite artifacts are removed when the run finishes
+}
+
+// testSuiteRun is the outcome of running a testsuite file.
+type testSuiteRun struct {
+	name   string                  // Name of the testsuite, which dependent testsuites reference its results by
+	result *engine.TestSuiteResult // Results of its test cases (nil when no test case ran)
+	err    error
+}
+
+func newTestSuiteRuns(fs afero.Fs, options *testexecutionUtils.Options) *testSuiteRuns {
+	return &testSuiteRuns{
+		fs:        fs,
+		options:   options,
+		discovery: newDiscovery(fs, options),
+		done:      make(map[string]*testSuiteRun),
+		files:     make(map[string]string),
+	}
+}
+
+// runDependencies runs the testsuites in depends-on that did not run yet and returns the results of the named ones,
+// by name. It fails when a dependency failed or depends, directly or not, on the testsuite itself.
+func (s *testSuiteRuns) runDependencies(testSuiteFile string, testSuiteSpec *api.TestSuiteSpec) (map[string]*engine.TestSuiteResult, error) {
+	suites := make(map[string]*engine.TestSuiteResult)
+
+	for _, dependency := range testSuiteSpec.DependsOn {
+		path, err := dependencyPath(testSuiteFile, dependency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand depends-on path %s: %w", dependency, err)
+		}
+
+		key := resolvePath(path)
+
+		if i := slices.Index(s.running, key); i >= 0 {
+			cycle := make([]string, 0, len(s.running)-i+1)
+			for _, running := range slices.Concat(s.running[i:], []string{key}) {
+				cycle = append(cycle, s.files[running])
+			}
+
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		if s.options.Debug {
+			utils.DebugPrintf("Running dependency %s of testsuite file %s\n", path, testSuiteFile)
+		}
+
+		if err := s.processTestSuiteFile(path); err != nil {
+			return nil, fmt.Errorf("dependency %s failed", path)
+		}
+
+		run := s.done[key]
+		if run.name == "" {
+			continue
+		}
+
+		if _, ok := suites[run.name]; ok {
+			return nil, fmt.Errorf("depends-on has several testsuites named '%s'", run.name)
+		}
+
+		suites[run.name] = run.result
+	}
+
+	return suites, nil
+}
+
+// dependencyPath returns the path of a depends-on entry: relative paths are relative to the testsuite file.
+func dependencyPath(testSuiteFile, dependency string) (string, error) {
+	if filepath.IsAbs(dependency) || strings.HasPrefix(dependency, "~") {
+		return testexecutionUtils.ExpandPathRelativeToTestSuiteFile(testSuiteFile, dependency)
+	}
+
+	return filepath.Join(filepath.Dir(testSuiteFile), dependency), nil
+}
+
+// cleanup removes the testsuite artifacts that named testsuites keep for the testsuites depending on them.
+func (s *testSuiteRuns) cleanup() {
+	for _, runner := range s.runners {
+		runner.Cleanup()
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// mockDependencyRunners replaces newRunnerFunc with mock runners that record the order testsuites run in and fail
+// the testsuites in failing. It returns the recorded testsuite files and the created runners.
+func mockDependencyRunners(t *testing.T, failing ...string) (*[]string, map[string]*mockRunner) {
+	t.Helper()
+
+	originalNewRunnerFunc := newRunnerFunc
+
+	t.Cleanup(func() {
+		newRunnerFunc = originalNewRunnerFunc
+	})
+
+	var order []string
+
+	runners := make(map[string]*mockRunner)
+
+	newRunnerFunc = func(options *testexecutionUtils.Options, testSuiteFile string, _ *api.TestSuiteSpec) runnerInterface {
+		runner := &mockRunner{options: options, result: engine.NewTestSuiteResult(testSuiteFile, false)}
+		runner.runTestsFunc = func() error {
+			order = append(order, testSuiteFile)
+
+			for _, file := range failing {
+				if file == testSuiteFile {
+					return errors.New("tests failed in testsuite " + testSuiteFile)
+				}
+			}
+
+			return nil
+		}
+		runners[testSuiteFile] = runner
+
+		return runner
+	}
+
+	return &order, runners
+}
+
+func TestProcessTargets_DependsOn(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/tests/network/network_xprin.yaml", []byte("name: network\n"+testContentWithTests), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tests/base_xprin.yaml", []byte(testContentWithTests), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tests/app_xprin.yaml", []byte(`depends-on:
+  - network/network_xprin.yaml
+  - base_xprin.yaml
+`+testContentWithTests), 0o644))
+
+	t.Run("dependencies run first and once", func(t *testing.T) {
+		order, runners := mockDependencyRunners(t)
+
+		var err error
+
+		unittestsUtils.CaptureOutput(func() {
+			err = ProcessTargets(fs, []string{"/tests/..."}, &testexecutionUtils.Options{})
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/tests/network/network_xprin.yaml", "/tests/base_xprin.yaml", "/tests/app_xprin.yaml"}, *order)
+
+		app := runners["/tests/app_xprin.yaml"]
+		assert.Equal(t, map[string]*engine.TestSuiteResult{"network": runners["/tests/network/network_xprin.yaml"].result}, app.options.Suites)
+		assert.Nil(t, runners["/tests/base_xprin.yaml"].options.Suites)
+
+		for file, runner := range runners {
+			assert.True(t, runner.cleaned, "artifacts of %s are removed when the run finishes", file)
+		}
+	})
+
+	t.Run("failed dependency", func(t *testing.T) {
+		order, _ := mockDependencyRunners(t, "/tests/network/network_xprin.yaml")
+
+		var err error
+
+		output := unittestsUtils.CaptureStderr(func() {
+			err = ProcessTargets(fs, []string{"/tests/app_xprin.yaml"}, &testexecutionUtils.Options{})
+		})
+		require.Error(t, err)
+
+		assert.Equal(t, []string{"/tests/network/network_xprin.yaml"}, *order)
+		assert.Contains(t, output, "dependency /tests/network/network_xprin.yaml failed")
+		assert.Contains(t, output, "FAIL\t/tests/app_xprin.yaml\t[dependency error]")
+	})
+}
+
+func TestProcessTargets_DependencyCycle(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/tests/a_xprin.yaml", []byte("depends-on: [b_xprin.yaml]\n"+testContentWithTests), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tests/b_xprin.yaml", []byte("depends-on: [a_xprin.yaml]\n"+testContentWithTests), 0o644))
+
+	order, _ := mockDependencyRunners(t)
+
+	var err error
+
+	output := unittestsUtils.CaptureStderr(func() {
+		err = ProcessTargets(fs, []string{"/tests"}, &testexecutionUtils.Options{})
+	})
+	require.Error(t, err)
+
+	assert.Empty(t, *order)
+	assert.Contains(t, output, "dependency cycle: /tests/a_xprin.yaml -> /tests/b_xprin.yaml -> /tests/a_xprin.yaml")
+	assert.Contains(t, output, "FAIL\t/tests/a_xprin.yaml\t[dependency error]")
+	assert.Contains(t, output, "FAIL\t/tests/b_xprin.yaml\t[dependency error]")
+}
+
+func TestRunDependencies_DuplicateNames(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/tests/a_xprin.yaml", []byte("name: shared\n"+testContentWithTests), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tests/b_xprin.yaml", []byte("name: shared\n"+testContentWithTests), 0o644))
+
+	mockDependencyRunners(t)
+
+	runs := newTestSuiteRuns(fs, &testexecutionUtils.Options{})
+
+	_, err := runs.runDependencies("/tests/app_xprin.yaml", &api.TestSuiteSpec{DependsOn: []string{"a_xprin.yaml", "b_xprin.yaml"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "depends-on has several testsuites named 'shared'")
+}
@@ -18,27 +18,76 @@ limitations under the License.
 package processor
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/spf13/afero"
 )
 
-// recursiveDirs recursively collects all directories under a root.
-func recursiveDirs(fs afero.Fs, root string) ([]string, error) {
+// IgnoreFileName is the name of the files listing, in gitignore syntax, the paths that discovery skips.
+// Its patterns apply to the directory containing it and every directory below it.
+const IgnoreFileName = ".xprinignore"
+
+// DefaultInclude are the file name patterns of testsuite files when none are configured.
+//
+//nolint:gochecknoglobals // Read-only defaults
+var DefaultInclude = []string{"xprin.yaml", "?*_xprin.yaml", "xprin.yml", "?*_xprin.yml"}
+
+// DefaultExclude are the patterns of paths that discovery always skips, in addition to the configured ones.
+//
+//nolint:gochecknoglobals // Read-only defaults
+var DefaultExclude = []string{".git/", "node_modules/", "vendor/"}
+
+// discovery finds testsuite files: files whose name matches one of the include patterns and whose path is not
+// excluded by the exclude patterns or the .xprinignore files. Exclude patterns and .xprinignore files use gitignore
+// syntax and are relative to the root of the git repository containing the path, or of the working directory.
+type discovery struct {
+	fs      afero.Fs
+	include []string
+	exclude []string
+	ignores map[string][]gitignore.Pattern // patterns of the .xprinignore file of each directory read so far
+}
+
+// newDiscovery returns a discovery using the include and exclude patterns of options.
+func newDiscovery(fs afero.Fs, options *testexecutionUtils.Options) *discovery {
+	include := options.Include
+	if len(include) == 0 {
+		include = DefaultInclude
+	}
+
+	return &discovery{
+		fs:      fs,
+		include: include,
+		exclude: append(append([]string{}, DefaultExclude...), options.Exclude...),
+		ignores: make(map[string][]gitignore.Pattern),
+	}
+}
+
+// recursiveDirs recursively collects all directories under a root, skipping excluded directories.
+func (d *discovery) recursiveDirs(root string) ([]string, error) {
 	var dirs []string
 
-	err := afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+	err := afero.Walk(d.fs, root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() {
-			dirs = append(dirs, path)
+		if !info.IsDir() {
+			return nil
+		}
+
+		if path != root && d.isExcluded(path, true) {
+			return filepath.SkipDir
 		}
 
+		dirs = append(dirs, path)
+
 		return nil
 	})
 
@@ -46,8 +95,8 @@ func recursiveDirs(fs afero.Fs, root string) ([]string, error) {
 }
 
 // findTestSuiteFiles finds all test files matching the given pattern.
-func findTestSuiteFiles(fs afero.Fs, pattern string) ([]string, error) {
-	matches, err := afero.Glob(fs, pattern)
+func (d *discovery) findTestSuiteFiles(pattern string) ([]string, error) {
+	matches, err := afero.Glob(d.fs, pattern)
 	if err != nil {
 		return nil, fmt.Errorf("failed to match pattern %s: %w", pattern, err)
 	}
@@ -55,14 +104,14 @@ func findTestSuiteFiles(fs afero.Fs, pattern string) ([]string, error) {
 	var files []string
 
 	for _, match := range matches {
-		info, err := fs.Stat(match)
+		info, err := d.fs.Stat(match)
 		if err != nil {
 			return nil, fmt.Errorf("failed to stat file %s: %w", match, err)
 		}
 
 		if !info.IsDir() {
-			// For files, only include if they are named "xprin.yaml" or end with "_xprin.yaml" with at least one character before the underscore
-			if !isValidTestSuiteFileName(match) {
+			// For files, only include if they match the include patterns
+			if !d.isValidTestSuiteFileName(match) {
 				continue
 			}
 
@@ -71,15 +120,15 @@ func findTestSuiteFiles(fs afero.Fs, pattern string) ([]string, error) {
 			continue
 		}
 
-		// Look for all yaml files and then filter by our validation function
-		allMatches, err := afero.Glob(fs, filepath.Join(match, "*.yaml"))
+		entries, err := afero.ReadDir(d.fs, match)
 		if err != nil {
-			return nil, fmt.Errorf("failed to match pattern in directory %s: %w", match, err)
+			return nil, fmt.Errorf("failed to read directory %s: %w", match, err)
 		}
 
-		// Filter results using isValidTestSuiteFileName
-		for _, fileMatch := range allMatches {
-			if isValidTestSuiteFileName(fileMatch) {
+		// Filter the files of the directory by the include and exclude patterns
+		for _, entry := range entries {
+			fileMatch := filepath.Join(match, entry.Name())
+			if !entry.IsDir() && d.isValidTestSuiteFileName(fileMatch) && !d.isExcluded(fileMatch, false) {
 				files = append(files, fileMatch)
 			}
 		}
@@ -92,11 +141,106 @@ func findTestSuiteFiles(fs afero.Fs, pattern string) ([]string, error) {
 	return files, nil
 }
 
-// isValidTestSuiteFileName checks if a filename matches the patterns for valid test suite files.
-// Valid patterns are:
-// - Exactly "xprin.yaml"
-// - Ending with "_xprin.yaml" with at least one character before the underscore.
-func isValidTestSuiteFileName(filename string) bool {
+// isValidTestSuiteFileName checks if a filename matches one of the include patterns.
+// The default patterns are:
+// - Exactly "xprin.yaml" or "xprin.yml"
+// - Ending with "_xprin.yaml" or "_xprin.yml" with at least one character before the underscore.
+func (d *discovery) isValidTestSuiteFileName(filename string) bool {
 	base := filepath.Base(filename)
-	return base == "xprin.yaml" || (strings.HasSuffix(base, "_xprin.yaml") && len(base) > len("_xprin.yaml"))
+
+	for _, pattern := range d.include {
+		if matched, err := filepath.Match(pattern, base); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isExcluded reports whether path matches the exclude patterns or the patterns of the .xprinignore files
+// of the directories from the ignore root down to the directory containing path.
+func (d *discovery) isExcluded(path string, isDir bool) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	root := d.ignoreRoot(filepath.Dir(abs))
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	components := strings.Split(filepath.ToSlash(rel), "/")
+
+	patterns := make([]gitignore.Pattern, 0, len(d.exclude))
+	for _, exclude := range d.exclude {
+		patterns = append(patterns, gitignore.ParsePattern(exclude, nil))
+	}
+
+	for i := range components {
+		patterns = append(patterns, d.ignoreFilePatterns(filepath.Join(root, filepath.Join(components[:i]...)), components[:i])...)
+	}
+
+	return gitignore.NewMatcher(patterns).Match(components, isDir)
+}
+
+// ignoreRoot returns the directory that exclude patterns are relative to: the closest directory containing .git,
+// or the working directory when dir is below it, or the filesystem root.
+func (d *discovery) ignoreRoot(dir string) string {
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := d.fs.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+
+		if filepath.Dir(current) == current {
+			break
+		}
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			return wd
+		}
+	}
+
+	return filepath.VolumeName(dir) + string(filepath.Separator)
+}
+
+// ignoreFilePatterns returns the patterns of the .xprinignore file in dir, whose path relative to the ignore root
+// is domain. A missing or unreadable file has no patterns.
+func (d *discovery) ignoreFilePatterns(dir string, domain []string) []gitignore.Pattern {
+	if patterns, ok := d.ignores[dir]; ok {
+		return patterns
+	}
+
+	var patterns []gitignore.Pattern
+
+	if data, err := afero.ReadFile(d.fs, filepath.Join(dir, IgnoreFileName)); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			patterns = append(patterns, gitignore.ParsePattern(line, domain))
+		}
+	}
+
+	d.ignores[dir] = patterns
+
+	return patterns
+}
+
+// CheckIncludePatterns checks that every include pattern is a valid file name pattern.
+func CheckIncludePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil || strings.ContainsRune(pattern, filepath.Separator) {
+			return fmt.Errorf("invalid include pattern %q: must be a file name pattern (e.g. '*_xprin.yaml')", pattern)
+		}
+	}
+
+	return nil
 }
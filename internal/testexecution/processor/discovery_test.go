@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
 	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
@@ -34,7 +35,7 @@ func TestRecursiveDirs(t *testing.T) {
 	require.NoError(t, fs.MkdirAll(sub1, 0o755))
 	require.NoError(t, fs.MkdirAll(sub2, 0o755))
 
-	dirs, err := recursiveDirs(fs, root)
+	dirs, err := newDiscovery(fs, &testexecutionUtils.Options{}).recursiveDirs(root)
 	require.NoError(t, err)
 	assert.Contains(t, dirs, root)
 	assert.Contains(t, dirs, sub1)
@@ -44,7 +45,7 @@ func TestRecursiveDirs(t *testing.T) {
 func TestRecursiveDirs_Error(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	// Test with a non-existent root to simulate an error
-	_, err := recursiveDirs(fs, "/nonexistent")
+	_, err := newDiscovery(fs, &testexecutionUtils.Options{}).recursiveDirs("/nonexistent")
 	require.Error(t, err)
 }
 
@@ -71,9 +72,11 @@ func TestFindTestSuiteFiles(t *testing.T) {
 		{"suffix match with different prefix", "whatever_xprin.yaml", true, "valid suffix pattern with different prefix"},
 		{"suffix match containing 'not'", "test_not_xprin.yaml", true, "valid suffix pattern containing 'not'"},
 		{"long name with suffix", "really_long_name_xprin.yaml", true, "valid suffix pattern with long name"},
+		{"yml extension", "xprin.yml", true, "exact pattern match with .yml extension"},
+		{"suffix match with yml extension", "test_xprin.yml", true, "valid suffix pattern with .yml extension"},
 
 		// Invalid file patterns
-		{"wrong extension", "xprin.yml", false, "wrong file extension (.yml instead of .yaml)"},
+		{"wrong extension", "xprin.json", false, "wrong file extension (.json instead of .yaml)"},
 		{"extra character after extension", "xprin.yaml1", false, "extra character after .yaml extension"},
 		{"missing underscore", "whateverxprin.yaml", false, "missing underscore before xprin"},
 		{"underscore with empty prefix", "_xprin.yaml", false, "underscore but nothing before it"},
@@ -93,7 +96,7 @@ func TestFindTestSuiteFiles(t *testing.T) {
 
 	// Test file pattern matching in main directory
 	t.Run("file pattern validation", func(t *testing.T) {
-		files, err := findTestSuiteFiles(fs, tempDir)
+		files, err := newDiscovery(fs, &testexecutionUtils.Options{}).findTestSuiteFiles(tempDir)
 		require.NoError(t, err)
 
 		// Convert to base filenames for easier assertions
@@ -127,14 +130,14 @@ func TestFindTestSuiteFiles(t *testing.T) {
 
 	// Test finding files in specific subdirectory
 	t.Run("subdirectory search", func(t *testing.T) {
-		files, err := findTestSuiteFiles(fs, subDir1)
+		files, err := newDiscovery(fs, &testexecutionUtils.Options{}).findTestSuiteFiles(subDir1)
 		require.NoError(t, err)
 		assert.Len(t, files, 2, "Should find 2 valid files in subDir1")
 	})
 
 	// Test finding specific file
 	t.Run("specific file search", func(t *testing.T) {
-		files, err := findTestSuiteFiles(fs, tempDir+"/test1_xprin.yaml")
+		files, err := newDiscovery(fs, &testexecutionUtils.Options{}).findTestSuiteFiles(tempDir + "/test1_xprin.yaml")
 		require.NoError(t, err)
 		assert.Len(t, files, 1, "Should find 1 file when specifying exact file path")
 		assert.Contains(t, files[0], "test1_xprin.yaml")
@@ -142,7 +145,7 @@ func TestFindTestSuiteFiles(t *testing.T) {
 
 	// Test non-existent pattern
 	t.Run("non-existent pattern", func(t *testing.T) {
-		_, err := findTestSuiteFiles(fs, tempDir+"/nonexistent")
+		_, err := newDiscovery(fs, &testexecutionUtils.Options{}).findTestSuiteFiles(tempDir + "/nonexistent")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "no test files found")
 	})
@@ -150,7 +153,7 @@ func TestFindTestSuiteFiles(t *testing.T) {
 	// Test invalid pattern syntax
 	t.Run("invalid pattern", func(t *testing.T) {
 		// afero.Glob returns an error for patterns with syntax errors
-		_, err := findTestSuiteFiles(fs, "[]") // Invalid pattern for afero.Glob
+		_, err := newDiscovery(fs, &testexecutionUtils.Options{}).findTestSuiteFiles("[]") // Invalid pattern for afero.Glob
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to match pattern")
 	})
@@ -193,8 +196,23 @@ func TestIsValidTestSuiteFileName(t *testing.T) {
 			want:     false,
 		},
 		{
-			name:     "invalid - wrong extension",
+			name:     "yml extension",
 			filename: "xprin.yml",
+			want:     true,
+		},
+		{
+			name:     "valid prefix with yml extension",
+			filename: "test_xprin.yml",
+			want:     true,
+		},
+		{
+			name:     "invalid - empty prefix with yml extension",
+			filename: "_xprin.yml",
+			want:     false,
+		},
+		{
+			name:     "invalid - wrong extension",
+			filename: "xprin.json",
 			want:     false,
 		},
 		{
@@ -226,8 +244,84 @@ func TestIsValidTestSuiteFileName(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			got := isValidTestSuiteFileName(tc.filename)
+			got := newDiscovery(afero.NewMemMapFs(), &testexecutionUtils.Options{}).isValidTestSuiteFileName(tc.filename)
 			assert.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestDiscovery_IncludeExclude(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	for _, dir := range []string{"/repo/.git", "/repo/tests/aws", "/repo/tests/node_modules/pkg", "/repo/tests/wip", "/repo/tests/legacy"} {
+		require.NoError(t, fs.MkdirAll(dir, 0o755))
+	}
+
+	for _, file := range []string{
+		"/repo/tests/aws/aws_xprin.yaml",
+		"/repo/tests/aws/aws.test.yaml",
+		"/repo/tests/aws/draft_xprin.yaml",
+		"/repo/tests/node_modules/pkg/xprin.yaml",
+		"/repo/tests/wip/wip_xprin.yaml",
+		"/repo/tests/legacy/legacy_xprin.yml",
+	} {
+		require.NoError(t, afero.WriteFile(fs, file, []byte("content"), 0o644))
+	}
+
+	require.NoError(t, afero.WriteFile(fs, "/repo/tests/"+IgnoreFileName, []byte("# work in progress\nwip/\ndraft_*\n"), 0o644))
+
+	findAll := func(d *discovery) []string {
+		dirs, err := d.recursiveDirs("/repo/tests")
+		require.NoError(t, err)
+
+		var files []string
+
+		for _, dir := range dirs {
+			found, err := d.findTestSuiteFiles(dir)
+			if err == nil {
+				files = append(files, found...)
+			}
+		}
+
+		return files
+	}
+
+	t.Run("default patterns and .xprinignore", func(t *testing.T) {
+		d := newDiscovery(fs, &testexecutionUtils.Options{})
+		assert.Equal(t, []string{"/repo/tests/aws/aws_xprin.yaml", "/repo/tests/legacy/legacy_xprin.yml"}, findAll(d))
+	})
+
+	t.Run("include patterns replace the defaults", func(t *testing.T) {
+		d := newDiscovery(fs, &testexecutionUtils.Options{Include: []string{"*.test.yaml"}})
+		assert.Equal(t, []string{"/repo/tests/aws/aws.test.yaml"}, findAll(d))
+	})
+
+	t.Run("exclude patterns are relative to the git root", func(t *testing.T) {
+		d := newDiscovery(fs, &testexecutionUtils.Options{Exclude: []string{"/tests/legacy"}})
+		assert.Equal(t, []string{"/repo/tests/aws/aws_xprin.yaml"}, findAll(d))
+	})
+
+	t.Run("negated patterns re-include paths", func(t *testing.T) {
+		d := newDiscovery(fs, &testexecutionUtils.Options{Exclude: []string{"!node_modules/"}})
+		assert.Contains(t, findAll(d), "/repo/tests/node_modules/pkg/xprin.yaml")
+	})
+
+	t.Run("excluded directories are not walked", func(t *testing.T) {
+		dirs, err := newDiscovery(fs, &testexecutionUtils.Options{}).recursiveDirs("/repo")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/repo", "/repo/tests", "/repo/tests/aws", "/repo/tests/legacy"}, dirs)
+	})
+}
+
+func TestCheckIncludePatterns(t *testing.T) {
+	require.NoError(t, CheckIncludePatterns(DefaultInclude))
+	require.NoError(t, CheckIncludePatterns([]string{"*.test.yaml", "[a-z]*_xprin.yaml"}))
+
+	err := CheckIncludePatterns([]string{"*_xprin.yaml", "[a-"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid include pattern "[a-"`)
+
+	err = CheckIncludePatterns([]string{"tests/*_xprin.yaml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a file name pattern")
+}
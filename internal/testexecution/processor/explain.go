@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"fmt"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// Explain prints the resolved specification of the test cases of a testsuite file, or only of its test case named test
+// or with the ID test, and the commands they would run, without running anything (see runner.Runner.Explain). The
+// testsuites it depends on do not run, so the template variables referencing their results cannot be rendered.
+func Explain(fs afero.Fs, testSuiteFile string, options *testexecutionUtils.Options, test string) error {
+	if options.Debug {
+		utils.DebugPrintf("Explaining testsuite file %s\n", testSuiteFile)
+	}
+
+	testSuiteSpec, err := load(fs, testSuiteFile)
+	if err != nil {
+		return reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")
+	}
+
+	if err := testSuiteSpec.CheckValidTestSuiteFile(); err != nil {
+		return reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")
+	}
+
+	if err := testSuiteSpec.CheckCrossplaneSelectors(options.CrossplaneDependencies()); err != nil {
+		return reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")
+	}
+
+	explained, err := runner.NewRunner(options, testSuiteFile, testSuiteSpec).Explain(test)
+	if err != nil {
+		return reportTestSuiteError(testSuiteFile, err, "invalid test cases")
+	}
+
+	if explained == 0 && test != "" {
+		return reportTestSuiteError(testSuiteFile, fmt.Errorf("no test case named or with the ID %s", test), "test case not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"path/filepath"
+	"testing"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestExplain(t *testing.T) {
+	dir := t.TempDir()
+	file := unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), `tests:
+  - name: aws
+    inputs:
+      xr: missing.yaml
+      composition: composition.yaml
+      functions: functions.yaml
+`)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "composition.yaml"), "")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "functions.yaml"), "")
+
+	t.Run("test cases that cannot run", func(t *testing.T) {
+		var err error
+
+		output := unittestsUtils.CaptureOutput(func() {
+			err = Explain(afero.NewOsFs(), file, &testexecutionUtils.Options{}, "")
+		})
+		require.Error(t, err)
+		assert.Contains(t, output.Stdout, "=== "+file+": aws\n")
+		assert.Contains(t, output.Stdout, "problems:\n  "+file+":4:11: XR file not found: ")
+		assert.Contains(t, output.Stderr, "1 test case cannot run as specified\nFAIL\t"+file+"\t[invalid test cases]\n")
+	})
+
+	t.Run("test case not found", func(t *testing.T) {
+		var err error
+
+		output := unittestsUtils.CaptureOutput(func() {
+			err = Explain(afero.NewOsFs(), file, &testexecutionUtils.Options{}, "gcp")
+		})
+		require.Error(t, err)
+		assert.Empty(t, output.Stdout)
+		assert.Contains(t, output.Stderr, "no test case named or with the ID gcp\nFAIL\t"+file+"\t[test case not found]\n")
+	})
+
+	t.Run("invalid testsuite file", func(t *testing.T) {
+		invalid := unittestsUtils.WriteTestFile(t, filepath.Join(dir, "invalid_xprin.yaml"), "tests:\n  - inputs: {}\n")
+
+		var err error
+
+		output := unittestsUtils.CaptureOutput(func() {
+			err = Explain(afero.NewOsFs(), invalid, &testexecutionUtils.Options{}, "")
+		})
+		require.Error(t, err)
+		assert.Contains(t, output.Stderr, "[invalid testsuite file]")
+	})
+}
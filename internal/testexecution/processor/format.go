@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/format"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/gertd/go-pluralize"
+	"github.com/spf13/afero"
+)
+
+// Format rewrites the testsuite files found in the targets in their canonical form (see format.Format), and prints the
+// files it changed. With check, the files are not rewritten: the files that are not formatted are printed, and an error
+// is returned when there is any, so that CI can fail on them.
+func Format(fs afero.Fs, targets []string, options *testexecutionUtils.Options, check bool) error {
+	d := newDiscovery(fs, options)
+
+	var files []string
+
+	for _, file := range d.testSuiteFiles(targets) {
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	var changed, failed int
+
+	for _, file := range files {
+		if options.Debug {
+			utils.DebugPrintf("Formatting testsuite file %s\n", file)
+		}
+
+		formatted, err := formatFile(fs, file, check)
+		if err != nil {
+			_ = reportError(file, "failed to format testsuite file", err)
+			failed++
+
+			continue
+		}
+
+		if !formatted {
+			continue
+		}
+
+		changed++
+
+		if check {
+			utils.OutputPrintf("%s is not formatted\n", file)
+		} else {
+			utils.OutputPrintf("Formatted %s\n", file)
+		}
+	}
+
+	plural := pluralize.NewClient()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to format %s", plural.Pluralize("testsuite file", failed, true))
+	}
+
+	if check && changed > 0 {
+		utils.OutputPrintf("FAIL\n")
+		return fmt.Errorf("%s not formatted (run xprin fmt to format them)", plural.Pluralize("testsuite file", changed, true))
+	}
+
+	return nil
+}
+
+// formatFile formats a testsuite file, and returns true if its canonical form differs from its content. With check,
+// the file is not rewritten.
+func formatFile(fs afero.Fs, file string, check bool) (bool, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read testsuite file %s: %w", file, err)
+	}
+
+	formatted, err := format.Format(data)
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(data, formatted) {
+		return false, nil
+	}
+
+	if check {
+		return true, nil
+	}
+
+	info, err := fs.Stat(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat testsuite file %s: %w", file, err)
+	}
+
+	if err := afero.WriteFile(fs, file, formatted, info.Mode()&os.ModePerm); err != nil {
+		return false, fmt.Errorf("failed to write testsuite file %s: %w", file, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestFormat(t *testing.T) {
+	const (
+		unformatted = "tests:\n- inputs:\n    xr: xr.yaml\n  name: a\n"
+		formatted   = "tests:\n  - name: a\n    inputs:\n      xr: xr.yaml\n"
+	)
+
+	newTestDir := func(t *testing.T) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "formatted_xprin.yaml"), formatted)
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "sub", "unformatted_xprin.yaml"), unformatted)
+
+		return dir
+	}
+
+	t.Run("check", func(t *testing.T) {
+		dir := newTestDir(t)
+		file := filepath.Join(dir, "sub", "unformatted_xprin.yaml")
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = Format(afero.NewOsFs(), []string{dir + "/..."}, &testexecutionUtils.Options{}, true)
+		})
+		require.EqualError(t, err, "1 testsuite file not formatted (run xprin fmt to format them)")
+		assert.Equal(t, file+" is not formatted\nFAIL\n", output)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, unformatted, string(data))
+	})
+
+	t.Run("rewrite", func(t *testing.T) {
+		dir := newTestDir(t)
+		file := filepath.Join(dir, "sub", "unformatted_xprin.yaml")
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = Format(afero.NewOsFs(), []string{dir + "/..."}, &testexecutionUtils.Options{}, false)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Formatted "+file+"\n", output)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, formatted, string(data))
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		dir := newTestDir(t)
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "invalid_xprin.yaml"), "tests: [\n")
+
+		var err error
+
+		stderr := unittestsUtils.CaptureStderr(func() {
+			err = Format(afero.NewOsFs(), []string{dir}, &testexecutionUtils.Options{}, true)
+		})
+		require.EqualError(t, err, "failed to format 1 testsuite file")
+		assert.Contains(t, stderr, "failed to format testsuite file in "+filepath.Join(dir, "invalid_xprin.yaml")+": failed to parse: yaml: line 1")
+	})
+}
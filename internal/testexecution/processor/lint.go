@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/lint"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/gertd/go-pluralize"
+	"github.com/spf13/afero"
+)
+
+// Lint statically checks the testsuite files found in the targets (see lint.Lint) and prints their problems as
+// file:line diagnostics, followed by a summary. With fix, the fixable problems are fixed in the files first. An error
+// is returned when any problem is an error; warnings alone do not fail.
+func Lint(fs afero.Fs, targets []string, options *testexecutionUtils.Options, fix bool) error {
+	d := newDiscovery(fs, options)
+
+	var files []string
+
+	for _, file := range d.testSuiteFiles(targets) {
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	var errs, warnings, fixable int
+
+	for _, file := range files {
+		if options.Debug {
+			utils.DebugPrintf("Linting testsuite file %s\n", file)
+		}
+
+		diagnostics, err := lintFile(fs, file, fix)
+		if err != nil {
+			_ = reportError(file, "failed to lint testsuite file", err)
+			errs++
+
+			continue
+		}
+
+		for _, diagnostic := range diagnostics {
+			utils.OutputPrintf("%s\n", diagnostic)
+
+			if diagnostic.Severity == lint.SeverityError {
+				errs++
+			} else {
+				warnings++
+			}
+
+			if diagnostic.Fixable() {
+				fixable++
+			}
+		}
+	}
+
+	plural := pluralize.NewClient()
+	summary := fmt.Sprintf("Linted %s: %s, %s", plural.Pluralize("testsuite file", len(files), true), plural.Pluralize("error", errs, true), plural.Pluralize("warning", warnings, true))
+
+	if fixable > 0 {
+		summary += fmt.Sprintf(" (%d fixable with --fix)", fixable)
+	}
+
+	utils.OutputPrintf("%s\n", summary)
+
+	if errs > 0 {
+		utils.OutputPrintf("FAIL\n")
+		return fmt.Errorf("lint found %s", plural.Pluralize("error", errs, true))
+	}
+
+	return nil
+}
+
+// lintFile returns the problems of a testsuite file. With fix, the fixable problems are fixed first, and the problems
+// left are returned.
+func lintFile(fs afero.Fs, file string, fix bool) ([]lint.Diagnostic, error) {
+	diagnostics, err := lint.Lint(fs, file)
+	if err != nil || !fix {
+		return diagnostics, err
+	}
+
+	fixed, err := lint.Fix(fs, file, diagnostics)
+	if err != nil || fixed == 0 {
+		return diagnostics, err
+	}
+
+	utils.OutputPrintf("Fixed %s in %s\n", pluralize.NewClient().Pluralize("problem", fixed, true), file)
+
+	diagnostics, err = lint.Lint(fs, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lint fixed testsuite file: %w", err)
+	}
+
+	return diagnostics, nil
+}
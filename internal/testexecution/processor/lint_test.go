@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"path/filepath"
+	"testing"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestLint(t *testing.T) {
+	newTestDir := func(t *testing.T) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "xr.yaml"), "{}\n")
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "composition.yaml"), "{}\n")
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "functions.yaml"), "{}\n")
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "valid_xprin.yaml"), "tests:\n  - name: a\n    inputs:\n      xr: xr.yaml\n      composition: composition.yaml\n      functions: functions.yaml\n")
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "sub", "warnings_xprin.yaml"), "common:\n  vars:\n    unused: x\ntests:\n  - name: a\n    inputs:\n      xr: ../xr.yaml\n      composition: ../composition.yaml\n      functions: ../functions.yaml\n")
+
+		return dir
+	}
+
+	t.Run("warnings only", func(t *testing.T) {
+		dir := newTestDir(t)
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = Lint(afero.NewOsFs(), []string{dir + "/..."}, &testexecutionUtils.Options{}, false)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "sub", "warnings_xprin.yaml")+":3: warning: common var 'unused' is not used (unused-common)\n"+
+			"Linted 2 testsuite files: 0 errors, 1 warning (1 fixable with --fix)\n", output)
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		dir := newTestDir(t)
+		file := filepath.Join(dir, "sub", "warnings_xprin.yaml")
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = Lint(afero.NewOsFs(), []string{file}, &testexecutionUtils.Options{}, true)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Fixed 1 problem in "+file+"\nLinted 1 testsuite file: 0 errors, 0 warnings\n", output)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		dir := newTestDir(t)
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "errors_xprin.yaml"), "tests:\n  - name: a\n    inputs:\n      xr: missing.yaml\n      composition: composition.yaml\n      functions: functions.yaml\n")
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = Lint(afero.NewOsFs(), []string{dir}, &testexecutionUtils.Options{}, false)
+		})
+		require.EqualError(t, err, "lint found 1 error")
+		assert.Equal(t, filepath.Join(dir, "errors_xprin.yaml")+":4: error: xr missing.yaml does not exist (missing-input)\n"+
+			"Linted 2 testsuite files: 1 error, 0 warnings\nFAIL\n", output)
+	})
+}
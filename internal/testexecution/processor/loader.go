@@ -17,16 +17,17 @@ limitations under the License.
 package processor
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
 	"github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/spf13/afero"
-	"sigs.k8s.io/yaml"
 )
 
-// load loads and validates a single testsuite file.
+// load loads and validates a single testsuite file. It is decoded strictly (see api.Decode), and the fields that do
+// not match the testsuite specification are reported as file:line:col errors.
 func load(fs afero.Fs, path string) (*api.TestSuiteSpec, error) {
 	data, err := afero.ReadFile(fs, path)
 	if err != nil {
@@ -39,8 +40,18 @@ func load(fs afero.Fs, path string) (*api.TestSuiteSpec, error) {
 		content = utils.ReplaceTemplateVarsWithPlaceholders(content)
 	}
 
-	var testSuiteSpec api.TestSuiteSpec
-	if err := yaml.Unmarshal([]byte(content), &testSuiteSpec); err != nil {
+	testSuiteSpec, err := api.Decode([]byte(content))
+	if err != nil {
+		var decodeErr *api.DecodeError
+		if errors.As(err, &decodeErr) {
+			lines := make([]string, 0, len(decodeErr.Errors))
+			for _, fieldErr := range decodeErr.Errors {
+				lines = append(lines, fmt.Sprintf("%s:%s: %s", path, fieldErr.Position, utils.RestoreTemplateVars(fieldErr.Message)))
+			}
+
+			return nil, fmt.Errorf("failed to parse testsuite file %s:\n%s", path, strings.Join(lines, "\n"))
+		}
+
 		return nil, fmt.Errorf("failed to parse testsuite file %s: %w", path, err)
 	}
 
@@ -48,5 +59,5 @@ func load(fs afero.Fs, path string) (*api.TestSuiteSpec, error) {
 		return nil, fmt.Errorf("no test cases found in testsuite file %s", path)
 	}
 
-	return &testSuiteSpec, nil
+	return testSuiteSpec, nil
 }
@@ -82,7 +82,8 @@ tests:
 	// Create an invalid test file (missing tests)
 	invalidTestContent2 := `
 common:
-  functions: ./myfunctions
+  inputs:
+    functions: ./myfunctions
 `
 	invalidTestFile2 := "/invalid2_xprin.yaml"
 	require.NoError(t, afero.WriteFile(fs, invalidTestFile2, []byte(invalidTestContent2), 0o644))
@@ -130,7 +131,8 @@ tests:
 	// Test with non-existent functions path
 	badFunctionsContent := `
 common:
-  functions: ./nonexistent_dir
+  inputs:
+    functions: ./nonexistent_dir
 tests:
 - name: test1
   inputs:
@@ -153,6 +155,31 @@ tests:
 		assert.Contains(t, err.Error(), "failed to parse testsuite file")
 	})
 
+	t.Run("strict decoding", func(t *testing.T) {
+		testFile := "/strict_xprin.yaml"
+		require.NoError(t, afero.WriteFile(fs, testFile, []byte(`tests:
+- name: test1
+  inputs:
+    xr: xr1.yaml
+  fuzz:
+    xrd: xrd.yaml
+    runs: "{{ .Vars.runs }}"
+  assertion:
+    xprin:
+    - name: count
+      type: Count
+  assertions:
+    xprin:
+    - name: exists
+      type: Exist
+`), 0o644))
+		_, err := load(fs, testFile)
+		require.EqualError(t, err, "failed to parse testsuite file /strict_xprin.yaml:\n"+
+			`/strict_xprin.yaml:7:11: field 'tests[0].fuzz.runs' must be an integer, got a string "{{.Vars.runs}}"`+"\n"+
+			"/strict_xprin.yaml:8:3: unknown field 'tests[0].assertion'\n"+
+			"/strict_xprin.yaml:15:13: invalid value 'Exist' for field 'tests[0].assertions.xprin[0].type': must be one of Count, Exists, NotExists, FieldType, FieldExists, FieldNotExists, FieldValue")
+	})
+
 	// Test template variable handling
 	t.Run("template variable handling", func(t *testing.T) {
 		t.Run("with template variables", func(t *testing.T) {
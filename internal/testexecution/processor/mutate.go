@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/mutation"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MutationReport is the outcome of the mutations of the compositions of a mutation testing run.
+type MutationReport struct {
+	Compositions []CompositionMutations
+}
+
+// CompositionMutations is the outcome of the mutations of a composition.
+type CompositionMutations struct {
+	Name    string // Name of the composition
+	Path    string // Path of the composition
+	Mutants []*mutation.Mutant
+}
+
+// Compositions returns the compositions used by the test cases of the testsuite files found in the targets, in order
+// and without duplicates. Like CRDsPackages, it fails on the first testsuite file that cannot be loaded or resolved.
+func Compositions(fs afero.Fs, targets []string, options *testexecutionUtils.Options) ([]string, error) {
+	d := newDiscovery(fs, options)
+
+	var compositions []string
+
+	for _, testSuiteFile := range d.testSuiteFiles(targets) {
+		testSuiteSpec, err := load(d.fs, testSuiteFile)
+		if err != nil {
+			return nil, err
+		}
+
+		testSuiteCompositions, err := runner.NewRunner(options, testSuiteFile, testSuiteSpec).Compositions()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", testSuiteFile, err)
+		}
+
+		for _, composition := range testSuiteCompositions {
+			if !slices.Contains(compositions, composition) {
+				compositions = append(compositions, composition)
+			}
+		}
+	}
+
+	return compositions, nil
+}
+
+// Mutate runs mutation testing of the compositions used by the test cases of the targets: the test cases run once
+// without mutations, which must pass, then once per mutation of each composition (see mutation.Mutations) with
+// their results hidden. The test cases run with a mutated copy of the composition in their inputs directory, so the
+// composition itself is never modified. A mutation survives when every test case using the composition passes with
+// it. The report is printed at the end, and an error is returned when a mutation survived.
+func Mutate(fs afero.Fs, targets []string, options *testexecutionUtils.Options) error {
+	compositions, err := Compositions(fs, targets, options)
+	if err != nil {
+		return err
+	}
+
+	if len(compositions) == 0 {
+		utils.OutputPrintf("Mutations: no compositions used by the test cases\n")
+		return nil
+	}
+
+	// Every mutation would be killed by test cases that fail anyway
+	var baseline bytes.Buffer
+	if processTargets(fs, targets, mutantOptions(options, nil, &baseline)) {
+		_, _ = os.Stdout.Write(baseline.Bytes())
+
+		utils.OutputPrintf("FAIL\n")
+
+		return fmt.Errorf("test cases fail without mutations")
+	}
+
+	report := &MutationReport{}
+
+	for _, path := range compositions {
+		composition, err := mutation.Load(fs, path)
+		if err != nil {
+			return err
+		}
+
+		c := CompositionMutations{Name: (&unstructured.Unstructured{Object: composition}).GetName(), Path: path}
+
+		for _, m := range mutation.Mutations(composition) {
+			if options.Debug {
+				utils.DebugPrintf("Running test cases with mutation of composition %s: %s\n", path, m)
+			}
+
+			mutant := mutation.NewMutant(path, m)
+			processTargets(fs, targets, mutantOptions(options, mutant, io.Discard))
+			c.Mutants = append(c.Mutants, mutant)
+		}
+
+		report.Compositions = append(report.Compositions, c)
+	}
+
+	report.WriteText(os.Stdout, options.Verbose)
+
+	if report.Survived() > 0 {
+		utils.OutputPrintf("FAIL\n")
+		return fmt.Errorf("mutations survived")
+	}
+
+	return nil
+}
+
+// mutantOptions returns a copy of options that runs the test cases with a mutant, printing their results to output.
+func mutantOptions(options *testexecutionUtils.Options, mutant *mutation.Mutant, output io.Writer) *testexecutionUtils.Options {
+	mutantOptions := *options
+	mutantOptions.Mutant = mutant
+	mutantOptions.Output = output
+
+	return &mutantOptions
+}
+
+// Survived returns the number of mutations that test cases ran with and did not kill.
+func (r *MutationReport) Survived() int {
+	var survived int
+
+	for _, c := range r.Compositions {
+		for _, m := range c.Mutants {
+			if len(m.Ran()) > 0 && len(m.KilledBy()) == 0 {
+				survived++
+			}
+		}
+	}
+
+	return survived
+}
+
+// WriteText prints the mutations that survived and those no test case ran with. With verbose, the mutations that
+// were killed are printed too, with the test cases that killed them.
+func (r *MutationReport) WriteText(w io.Writer, verbose bool) {
+	const spaces = "    "
+
+	fmt.Fprintf(w, "Mutations:\n") //nolint:errcheck // output function, error handling not practical
+
+	var total, killed, uncovered int
+
+	for _, c := range r.Compositions {
+		fmt.Fprintf(w, "%sComposition %s (%s), %d mutations:\n", spaces, c.Name, c.Path, len(c.Mutants)) //nolint:errcheck // output function, error handling not practical
+
+		for _, m := range c.Mutants {
+			total++
+
+			ran, killedBy := m.Ran(), m.KilledBy()
+
+			switch {
+			case len(ran) == 0:
+				uncovered++
+
+				fmt.Fprintf(w, "%s[s] %s (not run by any test case)\n", spaces+spaces, m.Mutation) //nolint:errcheck // output function, error handling not practical
+			case len(killedBy) == 0:
+				fmt.Fprintf(w, "%s[x] %s (survived %d test cases)\n", spaces+spaces, m.Mutation, len(ran)) //nolint:errcheck // output function, error handling not practical
+			default:
+				killed++
+
+				if !verbose {
+					continue
+				}
+
+				fmt.Fprintf(w, "%s[✓] %s (killed by %d of %d test cases)\n", spaces+spaces, m.Mutation, len(killedBy), len(ran)) //nolint:errcheck // output function, error handling not practical
+
+				for _, testCase := range killedBy {
+					fmt.Fprintf(w, "%s- %s\n", spaces+spaces+spaces, testCase) //nolint:errcheck // output function, error handling not practical
+				}
+			}
+		}
+	}
+
+	var percent float64
+	if total > 0 {
+		percent = float64(killed) / float64(total) * 100
+	}
+
+	fmt.Fprintf(w, "%sKilled: %d/%d (%.1f%%), %d survived, %d not run\n", spaces, killed, total, percent, total-killed-uncovered, uncovered) //nolint:errcheck // output function, error handling not practical
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/mutation"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const mutateTestComposition = `apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: xbuckets
+spec:
+  mode: Pipeline
+  pipeline:
+    - step: patch-and-transform
+      functionRef:
+        name: function-patch-and-transform
+      input:
+        apiVersion: pt.fn.crossplane.io/v1beta1
+        kind: Resources
+        resources:
+          - name: bucket
+    - step: auto-ready
+      functionRef:
+        name: function-auto-ready
+`
+
+func TestCompositions(t *testing.T) {
+	dir := t.TempDir()
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws_xprin.yaml"), `common:
+  inputs:
+    composition: composition.yaml
+    functions: functions
+tests:
+  - name: aws
+    inputs:
+      xr: xr.yaml
+  - name: other
+    inputs:
+      xr: xr.yaml
+      composition: other.yaml
+`)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "gcp", "gcp_xprin.yaml"), `tests:
+  - name: gcp
+    inputs:
+      xr: xr.yaml
+      composition: ../composition.yaml
+      functions: functions
+`)
+
+	compositions, err := Compositions(afero.NewOsFs(), []string{dir + "/..."}, &testexecutionUtils.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "composition.yaml"), filepath.Join(dir, "other.yaml")}, compositions)
+}
+
+func TestMutate(t *testing.T) {
+	originalNewRunnerFunc := newRunnerFunc
+
+	defer func() {
+		newRunnerFunc = originalNewRunnerFunc
+	}()
+
+	dir := t.TempDir()
+	composition := unittestsUtils.WriteTestFile(t, filepath.Join(dir, "composition.yaml"), mutateTestComposition)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "xprin.yaml"), `tests:
+  - name: bucket
+    inputs:
+      xr: xr.yaml
+      composition: composition.yaml
+      functions: functions
+`)
+
+	// The test case kills the mutations removing a pipeline step, and the baseline fails when failBaseline is set
+	var failBaseline bool
+
+	newRunnerFunc = func(options *testexecutionUtils.Options, _ string, _ *api.TestSuiteSpec) runnerInterface {
+		return &mockRunner{options: options, runTestsFunc: func() error {
+			if options.Mutant == nil {
+				if failBaseline {
+					return errors.New("tests failed in testsuite")
+				}
+
+				return nil
+			}
+
+			killed := options.Mutant.Mutation.Kind == mutation.KindRemoveStep
+			options.Mutant.AddResult("xprin.yaml: bucket", killed)
+
+			if killed {
+				return errors.New("tests failed in testsuite")
+			}
+
+			return nil
+		}}
+	}
+
+	t.Run("surviving mutations fail", func(t *testing.T) {
+		failBaseline = false
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = Mutate(afero.NewOsFs(), []string{dir}, &testexecutionUtils.Options{})
+		})
+
+		require.ErrorContains(t, err, "mutations survived")
+		assert.Contains(t, output, "Composition xbuckets ("+composition+"), 3 mutations:\n")
+		assert.Contains(t, output, `[x] remove resource "bucket" of step "patch-and-transform" (survived 1 test cases)`)
+		assert.NotContains(t, output, `remove pipeline step`, "killed mutations are only printed with verbose")
+		assert.Contains(t, output, "Killed: 2/3 (66.7%), 1 survived, 0 not run\n")
+		assert.Contains(t, output, "FAIL\n")
+	})
+
+	t.Run("verbose prints the killed mutations", func(t *testing.T) {
+		failBaseline = false
+
+		output := unittestsUtils.CaptureStdout(func() {
+			_ = Mutate(afero.NewOsFs(), []string{dir}, &testexecutionUtils.Options{Verbose: true})
+		})
+
+		assert.Contains(t, output, "[✓] remove pipeline step \"auto-ready\" (killed by 1 of 1 test cases)\n            - xprin.yaml: bucket\n")
+	})
+
+	t.Run("failing baseline", func(t *testing.T) {
+		failBaseline = true
+
+		var err error
+
+		output := unittestsUtils.CaptureStdout(func() {
+			err = Mutate(afero.NewOsFs(), []string{dir}, &testexecutionUtils.Options{})
+		})
+
+		require.ErrorContains(t, err, "test cases fail without mutations")
+		assert.NotContains(t, output, "Mutations:")
+	})
+}
+
+func TestMutationReportWriteText(t *testing.T) {
+	mutations := mutation.Mutations(map[string]any{"spec": map[string]any{"pipeline": []any{
+		map[string]any{"step": "patch-and-transform"},
+		map[string]any{"step": "auto-ready"},
+	}}})
+
+	killed := mutation.NewMutant("/composition.yaml", mutations[0])
+	killed.AddResult("suite: a", true)
+
+	notRun := mutation.NewMutant("/composition.yaml", mutations[1])
+
+	report := &MutationReport{Compositions: []CompositionMutations{{Name: "xbuckets", Path: "/composition.yaml", Mutants: []*mutation.Mutant{killed, notRun}}}}
+
+	var buf bytes.Buffer
+	report.WriteText(&buf, false)
+
+	assert.Equal(t, 0, report.Survived())
+	assert.Equal(t, "Mutations:\n    Composition xbuckets (/composition.yaml), 2 mutations:\n        [s] remove pipeline step \"auto-ready\" (not run by any test case)\n    Killed: 1/2 (50.0%), 0 survived, 1 not run\n", buf.String())
+}
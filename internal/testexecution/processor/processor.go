@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
 	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
 	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/crossplane-contrib/xprin/internal/utils"
@@ -35,6 +36,8 @@ import (
 // runnerInterface allows dependency injection for test runners (for production and testing).
 type runnerInterface interface {
 	RunTests() error
+	TestSuiteResult() *engine.TestSuiteResult
+	Cleanup()
 }
 
 // Mockable functions
@@ -46,12 +49,68 @@ var (
 	}
 )
 
-// ProcessTargets processes the targets and runs the tests
+// ProcessTargets processes the targets and runs the tests.
+// When options.CrossplaneRuns is set, all targets are processed once per crossplane dependency.
+// When options.Coverage is set, the coverage of all the test cases that ran is reported at the end.
+func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.Options) error {
+	var hasErrors bool
+
+	options = withCoverage(options)
+
+	for _, runOptions := range crossplaneRunOptions(options) {
+		if runOptions.Debug && runOptions.Label != "" {
+			utils.DebugPrintf("Running targets with crossplane dependency %s\n", runOptions.Crossplane)
+		}
+
+		if processTargets(fs, targets, runOptions) {
+			hasErrors = true
+		}
+	}
+
+	if options.CoverageReport != nil {
+		if err := writeCoverage(fs, options); err != nil {
+			_ = reportError(options.CoverageFile, "failed to write coverage", err)
+			hasErrors = true
+		}
+	}
+
+	if hasErrors {
+		utils.OutputPrintf("FAIL\n")
+		return fmt.Errorf("processing completed with errors")
+	}
+
+	return nil
+}
+
+// crossplaneRunOptions returns one set of options per crossplane dependency in options.CrossplaneRuns,
+// each selecting and labelled with that dependency, or options itself when there is no matrix run.
+func crossplaneRunOptions(options *testexecutionUtils.Options) []*testexecutionUtils.Options {
+	if len(options.CrossplaneRuns) == 0 {
+		return []*testexecutionUtils.Options{options}
+	}
+
+	runs := make([]*testexecutionUtils.Options, 0, len(options.CrossplaneRuns))
+	for _, crossplane := range options.CrossplaneRuns {
+		runOptions := *options
+		runOptions.Crossplane = crossplane
+		runOptions.Label = crossplane
+		runs = append(runs, &runOptions)
+	}
+
+	return runs
+}
+
+// processTargets processes the targets with the given options and returns true if any errors occurred.
 //
 //nolint:gocognit // Complex target processing with multiple validation and execution phases
-func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.Options) error {
+func processTargets(fs afero.Fs, targets []string, options *testexecutionUtils.Options) bool {
 	var hasErrors bool
 
+	runs := newTestSuiteRuns(fs, options)
+	defer runs.cleanup()
+
+	d := runs.discovery
+
 	for _, path := range targets {
 		if strings.HasSuffix(path, "...") {
 			root := strings.TrimSuffix(path, "...")
@@ -59,7 +118,7 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 				root = strings.TrimSuffix(root, string(filepath.Separator))
 			}
 
-			dirs, err := recursiveDirs(fs, root)
+			dirs, err := d.recursiveDirs(root)
 			if err != nil {
 				_ = reportError(root, "failed to find testsuite files", err)
 				hasErrors = true
@@ -73,7 +132,7 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 					continue
 				}
 
-				if err := processDirectory(fs, dir, options); err != nil {
+				if err := runs.processDirectory(dir); err != nil {
 					hasErrors = true
 				}
 			}
@@ -98,7 +157,7 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 		}
 
 		if info.IsDir() {
-			if err := processDirectory(fs, path, options); err != nil {
+			if err := runs.processDirectory(path); err != nil {
 				hasErrors = true
 			}
 
@@ -106,35 +165,30 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 		}
 
 		// Direct file - check if it's a valid test file
-		if !isValidTestSuiteFileName(path) {
+		if !d.isValidTestSuiteFileName(path) {
 			if options.Debug {
-				utils.DebugPrintf("Skipping file %s because it is not a valid test file. It should be named 'xprin.yaml' or end with '_xprin.yaml' with at least one character before the underscore\n", path)
+				utils.DebugPrintf("Skipping file %s because it is not a valid test file. Its name should match one of: %s\n", path, strings.Join(d.include, ", "))
 			}
 
 			continue
 		}
 
-		if err := processTestSuiteFile(fs, path, options); err != nil {
+		if err := runs.processTestSuiteFile(path); err != nil {
 			hasErrors = true
 		}
 	}
 
-	if hasErrors {
-		utils.OutputPrintf("FAIL\n")
-		return fmt.Errorf("processing completed with errors")
-	}
-
-	return nil
+	return hasErrors
 }
 
 // processDirectory handles finding testsuite files in a directory, printing the go test-style message if none are found.
 // Optionally runs tests from each found testsuite file after loading and validating the configuration.
-func processDirectory(fs afero.Fs, dir string, options *testexecutionUtils.Options) error {
-	if options.Debug {
+func (s *testSuiteRuns) processDirectory(dir string) error {
+	if s.options.Debug {
 		utils.DebugPrintf("Processing directory %s\n", dir)
 	}
 
-	files, err := findTestSuiteFiles(fs, dir)
+	files, err := s.discovery.findTestSuiteFiles(dir)
 	if err != nil {
 		// Special case: if the error is just that no files were found, handle it as an info message
 		if strings.HasPrefix(err.Error(), "no test files found matching pattern") {
@@ -147,7 +201,7 @@ func processDirectory(fs afero.Fs, dir string, options *testexecutionUtils.Optio
 	// Note: No need to check len(files) == 0 here because:
 	// 1. findTestSuiteFiles guarantees it will return an error if no files are found
 	// 2. If we get here, we already know there's no error, so files must be non-empty
-	if options.Debug {
+	if s.options.Debug {
 		plural := pluralize.NewClient()
 		utils.DebugPrintf("Found %s in directory %s\n", plural.Pluralize("testsuite file", len(files), true), dir)
 	}
@@ -155,7 +209,7 @@ func processDirectory(fs afero.Fs, dir string, options *testexecutionUtils.Optio
 	var hasErrors bool
 
 	for _, testSuiteFile := range files {
-		if err := processTestSuiteFile(fs, testSuiteFile, options); err != nil {
+		if err := s.processTestSuiteFile(testSuiteFile); err != nil {
 			hasErrors = true
 		}
 	}
@@ -168,38 +222,77 @@ func processDirectory(fs afero.Fs, dir string, options *testexecutionUtils.Optio
 }
 
 // processTestSuiteFile processes a single test file, loading the configuration and running tests if applicable.
-func processTestSuiteFile(fs afero.Fs, testSuiteFile string, options *testexecutionUtils.Options) error {
-	if options.Debug {
+// The testsuites it depends on run first, and every testsuite file runs at most once per run.
+func (s *testSuiteRuns) processTestSuiteFile(testSuiteFile string) error {
+	key := resolvePath(testSuiteFile)
+
+	// Testsuites that already ran (e.g. as a dependency of an earlier testsuite) do not run again
+	if run, ok := s.done[key]; ok {
+		return run.err
+	}
+
+	s.files[key] = testSuiteFile
+	s.running = append(s.running, key)
+	run := s.runTestSuiteFile(testSuiteFile)
+	s.running = s.running[:len(s.running)-1]
+	s.done[key] = run
+
+	return run.err
+}
+
+// runTestSuiteFile loads and validates a testsuite file, runs its dependencies and then its test cases.
+func (s *testSuiteRuns) runTestSuiteFile(testSuiteFile string) *testSuiteRun {
+	if s.options.Debug {
 		utils.DebugPrintf("Processing testsuite file %s\n", testSuiteFile)
 	}
 
 	// Load and validate test configuration
-	testSuiteSpec, err := load(fs, testSuiteFile)
+	testSuiteSpec, err := load(s.fs, testSuiteFile)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), ("no test cases found")) {
 			fmt.Fprintf(os.Stderr, "?   \t%s\t[no test cases found]\n", testSuiteFile)
-			return nil
+			return &testSuiteRun{}
 		}
 
-		return reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")
+		return &testSuiteRun{err: reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")}
 	}
 
 	// Now that we know we have tests to run, check for empty names and duplicate IDs
 	if err := testSuiteSpec.CheckValidTestSuiteFile(); err != nil {
-		return reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")
+		return &testSuiteRun{err: reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")}
+	}
+
+	if err := testSuiteSpec.CheckCrossplaneSelectors(s.options.CrossplaneDependencies()); err != nil {
+		return &testSuiteRun{err: reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")}
+	}
+
+	suites, err := s.runDependencies(testSuiteFile, testSuiteSpec)
+	if err != nil {
+		return &testSuiteRun{name: testSuiteSpec.Name, err: reportTestSuiteError(testSuiteFile, err, "dependency error")}
+	}
+
+	options := s.options
+	if len(suites) > 0 {
+		suiteOptions := *s.options
+		suiteOptions.Suites = suites
+		options = &suiteOptions
 	}
 
 	testRunner := newRunnerFunc(options, testSuiteFile, testSuiteSpec)
+	s.runners = append(s.runners, testRunner)
 
 	fileErr := testRunner.RunTests()
+	run := &testSuiteRun{name: testSuiteSpec.Name, result: testRunner.TestSuiteResult()}
+
 	if fileErr != nil {
 		errMsg := fileErr.Error()
 		if !strings.Contains(errMsg, "tests failed in testsuite") {
-			return reportTestSuiteError(testSuiteFile, fileErr, "testsuite file execution error")
+			run.err = reportTestSuiteError(testSuiteFile, fileErr, "testsuite file execution error")
+			return run
 		}
 
-		return fmt.Errorf("test execution failed for %s: %w", testSuiteFile, fileErr)
+		run.err = fmt.Errorf("test execution failed for %s: %w", testSuiteFile, fileErr)
 	}
 
-	return nil
+	return run
 }
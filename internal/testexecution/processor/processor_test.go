@@ -18,6 +18,7 @@ package processor
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -25,6 +26,8 @@ import (
 	"testing"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/coverage"
+	"github.com/crossplane-contrib/xprin/internal/engine"
 	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
 	"github.com/spf13/afero"
@@ -44,6 +47,8 @@ type mockRunner struct {
 	runTestsFunc func() error
 	output       io.Writer
 	options      *testexecutionUtils.Options
+	result       *engine.TestSuiteResult
+	cleaned      bool
 }
 
 func (m *mockRunner) RunTests() error {
@@ -54,6 +59,14 @@ func (m *mockRunner) RunTests() error {
 	return nil
 }
 
+func (m *mockRunner) TestSuiteResult() *engine.TestSuiteResult {
+	return m.result
+}
+
+func (m *mockRunner) Cleanup() {
+	m.cleaned = true
+}
+
 func TestProcessTargets(t *testing.T) {
 	originalNewRunnerFunc := newRunnerFunc
 
@@ -304,6 +317,139 @@ func TestProcessTargets(t *testing.T) {
 		require.NoError(t, err, "Processing directory with mixed valid/invalid files should not error")
 		assert.Contains(t, buf.String(), "test_xprin.yaml", "Output should include the valid file names")
 	})
+
+	t.Run("crossplane matrix runs every testsuite once per dependency", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/a_xprin.yaml", []byte(testContentWithTests), 0o644))
+		require.NoError(t, afero.WriteFile(fs, "/b_xprin.yaml", []byte(testContentWithTests), 0o644))
+
+		var runs []string
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, testSuiteFile string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsFunc: func() error {
+					runs = append(runs, fmt.Sprintf("%s %s %s", options.CrossplaneDependency(), options.Label, testSuiteFile))
+					return nil
+				},
+			}
+		}
+
+		options := &testexecutionUtils.Options{CrossplaneRuns: []string{"crossplane-1.20", "crossplane-2.0"}}
+		err := ProcessTargets(fs, []string{"/a_xprin.yaml", "/b_xprin.yaml"}, options)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"crossplane-1.20 crossplane-1.20 /a_xprin.yaml",
+			"crossplane-1.20 crossplane-1.20 /b_xprin.yaml",
+			"crossplane-2.0 crossplane-2.0 /a_xprin.yaml",
+			"crossplane-2.0 crossplane-2.0 /b_xprin.yaml",
+		}, runs)
+		assert.Empty(t, options.Crossplane, "original options must not be modified")
+	})
+
+	t.Run("crossplane matrix fails if any run fails", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/a_xprin.yaml", []byte(testContentWithTests), 0o644))
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, _ string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsFunc: func() error {
+					if options.Crossplane == "crossplane-1.20" {
+						return errors.New("tests failed in testsuite a_xprin.yaml")
+					}
+
+					return nil
+				},
+			}
+		}
+
+		var err error
+
+		stdout := unittestsUtils.CaptureStdout(func() {
+			err = ProcessTargets(fs, []string{"/a_xprin.yaml"}, &testexecutionUtils.Options{CrossplaneRuns: []string{"crossplane-1.20", "crossplane-2.0"}})
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, strings.Count(stdout, "FAIL\n"), "FAIL should be printed once after all runs")
+	})
+
+	t.Run("coverage is aggregated over all runs", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/a_xprin.yaml", []byte(testContentWithTests), 0o644))
+		require.NoError(t, afero.WriteFile(fs, "/b_xprin.yaml", []byte(testContentWithTests), 0o644))
+		require.NoError(t, afero.WriteFile(fs, "/composition.yaml", []byte("apiVersion: apiextensions.crossplane.io/v1\nkind: Composition\nmetadata:\n  name: xbuckets\nspec:\n  pipeline:\n    - step: render\n"), 0o644))
+
+		var reports []*coverage.Report
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, _ string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsFunc: func() error {
+					reports = append(reports, options.CoverageReport)
+					return options.CoverageReport.AddRender(fs, "/composition.yaml", "/composition.yaml", nil, true)
+				},
+			}
+		}
+
+		options := &testexecutionUtils.Options{Coverage: true, CoverageFile: "/coverage.json"}
+
+		var err error
+
+		stdout := unittestsUtils.CaptureStdout(func() {
+			err = ProcessTargets(fs, []string{"/a_xprin.yaml", "/b_xprin.yaml"}, options)
+		})
+
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Same(t, reports[0], reports[1], "all testsuites should share the coverage report of the run")
+		assert.Nil(t, options.CoverageReport, "original options must not be modified")
+		assert.Contains(t, stdout, "Composition xbuckets (/composition.yaml), 2 renders:")
+
+		data, err := afero.ReadFile(fs, "/coverage.json")
+		require.NoError(t, err)
+
+		var summary coverage.Summary
+		require.NoError(t, json.Unmarshal(data, &summary))
+		require.Len(t, summary.Compositions, 1)
+		assert.Equal(t, 2, summary.Compositions[0].Renders)
+	})
+
+	t.Run("no coverage with --list", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		options := &testexecutionUtils.Options{Coverage: true, CoverageFile: "/coverage.json", List: true}
+		require.NoError(t, ProcessTargets(fs, []string{"/missing"}, options))
+
+		exists, err := afero.Exists(fs, "/coverage.json")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestCrossplaneRunOptions(t *testing.T) {
+	t.Run("no matrix returns the same options", func(t *testing.T) {
+		options := &testexecutionUtils.Options{Verbose: true}
+
+		runs := crossplaneRunOptions(options)
+		require.Len(t, runs, 1)
+		assert.Same(t, options, runs[0])
+		assert.Equal(t, testexecutionUtils.DefaultCrossplane, runs[0].CrossplaneDependency())
+	})
+
+	t.Run("matrix returns labelled copies", func(t *testing.T) {
+		options := &testexecutionUtils.Options{Verbose: true, CrossplaneRuns: []string{"crossplane-1.20", "crossplane-2.0"}}
+
+		runs := crossplaneRunOptions(options)
+		require.Len(t, runs, 2)
+
+		for i, name := range options.CrossplaneRuns {
+			assert.Equal(t, name, runs[i].Crossplane)
+			assert.Equal(t, name, runs[i].Label)
+			assert.True(t, runs[i].Verbose)
+		}
+	})
 }
 
 func TestProcessDirectory(t *testing.T) {
@@ -326,7 +472,7 @@ func TestProcessDirectory(t *testing.T) {
 				var err error
 
 				out := unittestsUtils.CaptureStderr(func() {
-					err = processDirectory(fs, dir, &testexecutionUtils.Options{})
+					err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processDirectory(dir)
 				})
 				assert.Contains(t, out, "?   \t"+dir+"\t[no testsuite files]", "expected no testsuite files message")
 				assert.NoError(t, err, "did not expect error for empty directory")
@@ -344,7 +490,7 @@ func TestProcessDirectory(t *testing.T) {
 		var err error
 
 		out := unittestsUtils.CaptureStderr(func() {
-			err = processDirectory(fs, badPattern, &testexecutionUtils.Options{})
+			err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processDirectory(badPattern)
 		})
 		// processDirectory treats "no test files found" as a special case and doesn't return an error
 		// It just prints a message to stderr
@@ -368,7 +514,7 @@ func TestProcessDirectory(t *testing.T) {
 		var err error
 
 		out := unittestsUtils.CaptureOutput(func() {
-			err = processDirectory(fs, dir, &testexecutionUtils.Options{})
+			err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processDirectory(dir)
 		})
 		// Since we're writing dummy files, there will likely be errors during processing
 		// but that's not what we're testing here - we're testing file discovery
@@ -389,7 +535,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		var err error
 
 		out := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processTestSuiteFile(testFile)
 		})
 		if !strings.Contains(out, "?   \t/suite.yaml\t[no test cases found]") {
 			t.Errorf("expected no test cases found output, got: %q", out)
@@ -414,7 +560,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processTestSuiteFile(testFile)
 		})
 
 		// Should have an error returned
@@ -432,6 +578,24 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 	})
 
+	t.Run("unknown crossplane dependency error", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		testFile := testSuiteYAML
+		require.NoError(t, afero.WriteFile(fs, testFile, []byte("tests:\n  - name: v2 only\n    crossplane:\n      only: [crossplane-2.O]\n"), 0o644))
+
+		options := &testexecutionUtils.Options{Dependencies: map[string]string{"crossplane": "crossplane", "crossplane-2.0": "crossplane2", "yq": "yq"}}
+
+		var err error
+
+		stderrOutput := unittestsUtils.CaptureStderr(func() {
+			err = newTestSuiteRuns(fs, options).processTestSuiteFile(testFile)
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, stderrOutput, "test case 'v2 only' selects unknown crossplane dependency 'crossplane-2.O' (configured: crossplane, crossplane-2.0)")
+		assert.Contains(t, stderrOutput, "FAIL\t/suite.yaml\t[invalid testsuite file]")
+	})
+
 	t.Run("no top-level group names", func(t *testing.T) {
 		fs := afero.NewMemMapFs()
 		testFile := testSuiteYAML
@@ -446,7 +610,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processTestSuiteFile(testFile)
 		})
 
 		if !strings.Contains(stderrOutput, "?   \t/suite.yaml\t[no test cases found]") {
@@ -473,7 +637,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 			return runner
 		}
 
-		err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+		err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processTestSuiteFile(testFile)
 		if err == nil {
 			t.Errorf("expected error, got nil")
 		}
@@ -497,7 +661,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processTestSuiteFile(testFile)
 		})
 
 		// Check stderr for FAIL status
@@ -533,7 +697,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processTestSuiteFile(testFile)
 		})
 
 		if strings.Contains(stderrOutput, "FAIL") {
@@ -625,7 +789,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 				}
 
 				stderrOutput := unittestsUtils.CaptureStderr(func() {
-					processErr = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+					processErr = newTestSuiteRuns(fs, &testexecutionUtils.Options{}).processTestSuiteFile(testFile)
 				})
 
 				if len(tt.expectedErrors) > 0 {
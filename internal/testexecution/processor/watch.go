@@ -0,0 +1,336 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/afero"
+)
+
+const (
+	// watchDebounce is how long file events are collected before the affected test cases are re-run,
+	// so that saving several files (or an editor writing a file in several steps) triggers a single run.
+	watchDebounce = 200 * time.Millisecond
+
+	// watchChangedSince labels the changes that trigger a re-run in watch mode.
+	watchChangedSince = "last run"
+
+	clearScreen = "\033[H\033[2J"
+)
+
+// Watch runs the targets, then watches the testsuite files and every path their test cases depend on and re-runs
+// the affected test cases whenever any of them changes, until ctx is cancelled. The mapping from paths to test cases
+// is rebuilt whenever a testsuite file changes.
+func Watch(ctx context.Context, fs afero.Fs, targets []string, options *testexecutionUtils.Options) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close() //nolint:errcheck // nothing to do if closing the watcher fails
+
+	d := newDiscovery(fs, options)
+	paths := watchPaths(d, targets, options)
+	watchDirectories(d, watcher, targets, paths, options)
+
+	runWatched(fs, targets, options, nil, len(paths))
+
+	var (
+		pending = make(map[string]bool)
+		timer   <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			utils.WarningPrintf("file watcher error: %v\n", err)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			// Watch new directories that contain watched paths, and pick up the files written before they were watched
+			if created, ok := createdPaths(fs, event, paths); ok {
+				watchDirectories(d, watcher, targets, paths, options)
+
+				for _, path := range created {
+					pending[path] = true
+					timer = time.After(watchDebounce)
+				}
+
+				continue
+			}
+
+			if !d.isWatched(event.Name, paths) {
+				continue
+			}
+
+			pending[event.Name] = true
+			timer = time.After(watchDebounce)
+		case <-timer:
+			changed := make([]string, 0, len(pending))
+			for path := range pending {
+				changed = append(changed, path)
+			}
+
+			slices.Sort(changed)
+			clear(pending)
+
+			if slices.ContainsFunc(changed, d.isValidTestSuiteFileName) {
+				if options.Debug {
+					utils.DebugPrintf("Rebuilding watched paths because a testsuite file changed\n")
+				}
+
+				paths = watchPaths(d, targets, options)
+				watchDirectories(d, watcher, targets, paths, options)
+			}
+
+			runOptions := *options
+			runOptions.ChangedSince = watchChangedSince
+			runOptions.ChangedFiles = changed
+
+			runWatched(fs, targets, &runOptions, changed, len(paths))
+		}
+	}
+}
+
+// runWatched clears the screen, runs the targets and prints a one-line summary of the run.
+// changed holds the files that triggered the run and is empty for the initial run of every test case.
+func runWatched(fs afero.Fs, targets []string, options *testexecutionUtils.Options, changed []string, watched int) {
+	utils.OutputPrintf(clearScreen)
+
+	if len(changed) > 0 {
+		utils.OutputPrintf("Changed: %s\n\n", strings.Join(changed, ", "))
+	}
+
+	status := "ok"
+	if err := ProcessTargets(fs, targets, options); err != nil {
+		status = "FAIL"
+	}
+
+	utils.OutputPrintf("\n[%s] %s - watching %d paths for changes (press Ctrl+C to stop)\n", time.Now().Format(time.TimeOnly), status, watched)
+}
+
+// watchPaths returns the absolute paths, with symlinks evaluated, of the testsuite files found in the targets and of
+// every path their test cases depend on. Testsuite files that cannot be loaded are watched without their dependencies.
+func watchPaths(d *discovery, targets []string, options *testexecutionUtils.Options) []string {
+	var paths []string
+
+	for _, testSuiteFile := range d.testSuiteFiles(targets) {
+		testSuiteSpec, err := load(d.fs, testSuiteFile)
+		if err != nil {
+			paths = append(paths, testSuiteFile)
+			continue
+		}
+
+		paths = append(paths, runner.NewRunner(options, testSuiteFile, testSuiteSpec).WatchPaths()...)
+	}
+
+	for i, path := range paths {
+		paths[i] = resolvePath(path)
+	}
+
+	slices.Sort(paths)
+
+	return slices.Compact(paths)
+}
+
+// testSuiteFiles returns the testsuite files found in the targets, the same way processTargets finds them.
+// Targets that cannot be accessed are ignored, as they are reported when the targets run.
+func (d *discovery) testSuiteFiles(targets []string) []string {
+	var files []string
+
+	for _, dir := range d.targetDirectories(targets) {
+		found, err := d.findTestSuiteFiles(dir)
+		if err == nil {
+			files = append(files, found...)
+		}
+	}
+
+	for _, path := range targets {
+		if info, err := d.fs.Stat(path); err == nil && !info.IsDir() && d.isValidTestSuiteFileName(path) {
+			files = append(files, path)
+		}
+	}
+
+	return files
+}
+
+// targetDirectories returns the directories that the targets search for testsuite files.
+func (d *discovery) targetDirectories(targets []string) []string {
+	var dirs []string
+
+	for _, path := range targets {
+		if strings.HasSuffix(path, "...") {
+			root := strings.TrimSuffix(strings.TrimSuffix(path, "..."), string(filepath.Separator))
+
+			found, err := d.recursiveDirs(root)
+			if err == nil {
+				dirs = append(dirs, found...)
+			}
+
+			continue
+		}
+
+		if info, err := d.fs.Stat(path); err == nil && info.IsDir() {
+			dirs = append(dirs, path)
+		}
+	}
+
+	return dirs
+}
+
+// watchDirectories replaces the directories watched by watcher with the target directories, the directories
+// containing the watched paths and every directory below watched directories. Editors often replace files
+// instead of writing them, so directories are watched rather than the files themselves.
+func watchDirectories(d *discovery, watcher *fsnotify.Watcher, targets, paths []string, options *testexecutionUtils.Options) {
+	fs := d.fs
+	dirs := make(map[string]bool)
+
+	for _, dir := range d.targetDirectories(targets) {
+		dirs[resolvePath(dir)] = true
+	}
+
+	for _, path := range paths {
+		info, err := fs.Stat(path)
+		if err != nil {
+			dirs[existingDirectory(fs, filepath.Dir(path))] = true
+			continue
+		}
+
+		if !info.IsDir() {
+			dirs[filepath.Dir(path)] = true
+			continue
+		}
+
+		found, err := d.recursiveDirs(path)
+		if err != nil {
+			dirs[path] = true
+			continue
+		}
+
+		for _, dir := range found {
+			dirs[dir] = true
+		}
+	}
+
+	for _, dir := range watcher.WatchList() {
+		if !dirs[dir] {
+			_ = watcher.Remove(dir)
+		}
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil && options.Debug {
+			utils.DebugPrintf("Not watching %s: %v\n", dir, err)
+		}
+	}
+}
+
+// existingDirectory returns dir, or its closest ancestor that exists when dir does not exist yet.
+func existingDirectory(fs afero.Fs, dir string) string {
+	for {
+		if _, err := fs.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			return dir
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
+
+// createdPaths reports whether event creates a directory that contains watched paths and returns the watched paths
+// that already exist in it.
+func createdPaths(fs afero.Fs, event fsnotify.Event, paths []string) ([]string, bool) {
+	if !event.Has(fsnotify.Create) {
+		return nil, false
+	}
+
+	if info, err := fs.Stat(event.Name); err != nil || !info.IsDir() {
+		return nil, false
+	}
+
+	var (
+		created  []string
+		contains bool
+	)
+
+	for _, path := range paths {
+		if !strings.HasPrefix(path, event.Name+string(filepath.Separator)) {
+			continue
+		}
+
+		contains = true
+
+		if _, err := fs.Stat(path); err == nil {
+			created = append(created, path)
+		}
+	}
+
+	return created, contains
+}
+
+// isWatched reports whether path is a testsuite file, one of the watched paths or a file below a watched directory.
+func (d *discovery) isWatched(path string, paths []string) bool {
+	if d.isValidTestSuiteFileName(path) {
+		return true
+	}
+
+	for _, watched := range paths {
+		if path == watched || strings.HasPrefix(path, watched+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolvePath returns path made absolute and with the symlinks of its directory evaluated, so that it matches the
+// paths of file events and of git changes. The file itself need not exist.
+func resolvePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+
+	return path
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const watchTestSuite = `common:
+  inputs:
+    composition: composition.yaml
+    functions: functions
+tests:
+  - name: aws
+    inputs:
+      xr: aws/xr.yaml
+  - name: gcp
+    inputs:
+      xr: gcp/xr.yaml
+`
+
+// createWatchTestSuite creates a testsuite with its inputs in a new directory and returns the directory.
+func createWatchTestSuite(t *testing.T) string {
+	t.Helper()
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "suite_xprin.yaml"), watchTestSuite)
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "composition.yaml"), "kind: Composition\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "functions", "functions.yaml"), "kind: Function\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws", "xr.yaml"), "kind: XR\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "gcp", "xr.yaml"), "kind: XR\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "README.md"), "# Tests\n")
+
+	return dir
+}
+
+func TestWatchPaths(t *testing.T) {
+	dir := createWatchTestSuite(t)
+	options := &testexecutionUtils.Options{}
+	d := newDiscovery(afero.NewOsFs(), options)
+
+	want := []string{
+		filepath.Join(dir, "aws", "xr.yaml"),
+		filepath.Join(dir, "composition.yaml"),
+		filepath.Join(dir, "functions"),
+		filepath.Join(dir, "gcp", "xr.yaml"),
+		filepath.Join(dir, "suite_xprin.yaml"),
+	}
+
+	t.Run("directory target", func(t *testing.T) {
+		assert.Equal(t, want, watchPaths(d, []string{dir}, options))
+	})
+
+	t.Run("recursive target", func(t *testing.T) {
+		assert.Equal(t, want, watchPaths(d, []string{dir + "/..."}, options))
+	})
+
+	t.Run("invalid testsuite file is watched without dependencies", func(t *testing.T) {
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "other_xprin.yaml"), "tests: [")
+
+		paths := watchPaths(d, []string{filepath.Join(dir, "other_xprin.yaml")}, options)
+		assert.Equal(t, []string{filepath.Join(dir, "other_xprin.yaml")}, paths)
+	})
+}
+
+func TestIsWatched(t *testing.T) {
+	paths := []string{"/tests/composition.yaml", "/tests/functions"}
+	d := newDiscovery(afero.NewMemMapFs(), &testexecutionUtils.Options{})
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "watched file", path: "/tests/composition.yaml", want: true},
+		{name: "file in a watched directory", path: "/tests/functions/function-patch.yaml", want: true},
+		{name: "new testsuite file", path: "/tests/new_xprin.yaml", want: true},
+		{name: "unrelated file", path: "/tests/README.md", want: false},
+		{name: "file with a watched directory as prefix", path: "/tests/functions.yaml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, d.isWatched(tt.path, paths))
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	originalNewRunnerFunc := newRunnerFunc
+
+	defer func() {
+		newRunnerFunc = originalNewRunnerFunc
+	}()
+
+	dir := createWatchTestSuite(t)
+	runs := make(chan *testexecutionUtils.Options, 10)
+
+	newRunnerFunc = func(options *testexecutionUtils.Options, _ string, _ *api.TestSuiteSpec) runnerInterface {
+		return &mockRunner{options: options, runTestsFunc: func() error {
+			runs <- options
+			return nil
+		}}
+	}
+
+	nextRun := func() *testexecutionUtils.Options {
+		select {
+		case options := <-runs:
+			return options
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for the tests to run")
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+
+	output := unittestsUtils.CaptureStdout(func() {
+		go func() {
+			done <- Watch(ctx, afero.NewOsFs(), []string{dir}, &testexecutionUtils.Options{})
+		}()
+
+		initial := nextRun()
+		assert.Empty(t, initial.ChangedSince, "the initial run selects every test case")
+
+		// Unrelated files do not trigger a run
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "README.md"), "# Changed\n")
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "aws", "xr.yaml"), "kind: XR\nspec: {}\n")
+
+		changed := nextRun()
+		assert.Equal(t, watchChangedSince, changed.ChangedSince)
+		assert.Equal(t, []string{filepath.Join(dir, "aws", "xr.yaml")}, changed.ChangedFiles)
+
+		// Test cases added to the testsuite file are watched after it changes
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "suite_xprin.yaml"), watchTestSuite+"  - name: azure\n    inputs:\n      xr: azure/xr.yaml\n")
+		assert.Equal(t, []string{filepath.Join(dir, "suite_xprin.yaml")}, nextRun().ChangedFiles)
+
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, "azure", "xr.yaml"), "kind: XR\n")
+		assert.Equal(t, []string{filepath.Join(dir, "azure", "xr.yaml")}, nextRun().ChangedFiles)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	assert.Contains(t, output, clearScreen)
+	assert.Contains(t, output, "Changed: "+filepath.Join(dir, "aws", "xr.yaml"))
+	assert.Contains(t, output, "watching 5 paths for changes")
+	assert.Contains(t, output, "watching 6 paths for changes")
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// ArtifactsIndexFile is the name of the index of the testsuites and test cases in the artifacts directory.
+const ArtifactsIndexFile = "index.json"
+
+// unsafeNameChars matches the characters that are replaced in the directory names of test cases.
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ArtifactsIndex lists the testsuites that wrote artifacts and the results of their test cases.
+// Paths are relative to the artifacts directory and use forward slashes.
+type ArtifactsIndex struct {
+	Suites []ArtifactsSuite `json:"suites"`
+}
+
+// ArtifactsSuite is the entry of a testsuite run in the artifacts index.
+type ArtifactsSuite struct {
+	File   string          `json:"file"`            // Testsuite file, relative to the working directory when possible
+	Label  string          `json:"label,omitempty"` // Run label (the crossplane dependency of a matrix run)
+	Status string          `json:"status"`
+	Path   string          `json:"path"` // Directory of the artifacts of the testsuite
+	Tests  []ArtifactsTest `json:"tests"`
+}
+
+// ArtifactsTest is the entry of a test case in the artifacts index.
+type ArtifactsTest struct {
+	Name       string  `json:"name"`
+	ID         string  `json:"id,omitempty"`
+	Status     string  `json:"status"`
+	Duration   float64 `json:"duration"` // Seconds
+	Error      string  `json:"error,omitempty"`
+	SkipReason string  `json:"skipReason,omitempty"`
+	Path       string  `json:"path,omitempty"` // Directory of the artifacts of the test case, when they were kept
+}
+
+// artifactsSuitePath returns the directory of the artifacts of the testsuite, relative to the artifacts directory:
+// the testsuite path without its extension, below a directory named after the run label if any.
+func (r *Runner) artifactsSuitePath() string {
+	suite := relativeTestSuitePath(r.testSuiteFile)
+	suite = strings.TrimSuffix(suite, path.Ext(suite))
+
+	// Keep absolute testsuite paths and paths outside the working directory inside the artifacts directory
+	suite = strings.TrimLeft(strings.ReplaceAll(suite, "../", ""), "/")
+
+	if r.Label != "" {
+		suite = path.Join(unsafeNameChars.ReplaceAllString(r.Label, "-"), suite)
+	}
+
+	return suite
+}
+
+// testCaseArtifactsNames returns the directory name of the artifacts of each test case: its ID, or its name, with unsafe
+// characters replaced and a numeric suffix for names that are already taken.
+func testCaseArtifactsNames(tests []api.TestCase) []string {
+	names := make([]string, len(tests))
+	taken := make(map[string]bool)
+
+	for i, testCase := range tests {
+		name := testCase.ID
+		if name == "" {
+			name = testCase.Name
+		}
+
+		name = strings.Trim(unsafeNameChars.ReplaceAllString(name, "-"), "-.")
+		if name == "" {
+			name = "test"
+		}
+
+		unique := name
+		for n := 2; taken[unique]; n++ {
+			unique = fmt.Sprintf("%s-%d", name, n)
+		}
+
+		taken[unique] = true
+		names[i] = unique
+	}
+
+	return names
+}
+
+// keepArtifacts reports whether the artifacts of a test case with the given result are kept.
+func (r *Runner) keepArtifacts(result *engine.TestCaseResult) bool {
+	if r.ArtifactsRetention == testexecutionUtils.ArtifactsRetentionOnFailure {
+		return result.Status == engine.StatusFail()
+	}
+
+	return true
+}
+
+// saveArtifacts copies the inputs and outputs of the test case and writes the logs of its hooks to dir,
+// when the retention policy keeps the artifacts of result.
+func (r *Runner) saveArtifacts(result *engine.TestCaseResult, dir string) error {
+	if !r.keepArtifacts(result) {
+		return nil
+	}
+
+	for _, sub := range []struct{ src, dest string }{{r.inputsDir, "inputs"}, {r.outputsDir, "outputs"}} {
+		// The test case may have failed before its inputs and outputs directories were created
+		if exists, _ := afero.DirExists(r.fs, sub.src); sub.src == "" || !exists {
+			continue
+		}
+
+		if err := r.copy(sub.src, filepath.Join(dir, sub.dest)); err != nil {
+			return fmt.Errorf("failed to copy %s to artifacts directory: %w", sub.dest, err)
+		}
+	}
+
+	for _, hooks := range []struct {
+		name    string
+		results []engine.HookResult
+	}{{"pre-test", result.PreTestHooksResults}, {"post-test", result.PostTestHooksResults}} {
+		if len(hooks.results) == 0 {
+			continue
+		}
+
+		logFile := filepath.Join(dir, "hooks", hooks.name+".log")
+		if err := r.fs.MkdirAll(filepath.Dir(logFile), 0o750); err != nil {
+			return fmt.Errorf("failed to create hooks artifacts directory: %w", err)
+		}
+
+		if err := afero.WriteFile(r.fs, logFile, hooksLog(hooks.results), 0o600); err != nil {
+			return fmt.Errorf("failed to write %s hooks log: %w", hooks.name, err)
+		}
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Saved artifacts of test case '%s' to: %s\n", result.Name, dir)
+	}
+
+	return nil
+}
+
+// hooksLog returns the commands, outputs and errors of hooks, one block per hook.
+func hooksLog(results []engine.HookResult) []byte {
+	var buf bytes.Buffer
+
+	for _, hook := range results {
+		if hook.Name != "" {
+			fmt.Fprintf(&buf, "# %s\n", hook.Name)
+		}
+
+		fmt.Fprintf(&buf, "$ %s\n%s", hook.Command, hook.Output)
+
+		if len(hook.Output) > 0 && !bytes.HasSuffix(hook.Output, []byte("\n")) {
+			buf.WriteString("\n")
+		}
+
+		if hook.Error != nil {
+			fmt.Fprintf(&buf, "error: %v\n", hook.Error)
+		}
+
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes()
+}
+
+// artifactsTest returns the entry of a test case result in the artifacts index. name is the directory name of the
+// artifacts of the test case, which is only referenced when they were kept.
+func (r *Runner) artifactsTest(result *engine.TestCaseResult, name string) ArtifactsTest {
+	test := ArtifactsTest{
+		Name:       result.Name,
+		ID:         result.ID,
+		Status:     result.Status.Value,
+		Duration:   result.Duration.Seconds(),
+		SkipReason: result.SkipReason,
+	}
+
+	if result.Error != nil {
+		test.Error = result.Error.Error()
+	}
+
+	suitePath := r.artifactsSuitePath()
+	if exists, _ := afero.DirExists(r.fs, filepath.Join(r.ArtifactsDir, filepath.FromSlash(suitePath), name)); exists {
+		test.Path = path.Join(suitePath, name)
+	}
+
+	return test
+}
+
+// updateArtifactsIndex replaces the entry of this testsuite run in the index of the artifacts directory.
+func (r *Runner) updateArtifactsIndex(status engine.Status, tests []ArtifactsTest) error {
+	indexFile := filepath.Join(r.ArtifactsDir, ArtifactsIndexFile)
+
+	var index ArtifactsIndex
+
+	// A missing or unreadable index is replaced
+	if data, err := afero.ReadFile(r.fs, indexFile); err == nil {
+		_ = json.Unmarshal(data, &index)
+	}
+
+	suite := ArtifactsSuite{
+		File:   relativeTestSuitePath(r.testSuiteFile),
+		Label:  r.Label,
+		Status: status.Value,
+		Path:   r.artifactsSuitePath(),
+		Tests:  tests,
+	}
+
+	index.Suites = slices.DeleteFunc(index.Suites, func(s ArtifactsSuite) bool {
+		return s.File == suite.File && s.Label == suite.Label
+	})
+	index.Suites = append(index.Suites, suite)
+
+	slices.SortFunc(index.Suites, func(a, b ArtifactsSuite) int {
+		return strings.Compare(a.File+"\x00"+a.Label, b.File+"\x00"+b.Label)
+	})
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal artifacts index: %w", err)
+	}
+
+	if err := r.fs.MkdirAll(r.ArtifactsDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	if err := afero.WriteFile(r.fs, indexFile, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write artifacts index: %w", err)
+	}
+
+	return nil
+}
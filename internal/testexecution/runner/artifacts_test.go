@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestTestCaseArtifactsNames(t *testing.T) {
+	tests := []api.TestCase{
+		{Name: "basic test"},
+		{Name: "with id", ID: "with-id"},
+		{Name: "basic test"},
+		{Name: "../escape/attempt"},
+		{Name: "***"},
+	}
+
+	assert.Equal(t, []string{"basic-test", "with-id", "basic-test-2", "escape-attempt", "test"}, testCaseArtifactsNames(tests))
+}
+
+func TestArtifactsSuitePath(t *testing.T) {
+	tests := []struct {
+		name          string
+		testSuiteFile string
+		label         string
+		want          string
+	}{
+		{name: "relative testsuite file", testSuiteFile: "tests/aws_xprin.yaml", want: "tests/aws_xprin"},
+		{name: "run label", testSuiteFile: "tests/aws_xprin.yaml", label: "crossplane 2.0", want: "crossplane-2.0/tests/aws_xprin"},
+		{name: "testsuite file outside the working directory", testSuiteFile: "/outside/aws_xprin.yaml", want: "outside/aws_xprin"},
+		{name: "parent directory", testSuiteFile: "../tests/xprin.yml", want: "tests/xprin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(&testexecutionUtils.Options{Label: tt.label}, tt.testSuiteFile, &api.TestSuiteSpec{})
+			assert.Equal(t, tt.want, r.artifactsSuitePath())
+		})
+	}
+}
+
+func TestSaveArtifacts(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	newArtifactsRunner := func(retention string) *Runner {
+		r := NewRunner(&testexecutionUtils.Options{ArtifactsRetention: retention}, testSuiteFile, &api.TestSuiteSpec{})
+		r.inputsDir = filepath.Join(tmpDir, "inputs")
+		r.outputsDir = filepath.Join(tmpDir, "outputs")
+
+		return r
+	}
+
+	unittestsUtils.WriteTestFile(t, filepath.Join(tmpDir, "inputs", "xr.yaml"), "kind: XR\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(tmpDir, "outputs", "rendered.yaml"), "kind: Rendered\n")
+	unittestsUtils.WriteTestFile(t, filepath.Join(tmpDir, "outputs", "assertions.txt"), "[✓] count\n")
+
+	passed := createTestCaseResult("passed", false, nil)
+	passed.PostTestHooksResults = []engine.HookResult{
+		{Name: "check", Command: "echo ok", Output: []byte("ok")},
+		{Command: "false", Error: errors.New("exit status 1")},
+	}
+
+	t.Run("always keeps the artifacts", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "passed")
+		require.NoError(t, newArtifactsRunner(testexecutionUtils.ArtifactsRetentionAlways).saveArtifacts(passed, dir))
+
+		assert.FileExists(t, filepath.Join(dir, "inputs", "xr.yaml"))
+		assert.FileExists(t, filepath.Join(dir, "outputs", "rendered.yaml"))
+		assert.FileExists(t, filepath.Join(dir, "outputs", "assertions.txt"))
+		assert.NoFileExists(t, filepath.Join(dir, "hooks", "pre-test.log"))
+
+		log, err := os.ReadFile(filepath.Join(dir, "hooks", "post-test.log"))
+		require.NoError(t, err)
+		assert.Equal(t, "# check\n$ echo ok\nok\n\n$ false\nerror: exit status 1\n\n", string(log))
+	})
+
+	t.Run("on-failure only keeps the artifacts of failed test cases", func(t *testing.T) {
+		dir := t.TempDir()
+		r := newArtifactsRunner(testexecutionUtils.ArtifactsRetentionOnFailure)
+
+		require.NoError(t, r.saveArtifacts(passed, filepath.Join(dir, "passed")))
+		require.NoError(t, r.saveArtifacts(createTestCaseResult("failed", false, errors.New("boom")), filepath.Join(dir, "failed")))
+
+		assert.NoDirExists(t, filepath.Join(dir, "passed"))
+		assert.FileExists(t, filepath.Join(dir, "failed", "inputs", "xr.yaml"))
+	})
+
+	t.Run("missing inputs and outputs directories", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "early-failure")
+		r := newArtifactsRunner(testexecutionUtils.ArtifactsRetentionAlways)
+		r.inputsDir = filepath.Join(tmpDir, "missing")
+		r.outputsDir = ""
+
+		require.NoError(t, r.saveArtifacts(createTestCaseResult("early-failure", false, errors.New("boom")), dir))
+		assert.NoDirExists(t, filepath.Join(dir, "inputs"))
+	})
+}
+
+func TestRunTests_Artifacts(t *testing.T) {
+	artifactsDir := t.TempDir()
+
+	// Artifacts of a previous run are removed
+	unittestsUtils.WriteTestFile(t, filepath.Join(artifactsDir, "tests", "suite_xprin", "stale", "inputs", "xr.yaml"), "kind: XR\n")
+
+	spec := &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "passes"},
+		{Name: "fails", ID: "fails-id"},
+	}}
+
+	options := &testexecutionUtils.Options{ArtifactsDir: artifactsDir}
+	r := NewRunner(options, "tests/suite_xprin.yaml", spec)
+	r.output = &bytes.Buffer{}
+
+	var dirs []string
+
+	r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+		dirs = append(dirs, r.testCaseArtifactsDir)
+
+		if testCase.Name == "passes" {
+			return createTestCaseResult(testCase.Name, false, nil)
+		}
+
+		// Keep artifacts for the failing test case, like runTestCase does
+		require.NoError(t, os.MkdirAll(r.testCaseArtifactsDir, 0o750))
+
+		result := createTestCaseResult(testCase.Name, false, errors.New("boom"))
+		result.ID = testCase.ID
+
+		return result
+	}
+
+	require.Error(t, r.RunTests())
+
+	assert.Equal(t, []string{
+		filepath.Join(artifactsDir, "tests", "suite_xprin", "passes"),
+		filepath.Join(artifactsDir, "tests", "suite_xprin", "fails-id"),
+	}, dirs)
+	assert.NoDirExists(t, filepath.Join(artifactsDir, "tests", "suite_xprin", "stale"))
+
+	data, err := os.ReadFile(filepath.Join(artifactsDir, ArtifactsIndexFile))
+	require.NoError(t, err)
+
+	var index ArtifactsIndex
+	require.NoError(t, json.Unmarshal(data, &index))
+	require.Len(t, index.Suites, 1)
+
+	suite := index.Suites[0]
+	assert.Equal(t, "tests/suite_xprin.yaml", suite.File)
+	assert.Equal(t, "tests/suite_xprin", suite.Path)
+	assert.Equal(t, engine.StatusFail().Value, suite.Status)
+	require.Len(t, suite.Tests, 2)
+
+	for i := range suite.Tests {
+		suite.Tests[i].Duration = 0
+	}
+
+	assert.Equal(t, ArtifactsTest{Name: "passes", Status: engine.StatusPass().Value}, suite.Tests[0])
+	assert.Equal(t, ArtifactsTest{Name: "fails", ID: "fails-id", Status: engine.StatusFail().Value, Error: "boom", Path: "tests/suite_xprin/fails-id"}, suite.Tests[1])
+
+	t.Run("runs with other labels are kept in the index", func(t *testing.T) {
+		other := NewRunner(&testexecutionUtils.Options{ArtifactsDir: artifactsDir, Label: "crossplane-2.0"}, "tests/suite_xprin.yaml", spec)
+		require.NoError(t, other.updateArtifactsIndex(engine.StatusPass(), nil))
+
+		// Re-running a testsuite replaces its entry
+		require.NoError(t, r.updateArtifactsIndex(engine.StatusPass(), nil))
+
+		data, err := os.ReadFile(filepath.Join(artifactsDir, ArtifactsIndexFile))
+		require.NoError(t, err)
+
+		var index ArtifactsIndex
+		require.NoError(t, json.Unmarshal(data, &index))
+		require.Len(t, index.Suites, 2)
+		assert.Empty(t, index.Suites[0].Label)
+		assert.Equal(t, engine.StatusPass().Value, index.Suites[0].Status)
+		assert.Equal(t, "crossplane-2.0", index.Suites[1].Label)
+		assert.Equal(t, "crossplane-2.0/tests/suite_xprin", index.Suites[1].Path)
+	})
+}
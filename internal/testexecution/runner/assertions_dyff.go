@@ -27,7 +27,7 @@ import (
 )
 
 // executeAssertionsDyff runs dyff assertions: compares actual output to expected (golden) file using the dyff library.
-// Uses shared resolve+read from the executor; builds ytbx.InputFile from bytes, calls dyff.CompareInputFiles; on mismatch uses dyff.HumanReport.
+// Uses shared resolve+read from the executor and dyffCompare; on mismatch the dyff.HumanReport is the assertion message.
 func (e *assertionExecutor) executeAssertionsDyff(assertions []api.AssertionGoldenFile) []engine.AssertionResult {
 	results := make([]engine.AssertionResult, 0, len(assertions))
 
@@ -38,43 +38,55 @@ func (e *assertionExecutor) executeAssertionsDyff(assertions []api.AssertionGold
 			continue
 		}
 
-		fromDocs, err := ytbx.LoadDocuments(expectedBytes)
+		report, err := dyffCompare("expected", expectedPath, expectedBytes, "actual", actualPath, actualBytes)
 		if err != nil {
-			results = append(results, engine.NewAssertionResult(a.Name, engine.StatusError(), fmt.Sprintf("load expected: %v", err)))
+			results = append(results, engine.NewAssertionResult(a.Name, engine.StatusError(), err.Error()))
 			continue
 		}
 
-		toDocs, err := ytbx.LoadDocuments(actualBytes)
-		if err != nil {
-			results = append(results, engine.NewAssertionResult(a.Name, engine.StatusError(), fmt.Sprintf("load actual: %v", err)))
+		if report == "" {
+			results = append(results, engine.NewAssertionResult(a.Name, engine.StatusPass(), "files match"))
 			continue
 		}
 
-		fromInput := ytbx.InputFile{Location: expectedPath, Documents: fromDocs}
-		toInput := ytbx.InputFile{Location: actualPath, Documents: toDocs}
+		// Pass raw output to the formatter (same as hooks); no trimming so ASCII art keeps its layout.
+		results = append(results, engine.NewAssertionResult(a.Name, engine.StatusFail(), report))
+	}
 
-		report, err := dyff.CompareInputFiles(fromInput, toInput)
-		if err != nil {
-			results = append(results, engine.NewAssertionResult(a.Name, engine.StatusError(), fmt.Sprintf("dyff compare: %v", err)))
-			continue
-		}
+	return results
+}
 
-		if len(report.Diffs) == 0 {
-			results = append(results, engine.NewAssertionResult(a.Name, engine.StatusPass(), "files match"))
-			continue
-		}
+// dyffCompare compares two YAML streams with the dyff library and returns the human-readable report, or "" when they match.
+// fromName and toName describe the inputs in error messages (e.g. "expected" and "actual").
+func dyffCompare(fromName, fromLocation string, fromBytes []byte, toName, toLocation string, toBytes []byte) (string, error) {
+	fromDocs, err := ytbx.LoadDocuments(fromBytes)
+	if err != nil {
+		return "", fmt.Errorf("load %s: %w", fromName, err)
+	}
 
-		var buf bytes.Buffer
+	toDocs, err := ytbx.LoadDocuments(toBytes)
+	if err != nil {
+		return "", fmt.Errorf("load %s: %w", toName, err)
+	}
 
-		human := &dyff.HumanReport{Report: report}
-		if err := human.WriteReport(&buf); err != nil {
-			results = append(results, engine.NewAssertionResult(a.Name, engine.StatusError(), fmt.Sprintf("dyff report: %v", err)))
-			continue
-		}
+	fromInput := ytbx.InputFile{Location: fromLocation, Documents: fromDocs}
+	toInput := ytbx.InputFile{Location: toLocation, Documents: toDocs}
 
-		// Pass raw output to the formatter (same as hooks); no trimming so ASCII art keeps its layout.
-		results = append(results, engine.NewAssertionResult(a.Name, engine.StatusFail(), buf.String()))
+	report, err := dyff.CompareInputFiles(fromInput, toInput)
+	if err != nil {
+		return "", fmt.Errorf("dyff compare: %w", err)
 	}
 
-	return results
+	if len(report.Diffs) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+
+	human := &dyff.HumanReport{Report: report}
+	if err := human.WriteReport(&buf); err != nil {
+		return "", fmt.Errorf("dyff report: %w", err)
+	}
+
+	return buf.String(), nil
 }
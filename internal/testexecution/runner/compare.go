@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+const (
+	gitCmd = "git"
+
+	// currentRenderLocation names the render of this run in comparison reports.
+	currentRenderLocation = "current"
+)
+
+// compareRender renders the test case again with the --compare-with baseline and compares both renders with dyff.
+// The baseline is the "from" side of the report, so the report shows what changed from the baseline to this run.
+// Differences fail the comparison and a baseline that cannot be rendered is an error; both are reported in the
+// Compare section. An error is returned only when the comparison outputs cannot be written.
+func (r *Runner) compareRender(result *engine.TestCaseResult, inputXR, sourceComposition string, inputs api.Inputs) error {
+	name := r.CompareWith.String()
+
+	var compareResult engine.AssertionResult
+
+	baseline, err := r.renderBaseline(inputXR, sourceComposition, inputs)
+	if err != nil {
+		compareResult = engine.NewAssertionResult(name, engine.StatusError(), err.Error())
+	} else {
+		baselineFile := filepath.Join(r.outputsDir, "compare-rendered.yaml")
+		if err := afero.WriteFile(r.fs, baselineFile, baseline, 0o600); err != nil {
+			return fmt.Errorf("failed to write baseline render to file: %w", err)
+		}
+
+		if r.Debug {
+			utils.DebugPrintf("Wrote baseline render to: %s\n", baselineFile)
+		}
+
+		report, err := dyffCompare("baseline", name, baseline, "render", currentRenderLocation, result.RawRenderOutput)
+
+		switch {
+		case err != nil:
+			compareResult = engine.NewAssertionResult(name, engine.StatusError(), err.Error())
+		case report == "":
+			compareResult = engine.NewAssertionResult(name, engine.StatusPass(), "renders match")
+		default:
+			compareResult = engine.NewAssertionResult(name, engine.StatusFail(), report)
+		}
+	}
+
+	result.CompareResult = &compareResult
+	result.ProcessCompareOutput()
+
+	compareFile := filepath.Join(r.outputsDir, "compare.txt")
+	if err := afero.WriteFile(r.fs, compareFile, []byte(result.RawCompareOutput), 0o600); err != nil {
+		return fmt.Errorf("failed to write comparison output to file: %w", err)
+	}
+
+	result.Outputs.Compare = &compareFile
+
+	if r.Debug {
+		utils.DebugPrintf("Wrote comparison output to: %s\n", compareFile)
+	}
+
+	return nil
+}
+
+// renderBaseline runs crossplane render with the --compare-with baseline swapped in: another crossplane dependency,
+// an alternate functions file, or the composition at another git ref. inputs holds the copied test case inputs.
+func (r *Runner) renderBaseline(inputXR, sourceComposition string, inputs api.Inputs) ([]byte, error) {
+	crossplane := r.Dependencies[r.CrossplaneDependency()]
+	composition := inputs.Composition
+	functions := inputs.Functions
+
+	switch r.CompareWith.Kind {
+	case testexecutionUtils.ComparisonDependency:
+		path, ok := r.Dependencies[r.CompareWith.Dependency]
+		if !ok {
+			return nil, fmt.Errorf("unknown dependency %s", r.CompareWith.Dependency)
+		}
+
+		crossplane = path
+	case testexecutionUtils.ComparisonFunctions:
+		functions = r.CompareWith.Functions
+	case testexecutionUtils.ComparisonGit:
+		var err error
+
+		composition, err = r.compositionAtRef(sourceComposition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	renderArgs := r.renderArgs(inputXR, composition, functions, inputs)
+
+	if r.Debug {
+		utils.DebugPrintf("Running baseline render command: %s %s\n", crossplane, strings.Join(renderArgs, " "))
+	}
+
+	output, err := r.runCommand(crossplane, renderArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("baseline render failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	return output, nil
+}
+
+// compositionAtRef writes the composition as it is at the --compare-with git ref to the inputs directory and returns its path.
+// The composition must be inside the configured repository the comparison refers to.
+func (r *Runner) compositionAtRef(composition string) (string, error) {
+	repository, ref := r.CompareWith.Repository, r.CompareWith.Ref
+
+	repositoryPath, ok := r.Repositories[repository]
+	if !ok {
+		return "", fmt.Errorf("repository %s is not configured", repository)
+	}
+
+	repositoryPath, err := utils.ExpandTildeAbs(repositoryPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand repository path %s: %w", repositoryPath, err)
+	}
+
+	rel, err := filepath.Rel(resolveSymlinks(repositoryPath), resolveSymlinks(composition))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("composition %s is not in repository %s (%s)", composition, repository, repositoryPath)
+	}
+
+	// "./" makes the path relative to the repository path, which may be a subdirectory of the git work tree
+	output, err := r.runCommand(gitCmd, "-C", repositoryPath, "show", fmt.Sprintf("%s:./%s", ref, filepath.ToSlash(rel)))
+	if err != nil {
+		return "", fmt.Errorf("failed to read composition at %s@%s: %w\n%s", repository, ref, err, strings.TrimSpace(string(output)))
+	}
+
+	dest := filepath.Join(r.inputsDir, "compare", filepath.Base(composition))
+	if err := r.fs.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create compare directory: %w", err)
+	}
+
+	if err := afero.WriteFile(r.fs, dest, output, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write composition at %s@%s: %w", repository, ref, err)
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Wrote composition at %s@%s to: %s\n", repository, ref, dest)
+	}
+
+	return dest, nil
+}
+
+// resolveSymlinks returns path with symlinks evaluated, or path itself if it cannot be evaluated.
+func resolveSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	return path
+}
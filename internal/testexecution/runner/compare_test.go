@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const (
+	compareRenderV1 = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\ndata:\n  replicas: \"1\"\n"
+	compareRenderV2 = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\ndata:\n  replicas: \"2\"\n"
+)
+
+// newCompareRunner returns a runner with an in-memory filesystem that compares every render with the given baseline.
+func newCompareRunner(t *testing.T, compareWith string) *Runner {
+	t.Helper()
+
+	comparison, err := testexecutionUtils.ParseComparison(compareWith)
+	require.NoError(t, err)
+
+	options := &testexecutionUtils.Options{
+		Dependencies: map[string]string{
+			"crossplane":     "/bin/crossplane",
+			"crossplane-2.0": "/bin/crossplane-2.0",
+		},
+		Repositories: map[string]string{"compositions": "/repos/compositions"},
+		Render:       []string{config.RenderSubcommand},
+		CompareWith:  comparison,
+	}
+
+	runner := newMockRunner(options)
+	runner.fs = afero.NewMemMapFs()
+	runner.testSuiteSpec = &api.TestSuiteSpec{}
+
+	return runner
+}
+
+func compareTestCase() api.TestCase {
+	return api.TestCase{
+		Name: "compare",
+		Inputs: api.Inputs{
+			XR:          "xr.yaml",
+			Composition: "/repos/compositions/aws/composition.yaml",
+			Functions:   "functions.yaml",
+		},
+	}
+}
+
+func TestRunTestCase_CompareWith(t *testing.T) {
+	t.Run("dependency with a different render fails with a dyff report", func(t *testing.T) {
+		runner := newCompareRunner(t, "crossplane-2.0")
+		runner.runCommand = func(name string, _ ...string) ([]byte, error) {
+			if name == "/bin/crossplane-2.0" {
+				return []byte(compareRenderV2), nil
+			}
+
+			return []byte(compareRenderV1), nil
+		}
+
+		result := runner.runTestCase(compareTestCase(), engine.NewTestSuiteResult(testSuiteFile, false))
+
+		assert.Equal(t, engine.StatusFail(), result.Status)
+		require.NotNil(t, result.CompareResult)
+		assert.Equal(t, "crossplane-2.0", result.CompareResult.Name)
+		assert.Equal(t, engine.StatusFail(), result.CompareResult.Status)
+		assert.Contains(t, result.CompareResult.Message, "data.replicas")
+		assert.Contains(t, result.FormattedCompareOutput, "    Compare:\n        [x] crossplane-2.0\n")
+
+		require.NotNil(t, result.Outputs.Compare)
+		assert.Equal(t, "compare.txt", filepath.Base(*result.Outputs.Compare))
+	})
+
+	t.Run("dependency with the same render passes", func(t *testing.T) {
+		runner := newCompareRunner(t, "crossplane-2.0")
+
+		var binaries []string
+
+		runner.runCommand = func(name string, _ ...string) ([]byte, error) {
+			binaries = append(binaries, name)
+			return []byte(compareRenderV1), nil
+		}
+
+		result := runner.runTestCase(compareTestCase(), engine.NewTestSuiteResult(testSuiteFile, false))
+
+		assert.Equal(t, engine.StatusPass(), result.Status)
+		assert.Equal(t, []string{"/bin/crossplane", "/bin/crossplane-2.0"}, binaries)
+		require.NotNil(t, result.CompareResult)
+		assert.Equal(t, engine.NewAssertionResult("crossplane-2.0", engine.StatusPass(), "renders match"), *result.CompareResult)
+	})
+
+	t.Run("functions file replaces the functions of the baseline render", func(t *testing.T) {
+		runner := newCompareRunner(t, "functions:/next/functions.yaml")
+
+		var functions []string
+
+		runner.runCommand = func(_ string, args ...string) ([]byte, error) {
+			functions = append(functions, args[3])
+			return []byte(compareRenderV1), nil
+		}
+
+		result := runner.runTestCase(compareTestCase(), engine.NewTestSuiteResult(testSuiteFile, false))
+
+		assert.Equal(t, engine.StatusPass(), result.Status)
+		require.Len(t, functions, 2)
+		assert.Equal(t, filepath.Join(runner.inputsDir, "functions", "functions.yaml"), functions[0])
+		assert.Equal(t, "/next/functions.yaml", functions[1])
+	})
+
+	t.Run("baseline render failure is an error", func(t *testing.T) {
+		runner := newCompareRunner(t, "crossplane-2.0")
+		runner.runCommand = func(name string, _ ...string) ([]byte, error) {
+			if name == "/bin/crossplane-2.0" {
+				return []byte("crossplane: error: unknown function"), errors.New("exit status 1")
+			}
+
+			return []byte(compareRenderV1), nil
+		}
+
+		result := runner.runTestCase(compareTestCase(), engine.NewTestSuiteResult(testSuiteFile, false))
+
+		assert.Equal(t, engine.StatusFail(), result.Status)
+		require.NotNil(t, result.CompareResult)
+		assert.Equal(t, engine.StatusError(), result.CompareResult.Status)
+		assert.Equal(t, "baseline render failed: exit status 1\ncrossplane: error: unknown function", result.CompareResult.Message)
+	})
+
+	t.Run("no comparison without --compare-with", func(t *testing.T) {
+		runner := newCompareRunner(t, "crossplane-2.0")
+		runner.CompareWith = nil
+		runner.runCommand = func(_ string, _ ...string) ([]byte, error) {
+			return []byte(compareRenderV1), nil
+		}
+
+		result := runner.runTestCase(compareTestCase(), engine.NewTestSuiteResult(testSuiteFile, false))
+
+		assert.Equal(t, engine.StatusPass(), result.Status)
+		assert.Nil(t, result.CompareResult)
+		assert.Nil(t, result.Outputs.Compare)
+	})
+}
+
+func TestCompositionAtRef(t *testing.T) {
+	t.Run("reads the composition at the ref relative to the repository", func(t *testing.T) {
+		runner := newCompareRunner(t, "git:compositions@v1.0.0")
+		runner.inputsDir = "/tmp/inputs"
+
+		var gitArgs []string
+
+		runner.runCommand = func(name string, args ...string) ([]byte, error) {
+			gitArgs = append([]string{name}, args...)
+			return []byte("kind: Composition\n"), nil
+		}
+
+		path, err := runner.compositionAtRef("/repos/compositions/aws/composition.yaml")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"git", "-C", "/repos/compositions", "show", "v1.0.0:./aws/composition.yaml"}, gitArgs)
+		assert.Equal(t, filepath.Join("/tmp/inputs", "compare", "composition.yaml"), path)
+
+		content, err := afero.ReadFile(runner.fs, path)
+		require.NoError(t, err)
+		assert.Equal(t, "kind: Composition\n", string(content))
+	})
+
+	t.Run("composition outside the repository", func(t *testing.T) {
+		runner := newCompareRunner(t, "git:compositions@v1.0.0")
+
+		_, err := runner.compositionAtRef("/elsewhere/composition.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "composition /elsewhere/composition.yaml is not in repository compositions")
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		runner := newCompareRunner(t, "git:other@v1.0.0")
+
+		_, err := runner.compositionAtRef("/repos/compositions/aws/composition.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "repository other is not configured")
+	})
+
+	t.Run("git failure", func(t *testing.T) {
+		runner := newCompareRunner(t, "git:compositions@missing")
+		runner.runCommand = func(_ string, _ ...string) ([]byte, error) {
+			return []byte("fatal: invalid object name 'missing'."), errors.New("exit status 128")
+		}
+
+		_, err := runner.compositionAtRef("/repos/compositions/aws/composition.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read composition at compositions@missing: exit status 128\nfatal: invalid object name 'missing'.")
+	})
+
+	t.Run("baseline render uses the composition at the ref", func(t *testing.T) {
+		runner := newCompareRunner(t, "git:compositions@v1.0.0")
+		runner.inputsDir = "/tmp/inputs"
+
+		var compositions []string
+
+		runner.runCommand = func(name string, args ...string) ([]byte, error) {
+			if name == "git" {
+				return []byte("kind: Composition\n"), nil
+			}
+
+			compositions = append(compositions, args[2])
+
+			return []byte(compareRenderV1), nil
+		}
+
+		_, err := runner.renderBaseline("xr.yaml", "/repos/compositions/aws/composition.yaml", compareTestCase().Inputs)
+		require.NoError(t, err)
+		assert.True(t, slices.Contains(compositions, filepath.Join("/tmp/inputs", "compare", "composition.yaml")))
+	})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/crossplane-contrib/xprin/internal/validator"
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// recordInputCoverage records the spec fields the input XR or Claim sets for the XRDs of the test case: the XRD
+// the XR is patched with and the XRDs among its CRDs.
+func (r *Runner) recordInputCoverage(input, xrd, crdsDir string) error {
+	if r.CoverageReport == nil {
+		return nil
+	}
+
+	var paths []string
+	if xrd != "" {
+		paths = append(paths, xrd)
+	}
+
+	exists, err := afero.DirExists(r.fs, crdsDir)
+	if err != nil {
+		return fmt.Errorf("failed to check CRDs directory: %w", err)
+	}
+
+	if exists {
+		paths = append(paths, crdsDir)
+	}
+
+	xrds, err := validator.LoadXRDs(r.fs, paths)
+	if err != nil {
+		return fmt.Errorf("failed to load XRDs for coverage: %w", err)
+	}
+
+	if len(xrds) == 0 {
+		return nil
+	}
+
+	inputs, err := validator.LoadResources(r.fs, input)
+	if err != nil {
+		return fmt.Errorf("failed to load input for coverage: %w", err)
+	}
+
+	if err := r.CoverageReport.AddInput(uniqueXRDs(xrds), inputs); err != nil {
+		return fmt.Errorf("failed to record coverage: %w", err)
+	}
+
+	return nil
+}
+
+// uniqueXRDs drops the XRDs with the name of an earlier one, e.g. an XRD that is both patched in and among the CRDs.
+func uniqueXRDs(xrds []*apiextensionsv1.CompositeResourceDefinition) []*apiextensionsv1.CompositeResourceDefinition {
+	seen := make(map[string]bool, len(xrds))
+	unique := make([]*apiextensionsv1.CompositeResourceDefinition, 0, len(xrds))
+
+	for _, xrd := range xrds {
+		if seen[xrd.GetName()] {
+			continue
+		}
+
+		seen[xrd.GetName()] = true
+		unique = append(unique, xrd)
+	}
+
+	return unique
+}
+
+// recordRenderCoverage records a render of the composition, identified by its path in the testsuite.
+func (r *Runner) recordRenderCoverage(path, composition string, rendered []*unstructured.Unstructured, succeeded bool) error {
+	if r.CoverageReport == nil {
+		return nil
+	}
+
+	if err := r.CoverageReport.AddRender(r.fs, path, composition, rendered, succeeded); err != nil {
+		return fmt.Errorf("failed to record coverage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/coverage"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestRecordInputCoverage(t *testing.T) {
+	newCoverageRunner := func(report *coverage.Report) *Runner {
+		r := NewRunner(&testexecutionUtils.Options{CoverageReport: report}, testSuiteFile, &api.TestSuiteSpec{})
+		r.fs = afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/xr/xr.yaml", []byte(validTestXR), 0o600))
+
+		return r
+	}
+
+	t.Run("XRDs of the patches and CRDs are counted once", func(t *testing.T) {
+		report := coverage.NewReport()
+		r := newCoverageRunner(report)
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/xrd/xrd.yaml", []byte(validateTestXRD), 0o600))
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/crds/xrd.yaml", []byte(validateTestXRD), 0o600))
+
+		require.NoError(t, r.recordInputCoverage("/inputs/xr/xr.yaml", "/inputs/xrd/xrd.yaml", "/inputs/crds"))
+
+		summary := report.Summary()
+		require.Len(t, summary.XRDs, 1)
+		assert.Equal(t, 1, summary.XRDs[0].Inputs)
+		assert.Equal(t, []coverage.Item{{Name: "spec.region", Covered: true}}, summary.XRDs[0].SpecFields.Items)
+	})
+
+	t.Run("no XRDs", func(t *testing.T) {
+		report := coverage.NewReport()
+		require.NoError(t, newCoverageRunner(report).recordInputCoverage("/inputs/xr/xr.yaml", "", "/inputs/crds"))
+		assert.Empty(t, report.Summary().XRDs)
+	})
+
+	t.Run("coverage disabled", func(t *testing.T) {
+		require.NoError(t, newCoverageRunner(nil).recordInputCoverage("/missing.yaml", "/missing.yaml", "/inputs/crds"))
+		require.NoError(t, newCoverageRunner(nil).recordRenderCoverage("/missing.yaml", "/missing.yaml", nil, true))
+	})
+}
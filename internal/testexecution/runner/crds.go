@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/xrdtocrd"
+	"github.com/crossplane-contrib/xprin/internal/packagecache"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/crossplane/crossplane/v2/cmd/crank/render"
+	"github.com/spf13/afero"
+)
+
+// crdsCacheDir returns the package cache directory the CRDs of crds-from inputs are loaded from.
+func (r *Runner) crdsCacheDir() (string, error) {
+	if r.CRDsCacheDir != "" {
+		return r.CRDsCacheDir, nil
+	}
+
+	dir, err := utils.ExpandTildeAbs(packagecache.DefaultDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand CRDs cache directory: %w", err)
+	}
+
+	return dir, nil
+}
+
+// copyCRDsFrom copies the cached package files of the crds-from inputs into the packages subdirectory of crdsDir,
+// so that they are validated against together with the crds inputs. It never downloads packages: a package that is
+// not in the CRDs cache fails the test case.
+func (r *Runner) copyCRDsFrom(crdsFrom []string, crdsDir string) error {
+	if len(crdsFrom) == 0 {
+		return nil
+	}
+
+	cacheDir, err := r.crdsCacheDir()
+	if err != nil {
+		return err
+	}
+
+	packages, err := packagecache.Packages(r.fs, crdsFrom)
+	if err != nil {
+		return err
+	}
+
+	paths, err := packagecache.Lookup(r.fs, cacheDir, packages)
+	if err != nil {
+		return err
+	}
+
+	// Every cached package file is named package.yaml, so name the copies after their package directories
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = filepath.Base(filepath.Dir(path)) + ".yaml"
+	}
+
+	uniqueNames := uniqueBaseNamesForPaths(names)
+	for i, path := range paths {
+		if r.Debug {
+			utils.DebugPrintf("Loading CRDs of package %s from %s\n", packages[i], path)
+		}
+
+		if _, err := r.copyToPath(path, filepath.Join(crdsDir, "packages", uniqueNames[i])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeXRDCRDs writes the CRDs Crossplane generates for the XRD in xrdPath, i.e. the CRD of its composite resource and
+// the CRD of its claim, into the xrd subdirectory of crdsDir.
+func (r *Runner) writeXRDCRDs(xrdPath, crdsDir string) error {
+	xrd, err := render.LoadXRD(r.fs, xrdPath)
+	if err != nil {
+		return fmt.Errorf("failed to load XRD %s: %w", xrdPath, err)
+	}
+
+	crds, err := xrdtocrd.ForXRD(xrd)
+	if err != nil {
+		return fmt.Errorf("failed to derive CRDs from XRD %s: %w", xrdPath, err)
+	}
+
+	data, err := xrdtocrd.Marshal(crds)
+	if err != nil {
+		return err
+	}
+
+	dest := filepath.Join(crdsDir, "xrd", filepath.Base(xrdPath))
+	if err := r.fs.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", dest, err)
+	}
+
+	if err := afero.WriteFile(r.fs, dest, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write CRDs of XRD %s: %w", xrdPath, err)
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Wrote CRDs of XRD %s to: %s\n", xrdPath, dest)
+	}
+
+	return nil
+}
+
+// CRDsPackages returns the packages the crds-from inputs of the test cases reference, in order and without
+// duplicates, i.e. the packages xprin crds pull downloads into the CRDs cache for this testsuite. The test cases
+// chained to the results of other test cases or testsuites are left out (see resolveTestCasesWithoutRunning).
+func (r *Runner) CRDsPackages() ([]string, error) {
+	testCases, err := r.resolveTestCasesWithoutRunning()
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []string
+
+	for _, testCase := range testCases {
+		testCasePackages, err := packagecache.Packages(r.fs, testCase.Inputs.CRDsFrom)
+		if err != nil {
+			return nil, fmt.Errorf("test case '%s': %w", testCase.Name, err)
+		}
+
+		for _, pkg := range testCasePackages {
+			if !slices.Contains(packages, pkg) {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+
+	return packages, nil
+}
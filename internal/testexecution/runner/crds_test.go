@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/packagecache"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const (
+	crdsTestProvider      = "xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"
+	crdsTestFunction      = "xpkg.crossplane.io/crossplane-contrib/function-go-templating:v0.11.0"
+	crdsTestConfiguration = `apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: platform
+spec:
+  dependsOn:
+    - provider: xpkg.crossplane.io/crossplane-contrib/provider-aws-s3
+      version: v2.0.0
+`
+)
+
+// newCRDsRunner returns a runner of a testsuite in dir, with a crossplane.yaml file and a CRDs cache in dir/cache.
+func newCRDsRunner(t *testing.T, dir string, spec *api.TestSuiteSpec) *Runner {
+	t.Helper()
+
+	unittestsUtils.WriteTestFile(t, filepath.Join(dir, "crossplane.yaml"), crdsTestConfiguration)
+	unittestsUtils.WriteTestFile(t, packagecache.Path(filepath.Join(dir, "cache"), crdsTestProvider), validateTestXRD)
+
+	return NewRunner(&testexecutionUtils.Options{CRDsCacheDir: filepath.Join(dir, "cache")}, filepath.Join(dir, "suite_xprin.yaml"), spec)
+}
+
+func TestCopyCRDsFrom(t *testing.T) {
+	t.Run("cached packages are copied into the CRDs directory", func(t *testing.T) {
+		dir := t.TempDir()
+		r := newCRDsRunner(t, dir, &api.TestSuiteSpec{})
+
+		require.NoError(t, r.copyCRDsFrom([]string{filepath.Join(dir, "crossplane.yaml"), crdsTestProvider}, filepath.Join(dir, "crds")))
+
+		data, err := afero.ReadFile(r.fs, filepath.Join(dir, "crds", "packages", "provider-aws-s3@v2.0.0.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, validateTestXRD, string(data))
+	})
+
+	t.Run("packages missing from the cache fail", func(t *testing.T) {
+		dir := t.TempDir()
+		r := newCRDsRunner(t, dir, &api.TestSuiteSpec{})
+
+		err := r.copyCRDsFrom([]string{crdsTestFunction}, filepath.Join(dir, "crds"))
+		require.EqualError(t, err, "packages not found in the CRDs cache "+filepath.Join(dir, "cache")+" (run xprin crds pull): "+crdsTestFunction)
+	})
+
+	t.Run("no crds-from inputs", func(t *testing.T) {
+		dir := t.TempDir()
+		r := newCRDsRunner(t, dir, &api.TestSuiteSpec{})
+
+		require.NoError(t, r.copyCRDsFrom(nil, filepath.Join(dir, "crds")))
+
+		exists, err := afero.Exists(r.fs, filepath.Join(dir, "crds"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRunner_CRDsPackages(t *testing.T) {
+	spec := &api.TestSuiteSpec{
+		Common: api.Common{Inputs: api.Inputs{Composition: "composition.yaml", Functions: "functions", CRDsFrom: []string{"crossplane.yaml"}}},
+		Tests: []api.TestCase{
+			{Name: "common", Inputs: api.Inputs{XR: "xr.yaml"}},
+			{Name: "own", Inputs: api.Inputs{XR: "xr.yaml", CRDsFrom: []string{crdsTestFunction, crdsTestProvider}}},
+		},
+	}
+
+	t.Run("packages of every test case without duplicates", func(t *testing.T) {
+		packages, err := newCRDsRunner(t, t.TempDir(), spec).CRDsPackages()
+		require.NoError(t, err)
+		assert.Equal(t, []string{crdsTestProvider, crdsTestFunction}, packages)
+		assert.Equal(t, []string{"crossplane.yaml"}, spec.Common.Inputs.CRDsFrom)
+	})
+
+	t.Run("chained test cases are skipped", func(t *testing.T) {
+		chained := &api.TestSuiteSpec{
+			Common: spec.Common,
+			Tests: []api.TestCase{
+				{Name: "first", ID: "first", Inputs: api.Inputs{XR: "xr.yaml"}},
+				{Name: "second", Inputs: api.Inputs{XR: placeholders("{{ .Tests.first.Outputs.XR }}"), CRDsFrom: []string{crdsTestProvider}}},
+			},
+		}
+
+		var (
+			packages []string
+			err      error
+		)
+
+		stderr := unittestsUtils.CaptureStderr(func() {
+			packages, err = newCRDsRunner(t, t.TempDir(), chained).CRDsPackages()
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{crdsTestProvider}, packages)
+		assert.Contains(t, stderr, "Skipping test case 'second'")
+	})
+
+	t.Run("missing package files fail", func(t *testing.T) {
+		dir := t.TempDir()
+		r := newCRDsRunner(t, dir, spec)
+		require.NoError(t, r.fs.Remove(filepath.Join(dir, "crossplane.yaml")))
+
+		_, err := r.CRDsPackages()
+		require.ErrorContains(t, err, "test case 'common': failed to read packages from "+filepath.Join(dir, "crossplane.yaml"))
+	})
+}
+
+func TestWriteXRDCRDs(t *testing.T) {
+	t.Run("CRDs of the XRD are written into the CRDs directory", func(t *testing.T) {
+		r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{})
+		r.fs = afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/xrd/xrd.yaml", []byte(validateTestXRD), 0o600))
+
+		require.NoError(t, r.writeXRDCRDs("/inputs/xrd/xrd.yaml", "/inputs/crds"))
+
+		data, err := afero.ReadFile(r.fs, "/inputs/crds/xrd/xrd.yaml")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "kind: CustomResourceDefinition")
+		assert.Contains(t, string(data), "name: xbuckets.example.org")
+		assert.Contains(t, string(data), "compositionRef:")
+	})
+
+	t.Run("missing XRD fails", func(t *testing.T) {
+		r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{})
+		r.fs = afero.NewMemMapFs()
+
+		err := r.writeXRDCRDs("/inputs/xrd/xrd.yaml", "/inputs/crds")
+		require.ErrorContains(t, err, "failed to load XRD /inputs/xrd/xrd.yaml")
+	})
+}
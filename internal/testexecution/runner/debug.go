@@ -73,6 +73,14 @@ func (r *Runner) debugPrintInputs(inputs api.Inputs) {
 		}
 	}
 
+	if len(inputs.CRDsFrom) > 0 {
+		utils.DebugPrintf("  - CRDs From:\n")
+
+		for _, entry := range inputs.CRDsFrom {
+			utils.DebugPrintf("    - %s\n", entry)
+		}
+	}
+
 	if len(inputs.ContextFiles) > 0 {
 		utils.DebugPrintf("  - Context Files:\n")
 
@@ -135,12 +143,24 @@ func (r *Runner) debugPrintHooks(hooks api.Hooks) {
 	}
 }
 
+// debugPrintVars prints template variables in a consistent format.
+func (r *Runner) debugPrintVars(vars map[string]string) {
+	if len(vars) > 0 {
+		utils.DebugPrintf("  Vars:\n")
+
+		for name, value := range vars {
+			utils.DebugPrintf("      %s: %s\n", name, value)
+		}
+	}
+}
+
 // debugPrintCommon prints debug information for common configuration.
 func (r *Runner) debugPrintCommon(common api.Common, header string) {
 	utils.DebugPrintf("%s\n", header)
 	r.debugPrintPatches(common.Patches)
 	r.debugPrintInputs(common.Inputs)
 	r.debugPrintHooks(common.Hooks)
+	r.debugPrintVars(common.Vars)
 }
 
 // debugPrintTestCase prints debug information for a test case.
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+)
+
+// testCaseOrder returns the indexes of the test cases in the order they run (see api.TestSuiteSpec.RunOrder).
+func (r *Runner) testCaseOrder() []int {
+	return r.testSuiteSpec.RunOrder()
+}
+
+// failedDependency returns why a test case is skipped because of the test cases in its depends-on or whose results it
+// references with {{ .Tests.<id> }}, or an empty string when all of them passed.
+func (r *Runner) failedDependency(testCase api.TestCase, testSuiteResult *engine.TestSuiteResult) string {
+	completed := testSuiteResult.GetCompletedTests()
+
+	for _, id := range r.dependencyIDs(testCase) {
+		result, ok := completed[id]
+
+		switch {
+		case !ok:
+			return fmt.Sprintf("dependency %s did not run", id)
+		case result.Status == engine.StatusSkip():
+			return fmt.Sprintf("dependency %s was skipped", id)
+		case result.Status != engine.StatusPass():
+			return fmt.Sprintf("dependency %s failed", id)
+		}
+	}
+
+	return ""
+}
+
+// dependencyIDs returns the IDs of the test cases in the depends-on of a test case, followed by the sorted IDs of the
+// other test cases of the testsuite whose results it references with {{ .Tests.<id> }}. References to unknown IDs are
+// left to the rendering of the templates, which reports them.
+func (r *Runner) dependencyIDs(testCase api.TestCase) []string {
+	if r.testSuiteSpec.HasCommon() {
+		testCase.MergeCommon(r.testSuiteSpec.Common)
+	}
+
+	var referenced []string
+
+	for _, id := range referencedTestCaseIDs(testCase) {
+		known := slices.ContainsFunc(r.testSuiteSpec.Tests, func(other api.TestCase) bool { return other.ID == id })
+		if known && id != testCase.ID && !slices.Contains(testCase.DependsOn, id) && !slices.Contains(referenced, id) {
+			referenced = append(referenced, id)
+		}
+	}
+
+	slices.Sort(referenced)
+
+	return slices.Concat(testCase.DependsOn, referenced)
+}
+
+// missingSuiteResult returns why a test case is skipped because it references {{ .Suites.<name>.Tests.<id> }} results
+// that the testsuites in depends-on did not produce, e.g. test cases not affected by --changed-since, or an empty
+// string when all of them ran.
+func (r *Runner) missingSuiteResult(testCase api.TestCase) string {
+	if r.testSuiteSpec.HasCommon() {
+		testCase.MergeCommon(r.testSuiteSpec.Common)
+	}
+
+	values := flattenTestCase(testCase)
+	paths := slices.Sorted(maps.Keys(values))
+
+	for _, path := range paths {
+		for _, match := range testexecutionUtils.SuiteTestReferenceRe.FindAllStringSubmatch(values[path], -1) {
+			name, id := match[1], match[2]
+
+			suite, ok := r.Suites[name]
+			if !ok {
+				continue // Not in depends-on, rendering the template reports it
+			}
+
+			var result *engine.TestCaseResult
+			if suite != nil {
+				result = suite.GetCompletedTests()[id]
+			}
+
+			switch {
+			case result == nil:
+				return fmt.Sprintf("test case %s of testsuite %s did not run", id, name)
+			case result.Status == engine.StatusSkip():
+				return fmt.Sprintf("test case %s of testsuite %s was skipped", id, name)
+			}
+		}
+	}
+
+	return ""
+}
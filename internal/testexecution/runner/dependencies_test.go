@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestTestCaseOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		tests []api.TestCase
+		want  []int
+	}{
+		{
+			name:  "file order without dependencies",
+			tests: []api.TestCase{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			want:  []int{0, 1, 2},
+		},
+		{
+			name: "dependencies run first",
+			tests: []api.TestCase{
+				{Name: "verify", DependsOn: []string{"upgrade"}},
+				{Name: "upgrade", ID: "upgrade", DependsOn: []string{"setup"}},
+				{Name: "unrelated"},
+				{Name: "setup", ID: "setup"},
+			},
+			want: []int{2, 3, 1, 0},
+		},
+		{
+			name: "unknown IDs and cycles do not block test cases",
+			tests: []api.TestCase{
+				{Name: "a", ID: "a", DependsOn: []string{"b", "missing"}},
+				{Name: "b", ID: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"missing"}},
+			},
+			want: []int{2, 0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Tests: tt.tests})
+			assert.Equal(t, tt.want, r.testCaseOrder())
+		})
+	}
+}
+
+func TestRunTests_DependsOn(t *testing.T) {
+	spec := &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "verify", DependsOn: []string{"upgrade"}},
+		{Name: "upgrade", ID: "upgrade", DependsOn: []string{"setup"}},
+		{Name: "setup", ID: "setup"},
+		{Name: "cleanup", ID: "cleanup", DependsOn: []string{"setup"}},
+	}}
+
+	r := NewRunner(&testexecutionUtils.Options{Verbose: true}, testSuiteFile, spec)
+
+	var out bytes.Buffer
+
+	r.output = &out
+
+	var ran []string
+
+	r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, testCase.Name)
+
+		var err error
+		if testCase.Name == "upgrade" {
+			err = errors.New("upgrade failed")
+		}
+
+		result := createTestCaseResult(testCase.Name, true, err)
+		result.ID = testCase.ID
+
+		return result
+	}
+
+	require.Error(t, r.RunTests())
+
+	assert.Equal(t, []string{"setup", "upgrade", "cleanup"}, ran)
+	assert.Contains(t, out.String(), "--- SKIP: verify")
+	assert.Contains(t, out.String(), "dependency upgrade failed")
+}
+
+func TestFailedDependency(t *testing.T) {
+	testSuiteResult := engine.NewTestSuiteResult(testSuiteFile, false)
+
+	for _, result := range []*engine.TestCaseResult{
+		createTestCaseResult("passed", false, nil),
+		createTestCaseResult("failed", false, errors.New("boom")),
+		engine.NewTestCaseResult("skipped", "", false, false, false, false, false).Skip("not selected"),
+	} {
+		result.ID = result.Name
+		testSuiteResult.AddResult(result)
+	}
+
+	r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{})
+
+	tests := []struct {
+		dependsOn []string
+		want      string
+	}{
+		{dependsOn: nil, want: ""},
+		{dependsOn: []string{"passed"}, want: ""},
+		{dependsOn: []string{"passed", "failed"}, want: "dependency failed failed"},
+		{dependsOn: []string{"skipped"}, want: "dependency skipped was skipped"},
+		{dependsOn: []string{"later"}, want: "dependency later did not run"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, r.failedDependency(api.TestCase{Name: "test", DependsOn: tt.dependsOn}, testSuiteResult))
+	}
+
+	t.Run("test cases referenced through their results", func(t *testing.T) {
+		r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Tests: []api.TestCase{
+			{Name: "passed", ID: "passed"},
+			{Name: "failed", ID: "failed"},
+			{Name: "skipped", ID: "skipped"},
+		}})
+
+		tests := []struct {
+			xr   string
+			want string
+		}{
+			{xr: "{{ .Tests.passed.Outputs.XR }}", want: ""},
+			{xr: `{{ (index .Tests "failed").Outputs.XR }}`, want: "dependency failed failed"},
+			{xr: "{{ .Tests.skipped.Outputs.XR }}", want: "dependency skipped was skipped"},
+			{xr: "{{ .Tests.unknown.Outputs.XR }}", want: ""},
+		}
+
+		for _, tt := range tests {
+			testCase := api.TestCase{Name: "test", Inputs: api.Inputs{XR: placeholders(tt.xr)}}
+			assert.Equal(t, tt.want, r.failedDependency(testCase, testSuiteResult), tt.xr)
+		}
+	})
+}
@@ -0,0 +1,467 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/format"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/gertd/go-pluralize"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// explainTestCaseDir stands for the temporary directory of a test case in the commands printed by Explain: the
+	// inputs are copied to its inputs directory and the outputs are written to its outputs directory.
+	explainTestCaseDir = "$TESTCASE_DIR"
+
+	// explainFuzzXR stands for the random XR of each run of a fuzz test case.
+	explainFuzzXR = "$FUZZ_DIR/<run>.yaml"
+)
+
+// shellSafeRe matches the command arguments that are printed without quotes.
+//
+//nolint:gochecknoglobals // compiled once
+var shellSafeRe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./$<>-]+$`)
+
+// explainSteps are the values of the fields of a test case at each step of its resolution, by path (e.g.
+// inputs.crds[0]), as returned by flattenTestCase.
+type explainSteps struct {
+	original map[string]string // As written in the test case
+	merged   map[string]string // With the common configuration merged
+	rendered map[string]string // With the template variables rendered
+	final    map[string]string // With the input paths expanded
+}
+
+// Explain prints the resolved specification of the test cases of the testsuite named test or with the ID test (all of
+// them when test is empty), without running anything: the fields it gets from common, the values of its template
+// variables and its expanded input paths, followed by the crossplane render and validate commands runTestCase would
+// run. It returns the number of test cases it explained, and an error when any of them could not run as specified.
+func (r *Runner) Explain(test string) (int, error) {
+	if r.testSuiteSpec == nil {
+		return 0, fmt.Errorf("testsuite specification is required")
+	}
+
+	if err := r.expandXRVariants(); err != nil {
+		return 0, err
+	}
+
+	defer r.Cleanup()
+
+	var explained, failed int
+
+	for _, testCase := range r.testSuiteSpec.Tests {
+		if test != "" && test != testCase.ID && test != testCase.Name && !strings.HasPrefix(testCase.Name, test+" [") {
+			continue
+		}
+
+		explained++
+
+		if !r.explainTestCase(testCase) {
+			failed++
+		}
+	}
+
+	if explained > 0 {
+		fmt.Fprintf(r.output, "%s is the temporary directory of each test case, its inputs are copied to before rendering\n", explainTestCaseDir) //nolint:errcheck // output function, error handling not practical
+	}
+
+	if failed > 0 {
+		return explained, fmt.Errorf("%s cannot run as specified", pluralize.NewClient().Pluralize("test case", failed, true))
+	}
+
+	return explained, nil
+}
+
+// explainTestCase prints the resolved specification of a test case and its commands, and returns false when it cannot
+// run as specified. The test case is resolved the same way runTestCase does, up to the copy of its inputs.
+func (r *Runner) explainTestCase(testCase api.TestCase) bool {
+	var (
+		steps    explainSteps
+		problems []string
+	)
+
+	fmt.Fprintf(r.output, "=== %s: %s\n", r.testSuiteFile, testCase.Name) //nolint:errcheck // output function, error handling not practical
+
+	steps.original = flattenTestCase(testCase)
+
+	if r.testSuiteSpec.HasCommon() {
+		testCase.MergeCommon(r.testSuiteSpec.Common)
+	}
+
+	// Do not expand the slices and maps shared with the testsuite specification in place
+	testCase.Inputs.CRDs = slices.Clone(testCase.Inputs.CRDs)
+	testCase.Inputs.CRDsFrom = slices.Clone(testCase.Inputs.CRDsFrom)
+	testCase.Inputs.ContextFiles = maps.Clone(testCase.Inputs.ContextFiles)
+
+	steps.merged = flattenTestCase(testCase)
+
+	// The results of other test cases and testsuites are only known once they ran
+	rendered := true
+	if err := r.processTemplateVariables(&testCase, engine.NewTestSuiteResult(r.testSuiteFile, false)); err != nil {
+		rendered = false
+
+		if !referencesResults(steps.merged) {
+			problems = append(problems, fmt.Sprintf("failed to process template variables: %v", err))
+		}
+	}
+
+	steps.rendered = flattenTestCase(testCase)
+
+	// Each run of a fuzz test case renders one of its random XRs
+	fuzz := needsFuzz(testCase)
+	if fuzz {
+		testCase.Inputs.XR = explainFuzzXR
+	}
+
+	if rendered {
+		if err := testCase.CheckMandatoryFields(); err != nil {
+			problems = append(problems, err.Error())
+		}
+
+		resolver := *r
+		resolver.expandPathRelativeToTestSuiteFile = func(base, path string) (string, error) {
+			if path == explainFuzzXR {
+				return path, nil
+			}
+
+			return r.expandPathRelativeToTestSuiteFile(base, path)
+		}
+		resolver.verifyPathExists = func(path string) error {
+			if path == explainFuzzXR {
+				return nil
+			}
+
+			return r.verifyPathExists(path)
+		}
+
+		_, failedExpandedPaths, unverifiedPaths := resolver.expandInputPaths(&testCase)
+		problems = append(problems, failedExpandedPaths...)
+		problems = append(problems, unverifiedPaths...)
+	}
+
+	steps.final = flattenTestCase(testCase)
+
+	spec, err := steps.annotatedSpec(testCase)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	fmt.Fprint(r.output, spec) //nolint:errcheck // output function, error handling not practical
+
+	switch {
+	case !testCase.Crossplane.Allows(r.CrossplaneDependency()):
+		fmt.Fprintf(r.output, "skip: not selected for %s\n", r.CrossplaneDependency()) //nolint:errcheck // output function, error handling not practical
+	case rendered:
+		r.explainCommands(testCase, fuzz)
+	case len(problems) == 0:
+		fmt.Fprintln(r.output, "templates: not rendered, they reference the results of other test cases or testsuites") //nolint:errcheck // output function, error handling not practical
+	}
+
+	if len(problems) > 0 {
+		fmt.Fprintf(r.output, "problems:\n  %s\n", strings.Join(problems, "\n  ")) //nolint:errcheck // output function, error handling not practical
+	}
+
+	fmt.Fprintln(r.output) //nolint:errcheck // output function, error handling not practical
+
+	return len(problems) == 0
+}
+
+// explainCommands prints the crossplane render and validate commands of a resolved test case, with the paths of its
+// inputs copied to the temporary directory of the test case (see explainTestCaseDir).
+func (r *Runner) explainCommands(testCase api.TestCase, fuzz bool) {
+	inputsDir := filepath.Join(explainTestCaseDir, "inputs")
+	outputsDir := filepath.Join(explainTestCaseDir, "outputs")
+	crdsDir := filepath.Join(inputsDir, "crds")
+
+	// copyInput copies every input to a directory of its type, with its original name
+	copied := func(path, inputType string) string {
+		if path == "" {
+			return ""
+		}
+
+		return filepath.Join(inputsDir, inputType, filepath.Base(path))
+	}
+
+	inputs := testCase.Inputs
+	inputs.ContextFiles = make(map[string]string, len(testCase.Inputs.ContextFiles))
+
+	for key, contextFile := range testCase.Inputs.ContextFiles {
+		inputs.ContextFiles[key] = copied(contextFile, "context-files")
+	}
+
+	inputs.ObservedResources = copied(inputs.ObservedResources, "observed-resources")
+	inputs.ExtraResources = copied(inputs.ExtraResources, "extra-resources")
+	inputs.FunctionCredentials = copied(inputs.FunctionCredentials, "function-credentials")
+
+	// Claims are converted to an XR, and XRs are patched, next to the inputs
+	inputXR := copied(testCase.Inputs.XR, "xr")
+	if !testCase.HasXR() {
+		inputXR = filepath.Join(inputsDir, "xr.yaml")
+	}
+
+	if testCase.HasPatches() {
+		inputXR = filepath.Join(inputsDir, "patched-xr.yaml")
+	}
+
+	crossplane := r.Dependencies[r.CrossplaneDependency()]
+	if crossplane == "" {
+		crossplane = r.CrossplaneDependency()
+	}
+
+	renderArgs := r.renderArgs(inputXR, copied(testCase.Inputs.Composition, "composition"), copied(testCase.Inputs.Functions, "functions"), inputs)
+	fmt.Fprintf(r.output, "render: %s\n", commandLine(crossplane, renderArgs)) //nolint:errcheck // output function, error handling not practical
+
+	if fuzz {
+		runs := testCase.Fuzz.Runs
+		if runs == 0 {
+			runs = defaultFuzzRuns
+		}
+
+		fmt.Fprintf(r.output, "fuzz: render runs with %s generated from %s\n", pluralize.NewClient().Pluralize("random XR", runs, true), testCase.Fuzz.XRD) //nolint:errcheck // output function, error handling not practical
+	}
+
+	switch {
+	case !testCase.HasCRDs():
+		fmt.Fprintln(r.output, "validate: skipped because no CRDs were specified") //nolint:errcheck // output function, error handling not practical
+	case r.BuiltinValidator:
+		fmt.Fprintf(r.output, "validate: built-in validator with the CRDs in %s\n", crdsDir) //nolint:errcheck // output function, error handling not practical
+	default:
+		validateArgs := make([]string, 0, len(r.Validate)+2)
+		validateArgs = append(validateArgs, r.Validate...)
+		validateArgs = append(validateArgs, crdsDir, filepath.Join(outputsDir, "rendered.yaml"))
+		fmt.Fprintf(r.output, "validate: %s\n", commandLine(crossplane, validateArgs)) //nolint:errcheck // output function, error handling not practical
+	}
+}
+
+// annotatedSpec returns the YAML specification of a resolved test case, ordered as in the testsuite specification,
+// with a comment on the fields it gets from common and on the values its template variables and path expansion changed.
+func (s *explainSteps) annotatedSpec(testCase api.TestCase) (string, error) {
+	data, err := yaml.Marshal(testCase)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal test case to YAML: %w", err)
+	}
+
+	doc := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(data, doc); err != nil {
+		return "", fmt.Errorf("failed to parse test case YAML: %w", err)
+	}
+
+	pruneEmptyFields(doc.Content[0])
+	format.TestCase(doc.Content[0])
+	s.annotate(doc.Content[0], "", false)
+
+	var b bytes.Buffer
+
+	encoder := yamlv3.NewEncoder(&b)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(doc); err != nil {
+		return "", fmt.Errorf("failed to marshal test case to YAML: %w", err)
+	}
+
+	return testexecutionUtils.RestoreTemplateVars(b.String()), nil
+}
+
+// pruneEmptyFields removes the fields with an empty mapping (e.g. the patches of a test case without patches) from a
+// mapping, and from the mappings under it.
+func pruneEmptyFields(node *yamlv3.Node) {
+	if node.Kind != yamlv3.MappingNode {
+		return
+	}
+
+	content := node.Content[:0]
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pruneEmptyFields(node.Content[i+1])
+
+		if node.Content[i+1].Kind == yamlv3.MappingNode && len(node.Content[i+1].Content) == 0 {
+			continue
+		}
+
+		content = append(content, node.Content[i], node.Content[i+1])
+	}
+
+	node.Content = content
+}
+
+// annotate annotates the fields under a node at the given path. Under a field from common, the fields are not
+// annotated as from common again.
+func (s *explainSteps) annotate(node *yamlv3.Node, path string, fromCommon bool) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			s.annotateField(node.Content[i], node.Content[i+1], joinFieldPath(path, node.Content[i].Value), fromCommon)
+		}
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			s.annotateField(nil, item, fmt.Sprintf("%s[%d]", path, i), fromCommon)
+		}
+	case yamlv3.DocumentNode, yamlv3.ScalarNode, yamlv3.AliasNode:
+		return
+	}
+}
+
+// annotateField annotates a field, given its key (nil for the items of a sequence) and value, and the fields under it.
+func (s *explainSteps) annotateField(key, value *yamlv3.Node, path string, fromCommon bool) {
+	var notes []string
+
+	if !fromCommon && hasFieldPath(s.merged, path) && !hasFieldPath(s.original, path) {
+		notes = append(notes, "from common")
+		fromCommon = true
+	}
+
+	if value.Kind == yamlv3.ScalarNode {
+		notes = append(notes, s.valueNotes(path)...)
+	}
+
+	// The comment goes after the value of scalars, and after the key (or the first key of items) of collections
+	comment := value
+	if value.Kind != yamlv3.ScalarNode {
+		switch {
+		case key != nil:
+			comment = key
+		case value.Kind == yamlv3.MappingNode && len(value.Content) > 0:
+			comment = value.Content[0]
+		}
+	}
+
+	if len(notes) > 0 {
+		comment.LineComment = strings.Join(notes, ", ")
+	}
+
+	s.annotate(value, path, fromCommon)
+}
+
+// valueNotes returns the notes on how the value of a field was resolved: rendered from a template and/or expanded from
+// a relative path.
+func (s *explainSteps) valueNotes(path string) []string {
+	var notes []string
+
+	final := s.final[path]
+	if final == explainFuzzXR {
+		return []string{"random XR of each fuzz run"}
+	}
+
+	merged, inMerged := s.merged[path]
+	rendered, inRendered := s.rendered[path]
+
+	if inMerged && inRendered && merged != rendered {
+		notes = append(notes, "rendered from "+merged)
+	}
+
+	if inRendered && rendered != final {
+		notes = append(notes, "expanded from "+rendered)
+	}
+
+	return notes
+}
+
+// flattenTestCase returns the values of the fields of a test case by path (e.g. inputs.crds[0]), with their template
+// expressions restored. Empty fields are left out.
+func flattenTestCase(testCase api.TestCase) map[string]string {
+	values := make(map[string]string)
+
+	data, err := yaml.Marshal(testCase)
+	if err != nil {
+		return values
+	}
+
+	var value any
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return values
+	}
+
+	flattenValue(values, "", value)
+
+	return values
+}
+
+// flattenValue adds the values under a value at the given path to values.
+func flattenValue(values map[string]string, path string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			flattenValue(values, joinFieldPath(path, key), item)
+		}
+	case []any:
+		for i, item := range v {
+			flattenValue(values, fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	case nil:
+		return
+	default:
+		values[path] = testexecutionUtils.RestoreTemplateVars(fmt.Sprint(v))
+	}
+}
+
+// referencesResults reports whether the fields of a test case, except its hooks, reference the results of other test
+// cases or testsuites, as {{ .Tests.<id> }} or {{ .Suites.<name> }}.
+func referencesResults(values map[string]string) bool {
+	for path, value := range values {
+		if !strings.HasPrefix(path, "hooks.") && (strings.Contains(value, ".Tests.") || strings.Contains(value, ".Suites.")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasFieldPath reports whether values has a value at the given path or under it.
+func hasFieldPath(values map[string]string, path string) bool {
+	for key := range values {
+		if key == path || strings.HasPrefix(key, path+".") || strings.HasPrefix(key, path+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinFieldPath returns the path of a field of the mapping at the given path.
+func joinFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
+
+// commandLine returns a command with its arguments as a shell command line, quoting the arguments that need it.
+func commandLine(name string, args []string) string {
+	words := make([]string, 0, len(args)+1)
+
+	for _, word := range append([]string{name}, args...) {
+		if !shellSafeRe.MatchString(word) {
+			word = "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+		}
+
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
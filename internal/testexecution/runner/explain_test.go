@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// newExplainRunner returns a runner explaining a testsuite in dir, with its output in output.
+func newExplainRunner(dir string, spec *api.TestSuiteSpec, output *bytes.Buffer) *Runner {
+	options := &testexecutionUtils.Options{
+		Dependencies: map[string]string{"crossplane": "/usr/local/bin/crossplane"},
+		Render:       []string{"render", "--include-full-xr"},
+		Validate:     []string{"beta", "validate"},
+		Vars:         map[string]string{"env": "dev"},
+		Output:       output,
+	}
+
+	return NewRunner(options, filepath.Join(dir, "suite_xprin.yaml"), spec)
+}
+
+func TestExplain(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"composition.yaml", "functions.yaml", "crd.yaml", "dev/xr.yaml", "claim.yaml", "xrd.yaml", "context.yaml"} {
+		unittestsUtils.WriteTestFile(t, filepath.Join(dir, name), "")
+	}
+
+	spec := &api.TestSuiteSpec{
+		Common: api.Common{
+			Inputs: api.Inputs{Composition: "composition.yaml", Functions: "functions.yaml", CRDs: []string{"crd.yaml"}},
+		},
+		Tests: []api.TestCase{
+			{
+				Name:   "xr",
+				Inputs: api.Inputs{XR: placeholders("{{ .Vars.env }}/xr.yaml"), ContextFiles: map[string]string{"env": "context.yaml"}},
+			},
+			{
+				Name:    "claim",
+				ID:      "claim",
+				Inputs:  api.Inputs{Claim: "claim.yaml", CRDs: []string{}},
+				Patches: api.Patches{XRD: "xrd.yaml"},
+			},
+		},
+	}
+
+	t.Run("prints the resolved test cases and their commands", func(t *testing.T) {
+		var output bytes.Buffer
+
+		explained, err := newExplainRunner(dir, spec, &output).Explain("")
+		require.NoError(t, err)
+		assert.Equal(t, 2, explained)
+
+		file := filepath.Join(dir, "suite_xprin.yaml")
+		assert.Equal(t, `=== `+file+`: xr
+name: xr
+inputs:
+  xr: `+dir+`/dev/xr.yaml # rendered from {{ .Vars.env }}/xr.yaml, expanded from dev/xr.yaml
+  composition: `+dir+`/composition.yaml # from common, expanded from composition.yaml
+  functions: `+dir+`/functions.yaml # from common, expanded from functions.yaml
+  crds: # from common
+    - `+dir+`/crd.yaml # expanded from crd.yaml
+  context-files:
+    env: `+dir+`/context.yaml # expanded from context.yaml
+render: /usr/local/bin/crossplane render --include-full-xr $TESTCASE_DIR/inputs/xr/xr.yaml $TESTCASE_DIR/inputs/composition/composition.yaml $TESTCASE_DIR/inputs/functions/functions.yaml --context-files env=$TESTCASE_DIR/inputs/context-files/context.yaml
+validate: /usr/local/bin/crossplane beta validate $TESTCASE_DIR/inputs/crds $TESTCASE_DIR/outputs/rendered.yaml
+
+=== `+file+`: claim
+name: claim
+id: claim
+inputs:
+  claim: `+dir+`/claim.yaml # expanded from claim.yaml
+  composition: `+dir+`/composition.yaml # from common, expanded from composition.yaml
+  functions: `+dir+`/functions.yaml # from common, expanded from functions.yaml
+  crds: # from common
+    - `+dir+`/crd.yaml # expanded from crd.yaml
+patches:
+  xrd: `+dir+`/xrd.yaml # expanded from xrd.yaml
+render: /usr/local/bin/crossplane render --include-full-xr $TESTCASE_DIR/inputs/patched-xr.yaml $TESTCASE_DIR/inputs/composition/composition.yaml $TESTCASE_DIR/inputs/functions/functions.yaml
+validate: /usr/local/bin/crossplane beta validate $TESTCASE_DIR/inputs/crds $TESTCASE_DIR/outputs/rendered.yaml
+
+$TESTCASE_DIR is the temporary directory of each test case, its inputs are copied to before rendering
+`, output.String())
+
+		// The testsuite specification is left as it was
+		assert.Equal(t, placeholders("{{ .Vars.env }}/xr.yaml"), spec.Tests[0].Inputs.XR)
+		assert.Equal(t, "context.yaml", spec.Tests[0].Inputs.ContextFiles["env"])
+	})
+
+	t.Run("only the given test case", func(t *testing.T) {
+		var output bytes.Buffer
+
+		explained, err := newExplainRunner(dir, spec, &output).Explain("claim")
+		require.NoError(t, err)
+		assert.Equal(t, 1, explained)
+		assert.Contains(t, output.String(), ": claim\n")
+		assert.NotContains(t, output.String(), ": xr\n")
+
+		explained, err = newExplainRunner(dir, spec, &output).Explain("missing")
+		require.NoError(t, err)
+		assert.Equal(t, 0, explained)
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		var output bytes.Buffer
+
+		problems := &api.TestSuiteSpec{
+			Tests: []api.TestCase{
+				{
+					Name:   "missing inputs",
+					Inputs: api.Inputs{XR: "missing.yaml", Composition: "composition.yaml", Functions: "functions.yaml"},
+				},
+				{
+					Name:   "undefined variable",
+					Inputs: api.Inputs{XR: placeholders("{{ .Vars.undefined }}/xr.yaml"), Composition: "composition.yaml", Functions: "functions.yaml"},
+				},
+				{
+					Name:   "chained",
+					Inputs: api.Inputs{XR: placeholders("{{ .Tests.first.Outputs.XR }}"), Composition: "composition.yaml", Functions: "functions.yaml"},
+				},
+				{
+					Name:       "not selected",
+					Inputs:     api.Inputs{XR: "dev/xr.yaml", Composition: "composition.yaml", Functions: "functions.yaml"},
+					Crossplane: api.CrossplaneSelector{Only: []string{"crossplane-2.0"}},
+				},
+			},
+		}
+
+		explained, err := newExplainRunner(dir, problems, &output).Explain("")
+		require.EqualError(t, err, "2 test cases cannot run as specified")
+		assert.Equal(t, 4, explained)
+
+		assert.Contains(t, output.String(), "problems:\n  XR file not found: ")
+		assert.Contains(t, output.String(), `problems:
+  failed to process template variables: failed to render template: failed to execute template:`)
+		assert.Contains(t, output.String(), "  xr: {{ .Tests.first.Outputs.XR }}\n")
+		assert.Contains(t, output.String(), "templates: not rendered, they reference the results of other test cases or testsuites\n")
+		assert.Contains(t, output.String(), "skip: not selected for crossplane\n")
+	})
+}
+
+func TestCommandLine(t *testing.T) {
+	assert.Equal(t, "crossplane render xr.yaml --context-values 'key=a value' 'it'\\''s'", commandLine("crossplane", []string{"render", "xr.yaml", "--context-values", "key=a value", "it's"}))
+}
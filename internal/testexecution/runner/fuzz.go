@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/generatexr"
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultFuzzRuns is the number of random XRs a fuzz test case runs with when its runs are not set.
+	defaultFuzzRuns = 10
+
+	// defaultFuzzFixtures is the directory counterexamples are written to, relative to the testsuite file, when the
+	// fixtures of a fuzz test case are not set.
+	defaultFuzzFixtures = "testdata/fuzz"
+
+	// maxFuzzShrinkRuns is the maximum number of runs spent shrinking a failing XR.
+	maxFuzzShrinkRuns = 50
+)
+
+// fuzzRun runs a fuzz test case with generated XRs.
+type fuzzRun struct {
+	r               *Runner
+	testCase        api.TestCase
+	testSuiteResult *engine.TestSuiteResult
+	dir             string
+	runs            int
+}
+
+// runFuzzTestCase runs a test case with random XRs generated from the XRD of its fuzz configuration, until one fails.
+// The failing XR is shrunk to the simplest XR that still fails the same way, which is written to the fixtures
+// directory so that it can be used as the xr input of a regular test case. The result is that of the last run, or of
+// the shrunk XR, with the fuzz summary.
+func (r *Runner) runFuzzTestCase(testCase api.TestCase, testSuiteResult *engine.TestSuiteResult) *engine.TestCaseResult {
+	fuzz := *testCase.Fuzz
+
+	runs := fuzz.Runs
+	if runs == 0 {
+		runs = defaultFuzzRuns
+	}
+
+	fuzzer, err := r.newFuzzer(testCase.Name, fuzz)
+	if err != nil {
+		return r.failFuzzTestCase(testCase, fmt.Errorf("failed to generate random XRs: %w", err))
+	}
+
+	dir, err := afero.TempDir(r.fs, "", "xprin-fuzz-")
+	if err != nil {
+		return r.failFuzzTestCase(testCase, fmt.Errorf("failed to create fuzz directory: %w", err))
+	}
+
+	defer func() { _ = r.fs.RemoveAll(dir) }()
+
+	f := &fuzzRun{r: r, testCase: testCase, testSuiteResult: testSuiteResult, dir: dir}
+
+	var result *engine.TestCaseResult
+
+	for run := 1; run <= runs; run++ {
+		xr := fuzzer.Next(run)
+
+		// A dry run prepares the test case with a single random XR
+		result = f.run(xr)
+		if result.Status == engine.StatusSkip() || r.DryRun {
+			return result
+		}
+
+		if result.Status != engine.StatusFail() {
+			continue
+		}
+
+		if !isCounterexample(result) {
+			return withFuzzResult(result, fuzz.Seed, engine.StatusFail(), fmt.Sprintf("run %d of %d failed", run, runs))
+		}
+
+		if r.Debug {
+			utils.DebugPrintf("Fuzz run %d of test case '%s' failed, shrinking its XR\n", run, testCase.Name)
+		}
+
+		xr, result, steps := f.shrink(fuzzer, xr, result)
+
+		fixture, err := r.writeFuzzFixture(testCase, fuzz, run, xr)
+		if err != nil {
+			return withFuzzResult(result, fuzz.Seed, engine.StatusError(), fmt.Sprintf("run %d of %d failed, shrunk in %d steps, failed to write counterexample: %v", run, runs, steps, err))
+		}
+
+		return withFuzzResult(result, fuzz.Seed, engine.StatusFail(), fmt.Sprintf("run %d of %d failed, shrunk in %d steps, counterexample written to %s", run, runs, steps, fixture))
+	}
+
+	return withFuzzResult(result, fuzz.Seed, engine.StatusPass(), fmt.Sprintf("%d runs passed", runs))
+}
+
+// needsFuzz reports whether a test case has a fuzz configuration and was not given one of its random XRs yet.
+func needsFuzz(testCase api.TestCase) bool {
+	return testCase.Fuzz != nil && !testCase.HasXR()
+}
+
+// newFuzzer returns the fuzzer of the XRD of a fuzz configuration, resolved relative to the testsuite file.
+func (r *Runner) newFuzzer(name string, fuzz api.Fuzz) (*generatexr.Fuzzer, error) {
+	path, err := r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, fuzz.XRD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand XRD path: %w", err)
+	}
+
+	data, err := afero.ReadFile(r.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XRD: %w", err)
+	}
+
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	if err := yaml.Unmarshal(data, xrd); err != nil {
+		return nil, fmt.Errorf("failed to parse XRD %s: %w", path, err)
+	}
+
+	return generatexr.NewFuzzer(xrd, generatexr.Options{Version: fuzz.Version, Name: name}, fuzz.Seed)
+}
+
+// failFuzzTestCase returns the failed result of a fuzz test case that could not run.
+func (r *Runner) failFuzzTestCase(testCase api.TestCase, err error) *engine.TestCaseResult {
+	result := engine.NewTestCaseResult(testCase.Name, testCase.ID, r.Verbose, r.ShowRender, r.ShowValidate, r.ShowHooks, r.ShowAssertions)
+	result.Label = r.Label
+
+	return result.Fail(err)
+}
+
+// run runs the test case with an XR.
+func (f *fuzzRun) run(xr *unstructured.Unstructured) *engine.TestCaseResult {
+	f.runs++
+
+	file := filepath.Join(f.dir, fmt.Sprintf("%d.yaml", f.runs))
+
+	data, err := yaml.Marshal(xr.Object)
+	if err != nil {
+		return f.r.failFuzzTestCase(f.testCase, fmt.Errorf("failed to marshal random XR: %w", err))
+	}
+
+	if err := afero.WriteFile(f.r.fs, file, data, 0o600); err != nil {
+		return f.r.failFuzzTestCase(f.testCase, fmt.Errorf("failed to write random XR: %w", err))
+	}
+
+	// runTestCase replaces the input paths in place, so the runs cannot share them
+	testCase := f.testCase
+	testCase.Inputs.XR = file
+	testCase.Inputs.CRDs = slices.Clone(f.testCase.Inputs.CRDs)
+	testCase.Inputs.CRDsFrom = slices.Clone(f.testCase.Inputs.CRDsFrom)
+	testCase.Inputs.ContextFiles = maps.Clone(f.testCase.Inputs.ContextFiles)
+
+	return f.r.runTestCase(testCase, f.testSuiteResult)
+}
+
+// shrink returns the simplest XR found that is simpler than a failing XR and still fails the same way, with its
+// result and the number of steps it took. Shrinking stops after maxFuzzShrinkRuns runs.
+func (f *fuzzRun) shrink(fuzzer *generatexr.Fuzzer, xr *unstructured.Unstructured, result *engine.TestCaseResult) (*unstructured.Unstructured, *engine.TestCaseResult, int) {
+	steps, shrinkRuns := 0, 0
+	lastFailed := true
+
+shrinking:
+	for {
+		for _, candidate := range fuzzer.Shrink(xr) {
+			if shrinkRuns == maxFuzzShrinkRuns {
+				break shrinking
+			}
+
+			shrinkRuns++
+
+			candidateResult := f.run(candidate)
+			lastFailed = candidateResult.Status == engine.StatusFail() && sameFailure(result, candidateResult)
+
+			if lastFailed {
+				xr, result = candidate, candidateResult
+				steps++
+
+				continue shrinking
+			}
+		}
+
+		break
+	}
+
+	// Run the shrunk XR again when a simpler passing XR ran last, so that the artifacts are those of the shrunk XR
+	if !lastFailed && f.r.ArtifactsDir != "" {
+		result = f.run(xr)
+	}
+
+	return xr, result, steps
+}
+
+// isCounterexample reports whether a failed result failed because of its XR: in the input validation, the render,
+// the validation, the comparison, the assertions or the post-test hooks, rather than before running.
+func isCounterexample(result *engine.TestCaseResult) bool {
+	return result.HasFailedInputValidate || result.HasFailedRender || result.HasPipelineFailure()
+}
+
+// sameFailure reports whether two failed results failed in the same phases.
+func sameFailure(a, b *engine.TestCaseResult) bool {
+	return a.HasFailedInputValidate == b.HasFailedInputValidate &&
+		a.HasFailedRender == b.HasFailedRender &&
+		a.HasFailedValidate == b.HasFailedValidate &&
+		a.HasFailedCompare == b.HasFailedCompare &&
+		a.HasFailedAssertions == b.HasFailedAssertions &&
+		a.HasFailedPostTestHooks == b.HasFailedPostTestHooks
+}
+
+// withFuzzResult adds the fuzz summary of a fuzz test case to its result.
+func withFuzzResult(result *engine.TestCaseResult, seed int64, status engine.Status, message string) *engine.TestCaseResult {
+	fuzzResult := engine.NewAssertionResult(fmt.Sprintf("seed %d", seed), status, message)
+	result.FuzzResult = &fuzzResult
+	result.ProcessFuzzOutput()
+
+	return result
+}
+
+// writeFuzzFixture writes the shrunk XR of a fuzz test case to its fixtures directory, named after the test case and
+// the seed, and returns its path.
+func (r *Runner) writeFuzzFixture(testCase api.TestCase, fuzz api.Fuzz, run int, xr *unstructured.Unstructured) (string, error) {
+	fixtures := fuzz.Fixtures
+	if fixtures == "" {
+		fixtures = defaultFuzzFixtures
+	}
+
+	dir, err := r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, fixtures)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand fixtures path: %w", err)
+	}
+
+	if err := r.fs.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(xr.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal counterexample: %w", err)
+	}
+
+	name := strings.Trim(unsafeNameChars.ReplaceAllString(testCase.Name, "-"), "-.")
+	if name == "" {
+		name = "test"
+	}
+
+	file := filepath.Join(dir, fmt.Sprintf("%s-seed-%d.yaml", name, fuzz.Seed))
+	header := fmt.Sprintf("# Counterexample of test case '%s' (fuzz seed %d, run %d)\n", testCase.Name, fuzz.Seed, run)
+
+	if err := afero.WriteFile(r.fs, file, append([]byte(header), data...), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write counterexample: %w", err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"sigs.k8s.io/yaml"
+)
+
+const fuzzTestXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.org
+spec:
+  group: example.org
+  names:
+    kind: XBucket
+    plural: xbuckets
+  versions:
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [region]
+            properties:
+              region:
+                type: string
+                enum: [eu-west-1, us-east-1]
+              size:
+                type: integer
+                minimum: 1
+                maximum: 100
+              tags:
+                type: object
+                additionalProperties:
+                  type: string
+`
+
+func TestRunFuzzTestCase(t *testing.T) {
+	newFuzzRunner := func(run func(spec map[string]any) *engine.TestCaseResult) (*Runner, *[]map[string]any) {
+		r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{})
+		r.fs = afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(r.fs, "/xrd.yaml", []byte(fuzzTestXRD), 0o600))
+
+		var specs []map[string]any
+
+		r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+			data, err := afero.ReadFile(r.fs, testCase.Inputs.XR)
+			require.NoError(t, err)
+
+			xr := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(data, &xr))
+
+			spec, _ := xr["spec"].(map[string]any)
+			specs = append(specs, spec)
+
+			return run(spec)
+		}
+
+		return r, &specs
+	}
+
+	failLargeBuckets := func(spec map[string]any) *engine.TestCaseResult {
+		result := createTestCaseResult("fuzzed", false, nil)
+		if size, ok := spec["size"].(float64); ok && size > 50 {
+			result.HasFailedAssertions = true
+			result.Fail(errors.New("bucket too large"))
+		}
+
+		return result
+	}
+
+	t.Run("passing runs", func(t *testing.T) {
+		r, specs := newFuzzRunner(func(map[string]any) *engine.TestCaseResult {
+			return createTestCaseResult("fuzzed", false, nil)
+		})
+
+		result := r.runTestCase(api.TestCase{Name: "fuzzed", Fuzz: &api.Fuzz{XRD: "xrd.yaml", Runs: 5, Seed: 3}}, nil)
+
+		assert.Equal(t, engine.StatusPass(), result.Status)
+		assert.Len(t, *specs, 5)
+		require.NotNil(t, result.FuzzResult)
+		assert.Equal(t, engine.NewAssertionResult("seed 3", engine.StatusPass(), "5 runs passed"), *result.FuzzResult)
+
+		for _, spec := range *specs {
+			assert.Contains(t, []any{"eu-west-1", "us-east-1"}, spec["region"])
+		}
+	})
+
+	t.Run("runs are reproducible", func(t *testing.T) {
+		pass := func(map[string]any) *engine.TestCaseResult { return createTestCaseResult("fuzzed", false, nil) }
+		testCase := api.TestCase{Name: "fuzzed", Fuzz: &api.Fuzz{XRD: "xrd.yaml", Seed: 3}}
+
+		first, firstSpecs := newFuzzRunner(pass)
+		first.runTestCase(testCase, nil)
+
+		second, secondSpecs := newFuzzRunner(pass)
+		second.runTestCase(testCase, nil)
+
+		assert.Len(t, *firstSpecs, defaultFuzzRuns)
+		assert.Equal(t, *firstSpecs, *secondSpecs)
+	})
+
+	t.Run("failing run is shrunk to a fixture", func(t *testing.T) {
+		r, _ := newFuzzRunner(failLargeBuckets)
+
+		result := r.runTestCase(api.TestCase{Name: "large buckets", Fuzz: &api.Fuzz{XRD: "xrd.yaml", Runs: 50, Seed: 1}}, nil)
+
+		assert.Equal(t, engine.StatusFail(), result.Status)
+		require.NotNil(t, result.FuzzResult)
+		assert.Equal(t, engine.StatusFail(), result.FuzzResult.Status)
+		assert.Contains(t, result.FuzzResult.Message, "counterexample written to /testdata/fuzz/large-buckets-seed-1.yaml")
+		assert.Contains(t, result.FormattedFuzzOutput, "    Fuzz:\n        [x] seed 1 - run ")
+
+		data, err := afero.ReadFile(r.fs, "/testdata/fuzz/large-buckets-seed-1.yaml")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "# Counterexample of test case 'large buckets' (fuzz seed 1, run "))
+
+		xr := map[string]any{}
+		require.NoError(t, yaml.Unmarshal(data, &xr))
+
+		spec, _ := xr["spec"].(map[string]any)
+		assert.Equal(t, "eu-west-1", spec["region"], "the shrunk XR has the minimal values of the fields not causing the failure")
+		assert.NotContains(t, spec, "tags", "the shrunk XR has no optional fields not causing the failure")
+		assert.Greater(t, spec["size"], float64(50))
+	})
+
+	t.Run("fixtures directory", func(t *testing.T) {
+		r, _ := newFuzzRunner(failLargeBuckets)
+
+		result := r.runTestCase(api.TestCase{Name: "large", Fuzz: &api.Fuzz{XRD: "xrd.yaml", Runs: 50, Seed: 1, Fixtures: "fixtures"}}, nil)
+
+		assert.Contains(t, result.FuzzResult.Message, "counterexample written to /fixtures/large-seed-1.yaml")
+	})
+
+	t.Run("failures before running are not shrunk", func(t *testing.T) {
+		r, specs := newFuzzRunner(func(map[string]any) *engine.TestCaseResult {
+			return createTestCaseResult("fuzzed", false, errors.New("missing mandatory field: composition"))
+		})
+
+		result := r.runTestCase(api.TestCase{Name: "fuzzed", Fuzz: &api.Fuzz{XRD: "xrd.yaml"}}, nil)
+
+		assert.Equal(t, engine.StatusFail(), result.Status)
+		assert.Len(t, *specs, 1)
+		assert.Equal(t, engine.NewAssertionResult("seed 0", engine.StatusFail(), "run 1 of 10 failed"), *result.FuzzResult)
+	})
+
+	t.Run("skipped runs", func(t *testing.T) {
+		r, specs := newFuzzRunner(func(map[string]any) *engine.TestCaseResult {
+			return createTestCaseResult("fuzzed", false, nil).Skip("not meant to run")
+		})
+
+		result := r.runTestCase(api.TestCase{Name: "fuzzed", Fuzz: &api.Fuzz{XRD: "xrd.yaml"}}, nil)
+
+		assert.Equal(t, engine.StatusSkip(), result.Status)
+		assert.Len(t, *specs, 1)
+		assert.Nil(t, result.FuzzResult)
+	})
+
+	t.Run("missing XRD", func(t *testing.T) {
+		r, _ := newFuzzRunner(failLargeBuckets)
+
+		result := r.runTestCase(api.TestCase{Name: "fuzzed", Fuzz: &api.Fuzz{XRD: "missing.yaml"}}, nil)
+
+		assert.Equal(t, engine.StatusFail(), result.Status)
+		require.ErrorContains(t, result.Error, "failed to generate random XRs: failed to read XRD")
+	})
+}
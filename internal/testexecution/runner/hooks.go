@@ -31,6 +31,8 @@ import (
 // hookExecutor handles execution of hooks.
 type hookExecutor struct {
 	repositories   map[string]string
+	vars           map[string]string
+	suites         map[string]*suiteTemplateContext // Results of the testsuites in depends-on, by name
 	debug          bool
 	runCommand     func(name string, args ...string) ([]byte, error)
 	renderTemplate func(content string, templateContext *templateContext, templateName string) (string, error)
@@ -39,12 +41,14 @@ type hookExecutor struct {
 // newHookExecutor creates a new hook executor.
 func newHookExecutor(
 	repositories map[string]string,
+	vars map[string]string,
 	debug bool,
 	runCommand func(name string, args ...string) ([]byte, error),
 	renderTemplate func(content string, templateContext *templateContext, templateName string) (string, error),
 ) *hookExecutor {
 	return &hookExecutor{
 		repositories:   repositories,
+		vars:           vars,
 		debug:          debug,
 		runCommand:     runCommand,
 		renderTemplate: renderTemplate,
@@ -62,7 +66,10 @@ func (e *hookExecutor) processHookTemplateVariables(hook api.Hook, inputs api.In
 	}
 
 	commandWithTemplateVars = testexecutionUtils.RestoreTemplateVars(hook.Run)
-	context := newTemplateContext(e.repositories, inputs, outputs, tests)
+	context := newTemplateContext(e.repositories, e.vars, inputs, outputs, tests)
+	if e.suites != nil {
+		context.Suites = e.suites
+	}
 
 	finalCommand, err = e.renderTemplate(commandWithTemplateVars, context, "hook")
 	if err != nil {
@@ -70,7 +70,7 @@ func TestExecuteHooks(t *testing.T) {
 	}
 
 	// Execute hooks (pre-test hooks with outputs=nil)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
+	hookExecutor := newHookExecutor(repositories, nil, false, runCommand, renderTemplate)
 	results, err := hookExecutor.executeHooks(hooks, "test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
@@ -108,7 +108,7 @@ func TestExecuteHooks_Order(t *testing.T) {
 	}
 
 	// Execute hooks
-	hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
+	hookExecutor := newHookExecutor(nil, nil, false, runCommand, renderTemplate)
 	_, err := hookExecutor.executeHooks(hooks, "test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 
@@ -145,7 +145,7 @@ func TestExecuteHooks_HookFailure(t *testing.T) {
 		}
 
 		// Execute hooks - should fail
-		hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
+		hookExecutor := newHookExecutor(nil, nil, false, runCommand, renderTemplate)
 		_, err := hookExecutor.executeHooks(hooks, "test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		require.Error(t, err)
 
@@ -170,7 +170,7 @@ func TestExecuteHooks_HookFailure(t *testing.T) {
 		}
 
 		// Execute hooks - should fail
-		hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
+		hookExecutor := newHookExecutor(nil, nil, false, runCommand, renderTemplate)
 		_, err := hookExecutor.executeHooks(hooks, "pre-test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		require.Error(t, err)
 
@@ -231,7 +231,7 @@ func TestExecuteHooks_PostTestHooks(t *testing.T) {
 	}
 
 	// Execute hooks (post-test hooks with outputs != nil)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
+	hookExecutor := newHookExecutor(repositories, nil, false, runCommand, renderTemplate)
 	results, err := hookExecutor.executeHooks(hooks, "post-test", inputs, outputs, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
@@ -289,7 +289,7 @@ func TestExecuteHooks_PreTestHooks_InputsTemplateVariables(t *testing.T) {
 	}
 
 	// Execute hooks (pre-test hooks with outputs=nil, inputs available)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
+	hookExecutor := newHookExecutor(repositories, nil, false, runCommand, renderTemplate)
 	results, err := hookExecutor.executeHooks(hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 3)
@@ -329,7 +329,7 @@ func TestExecuteHooks_PreTestHooks_WithoutTemplateVariables(t *testing.T) {
 		return content, nil
 	}
 
-	hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
+	hookExecutor := newHookExecutor(nil, nil, false, runCommand, renderTemplate)
 	results, err := hookExecutor.executeHooks(hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
@@ -372,7 +372,7 @@ func TestExecuteHooks_PreTestHooks_OutputsTemplateVariables(t *testing.T) {
 
 	// Execute hooks with outputs=nil (pre-test scenario)
 	// This should fail because Outputs template variables cannot be resolved when outputs is nil
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
+	hookExecutor := newHookExecutor(repositories, nil, false, runCommand, renderTemplate)
 	results, err := hookExecutor.executeHooks(hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.Error(t, err)
 	// Results should contain the HookResult for the template rendering failure
@@ -443,7 +443,7 @@ func TestExecuteHooks_PostTestHooks_InputsAndOutputsTemplateVariables(t *testing
 	}
 
 	// Execute hooks (post-test hooks with outputs != nil - this enables template processing)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
+	hookExecutor := newHookExecutor(repositories, nil, false, runCommand, renderTemplate)
 	results, err := hookExecutor.executeHooks(hooks, "post-test", inputs, outputs, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 3)
@@ -499,7 +499,7 @@ func TestExecuteHooks_WorkingDirectory(t *testing.T) {
 	}
 
 	// Execute hooks
-	hookExecutor := newHookExecutor(nil, false, runner.runCommand, runner.renderTemplate)
+	hookExecutor := newHookExecutor(nil, nil, false, runner.runCommand, runner.renderTemplate)
 	results, err := hookExecutor.executeHooks(hooks, "pre-test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 
 	require.NoError(t, err)
@@ -513,7 +513,7 @@ func TestProcessHookTemplateVariables(t *testing.T) {
 	renderTemplate := func(content string, _ *templateContext, _ string) (string, error) {
 		return content, nil
 	}
-	exec := newHookExecutor(nil, false, nil, renderTemplate)
+	exec := newHookExecutor(nil, nil, false, nil, renderTemplate)
 
 	t.Run("no placeholders returns command as-is", func(t *testing.T) {
 		hook := api.Hook{Name: "h", Run: "echo hello"}
@@ -530,7 +530,7 @@ func TestProcessHookTemplateVariables(t *testing.T) {
 			rendered = content
 			return "echo /path", nil
 		}
-		exec := newHookExecutor(map[string]string{"r": "/path"}, false, nil, renderTemplate)
+		exec := newHookExecutor(map[string]string{"r": "/path"}, nil, false, nil, renderTemplate)
 		hook := api.Hook{Run: testexecutionUtils.CreatePlaceholder(".Repositories.r")}
 		final, cmdVars, err := exec.processHookTemplateVariables(hook, api.Inputs{}, nil, nil)
 		require.NoError(t, err)
@@ -539,11 +539,21 @@ func TestProcessHookTemplateVariables(t *testing.T) {
 		assert.Equal(t, "{{.Repositories.r}}", rendered)
 	})
 
+	t.Run("vars are passed to the template context", func(t *testing.T) {
+		runner := &Runner{}
+		exec := newHookExecutor(nil, map[string]string{"branch": "main"}, false, nil, runner.renderTemplate)
+		hook := api.Hook{Run: "git checkout " + testexecutionUtils.CreatePlaceholder(".Vars.branch")}
+		final, cmdVars, err := exec.processHookTemplateVariables(hook, api.Inputs{}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "git checkout main", final)
+		assert.Equal(t, "git checkout {{.Vars.branch}}", cmdVars)
+	})
+
 	t.Run("render error is returned", func(t *testing.T) {
 		renderTemplate := func(string, *templateContext, string) (string, error) {
 			return "", fmt.Errorf("render failed")
 		}
-		exec := newHookExecutor(nil, false, nil, renderTemplate)
+		exec := newHookExecutor(nil, nil, false, nil, renderTemplate)
 		hook := api.Hook{Run: testexecutionUtils.CreatePlaceholder(".X")}
 		_, _, err := exec.processHookTemplateVariables(hook, api.Inputs{}, nil, nil)
 		require.Error(t, err)
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/mutation"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+)
+
+// Compositions returns the resolved paths of the compositions the test cases use, in order and without duplicates,
+// i.e. the compositions xprin mutate mutates for this testsuite. The test cases chained to the results of other test
+// cases or testsuites are left out (see resolveTestCasesWithoutRunning).
+func (r *Runner) Compositions() ([]string, error) {
+	resolved, err := r.resolveTestCasesWithoutRunning()
+	if err != nil {
+		return nil, err
+	}
+
+	var compositions []string
+
+	for _, testCase := range resolved {
+		if !slices.Contains(compositions, testCase.Inputs.Composition) {
+			compositions = append(compositions, testCase.Inputs.Composition)
+		}
+	}
+
+	return compositions, nil
+}
+
+// mutateComposition applies the mutation of Options.Mutant to the copy of the composition in the inputs directory,
+// when the test case uses the mutated composition, so that the composition itself is never modified.
+func (r *Runner) mutateComposition(source, composition string) error {
+	if r.Mutant == nil || source != r.Mutant.Composition {
+		return nil
+	}
+
+	if err := mutation.ApplyFile(r.fs, composition, r.Mutant.Mutation); err != nil {
+		return err
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Mutated composition: %s\n", r.Mutant.Mutation)
+	}
+
+	r.mutated = true
+
+	return nil
+}
+
+// recordMutant records the result of a test case that ran with the mutated composition of Options.Mutant. Skipped
+// test cases did not run with it.
+func (r *Runner) recordMutant(result *engine.TestCaseResult) {
+	if !r.mutated {
+		return
+	}
+
+	r.mutated = false
+
+	if result.Status == engine.StatusSkip() {
+		return
+	}
+
+	r.Mutant.AddResult(fmt.Sprintf("%s: %s", r.testSuiteFile, result.Name), result.Status == engine.StatusFail())
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/mutation"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const mutateTestComposition = `apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: xbuckets
+spec:
+  mode: Pipeline
+  pipeline:
+    - step: patch-and-transform
+      functionRef:
+        name: function-patch-and-transform
+    - step: auto-ready
+      functionRef:
+        name: function-auto-ready
+`
+
+func TestCompositions(t *testing.T) {
+	r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{
+		Common: api.Common{Inputs: api.Inputs{Composition: "composition.yaml", Functions: "functions"}},
+		Tests: []api.TestCase{
+			{Name: "first", Inputs: api.Inputs{XR: "xr.yaml"}},
+			{Name: "second", Inputs: api.Inputs{XR: "xr.yaml", Composition: "other/composition.yaml"}},
+			{Name: "third", Inputs: api.Inputs{XR: "xr.yaml"}},
+		},
+	})
+	r.fs = afero.NewMemMapFs()
+
+	compositions, err := r.Compositions()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/composition.yaml", "/other/composition.yaml"}, compositions)
+
+	t.Run("chained test cases are skipped", func(t *testing.T) {
+		r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{
+			Common: api.Common{Inputs: api.Inputs{Composition: "composition.yaml", Functions: "functions"}},
+			Tests: []api.TestCase{
+				{Name: "first", ID: "first", Inputs: api.Inputs{XR: "xr.yaml"}},
+				{Name: "second", Inputs: api.Inputs{XR: placeholders("{{ .Tests.first.Outputs.XR }}"), Composition: "other/composition.yaml"}},
+			},
+		})
+		r.fs = afero.NewMemMapFs()
+
+		var compositions []string
+
+		stderr := unittestsUtils.CaptureStderr(func() {
+			compositions, err = r.Compositions()
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/composition.yaml"}, compositions)
+		assert.Contains(t, stderr, "Skipping test case 'second' of "+testSuiteFile+": its templates reference the results of other test cases or testsuites")
+	})
+
+	t.Run("invalid test case", func(t *testing.T) {
+		r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Tests: []api.TestCase{{Name: "invalid"}}})
+
+		_, err := r.Compositions()
+		require.ErrorContains(t, err, "failed to resolve test case 'invalid'")
+	})
+}
+
+func TestMutateComposition(t *testing.T) {
+	newMutateRunner := func(mutant *mutation.Mutant) *Runner {
+		r := NewRunner(&testexecutionUtils.Options{Mutant: mutant}, testSuiteFile, &api.TestSuiteSpec{})
+		r.fs = afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(r.fs, "/composition.yaml", []byte(mutateTestComposition), 0o600))
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/composition.yaml", []byte(mutateTestComposition), 0o600))
+
+		return r
+	}
+
+	removeStep := func() mutation.Mutation {
+		composition := map[string]any{"spec": map[string]any{"pipeline": []any{
+			map[string]any{"step": "patch-and-transform"},
+			map[string]any{"step": "auto-ready"},
+		}}}
+
+		return mutation.Mutations(composition)[0]
+	}
+
+	t.Run("copy of the mutated composition", func(t *testing.T) {
+		mutant := mutation.NewMutant("/composition.yaml", removeStep())
+		r := newMutateRunner(mutant)
+
+		require.NoError(t, r.mutateComposition("/composition.yaml", "/inputs/composition.yaml"))
+		assert.True(t, r.mutated)
+
+		original, err := afero.ReadFile(r.fs, "/composition.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, mutateTestComposition, string(original), "the composition itself is never modified")
+
+		mutated, err := mutation.Load(r.fs, "/inputs/composition.yaml")
+		require.NoError(t, err)
+		assert.Len(t, mutated["spec"].(map[string]any)["pipeline"], 1)
+
+		r.recordMutant(createTestCaseResult("killing", false, errors.New("assertion failed")))
+		assert.False(t, r.mutated)
+		assert.Equal(t, []string{testSuiteFile + ": killing"}, mutant.KilledBy())
+	})
+
+	t.Run("other compositions are not mutated", func(t *testing.T) {
+		mutant := mutation.NewMutant("/other.yaml", removeStep())
+		r := newMutateRunner(mutant)
+
+		require.NoError(t, r.mutateComposition("/composition.yaml", "/inputs/composition.yaml"))
+		assert.False(t, r.mutated)
+
+		r.recordMutant(createTestCaseResult("passing", false, nil))
+		assert.Empty(t, mutant.Ran())
+	})
+
+	t.Run("skipped test cases are not recorded", func(t *testing.T) {
+		mutant := mutation.NewMutant("/composition.yaml", removeStep())
+		r := newMutateRunner(mutant)
+
+		require.NoError(t, r.mutateComposition("/composition.yaml", "/inputs/composition.yaml"))
+		r.recordMutant(createTestCaseResult("skipped", false, nil).Skip("not meant to run"))
+		assert.Empty(t, mutant.Ran())
+	})
+
+	t.Run("passing test cases", func(t *testing.T) {
+		mutant := mutation.NewMutant("/composition.yaml", removeStep())
+		r := newMutateRunner(mutant)
+
+		require.NoError(t, r.mutateComposition("/composition.yaml", "/inputs/composition.yaml"))
+		r.recordMutant(createTestCaseResult("passing", false, nil))
+		assert.Equal(t, []string{testSuiteFile + ": passing"}, mutant.Ran())
+		assert.Empty(t, mutant.KilledBy())
+	})
+
+	t.Run("without mutant", func(t *testing.T) {
+		r := newMutateRunner(nil)
+
+		require.NoError(t, r.mutateComposition("/composition.yaml", "/inputs/composition.yaml"))
+		assert.False(t, r.mutated)
+		r.recordMutant(engine.NewTestCaseResult("passing", "", false, false, false, false, false))
+	})
+}
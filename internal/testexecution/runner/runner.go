@@ -20,14 +20,17 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
 	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/packagecache"
 	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/crossplane-contrib/xprin/internal/utils"
 	"github.com/gertd/go-pluralize"
@@ -45,11 +48,15 @@ type Runner struct {
 	testSuiteFile    string
 	testSuiteFileDir string
 	output           io.Writer
+	testSuiteResult  *engine.TestSuiteResult // Results of the test cases, once RunTests ran them
 	// Directory paths
 	inputsDir             string
 	outputsDir            string
 	testCaseTmpDir        string
 	testSuiteArtifactsDir string
+	testCaseArtifactsDir  string // Directory the artifacts of the running test case are kept in (empty without --artifacts-dir)
+	xrVariantsDir         string // Directory of the XRs generated for the test cases with xr-variants
+	mutated               bool   // Whether the running test case runs with the mutated Composition of Options.Mutant
 	// Mockable function fields
 	runTestsFunc                      func() error
 	runTestCaseFunc                   func(api.TestCase) *engine.TestCaseResult
@@ -71,15 +78,29 @@ type templateContext struct {
 	Outputs *engine.Outputs
 	// Cross-test references (available in hooks)
 	Tests map[string]*engine.TestCaseResult // Test ID to test case result mapping
+	// Cross-testsuite references to the testsuites in depends-on
+	Suites map[string]*suiteTemplateContext // Testsuite name to testsuite results mapping
+	// User-defined variables (config file, testsuite common vars, --var/--var-file)
+	Vars map[string]string // Variable name to value mapping
+}
+
+// suiteTemplateContext provides the results of a testsuite the testsuite depends on, as {{ .Suites.<name> }}.
+type suiteTemplateContext struct {
+	Tests map[string]*engine.TestCaseResult // Test ID to test case result mapping
 }
 
 // NewRunner creates a new test runner.
 func NewRunner(options *testexecutionUtils.Options, testSuiteFile string, testSuiteSpec *api.TestSuiteSpec) *Runner {
 	testSuiteFileDir := filepath.Dir(testSuiteFile)
 
+	var output io.Writer = os.Stdout // Default output to stdout
+	if options != nil && options.Output != nil {
+		output = options.Output
+	}
+
 	return &Runner{
 		fs:               afero.NewOsFs(),
-		output:           os.Stdout, // Default output to stdout
+		output:           output,
 		Options:          options,
 		testSuiteFile:    testSuiteFile,
 		testSuiteFileDir: testSuiteFileDir,
@@ -107,16 +128,70 @@ func NewRunner(options *testexecutionUtils.Options, testSuiteFile string, testSu
 }
 
 // newTemplateContext creates a new template context with the given parameters.
-func newTemplateContext(repositories map[string]string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) *templateContext {
+func newTemplateContext(repositories, vars map[string]string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) *templateContext {
 	if repositories == nil {
 		repositories = make(map[string]string)
 	}
 
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+
 	return &templateContext{
 		Repositories: repositories,
 		Inputs:       inputs,
 		Outputs:      outputs,
 		Tests:        tests,
+		Suites:       make(map[string]*suiteTemplateContext),
+		Vars:         vars,
+	}
+}
+
+// suiteTemplateContexts returns the template contexts of the testsuites the testsuite depends on, by name.
+func (r *Runner) suiteTemplateContexts() map[string]*suiteTemplateContext {
+	suites := make(map[string]*suiteTemplateContext, len(r.Suites))
+
+	for name, result := range r.Suites {
+		// A testsuite that ran no test cases (e.g. none affected by --changed-since) has no result, and the test cases
+		// it skipped have no outputs (see missingSuiteResult)
+		tests := make(map[string]*engine.TestCaseResult)
+		if result != nil {
+			tests = result.GetCompletedTests()
+			maps.DeleteFunc(tests, func(_ string, testCaseResult *engine.TestCaseResult) bool {
+				return testCaseResult.Status == engine.StatusSkip()
+			})
+		}
+
+		suites[name] = &suiteTemplateContext{Tests: tests}
+	}
+
+	return suites
+}
+
+// templateVars returns the template variables for the testsuite with precedence CLI over testsuite over config.
+func (r *Runner) templateVars() map[string]string {
+	var suiteVars map[string]string
+	if r.testSuiteSpec != nil {
+		suiteVars = r.testSuiteSpec.Common.Vars
+	}
+
+	return testexecutionUtils.MergeVars(r.ConfigVars, suiteVars, r.Vars)
+}
+
+// TestSuiteResult returns the results of the test cases run by RunTests, or nil when RunTests ran no test cases.
+func (r *Runner) TestSuiteResult() *engine.TestSuiteResult {
+	return r.testSuiteResult
+}
+
+// Cleanup removes the testsuite artifacts directory that named testsuites keep after RunTests, so that the testsuites
+// depending on them can use the outputs of their test cases, and the XRs generated for xr-variants.
+func (r *Runner) Cleanup() {
+	if r.testSuiteArtifactsDir != "" {
+		_ = r.fs.RemoveAll(r.testSuiteArtifactsDir)
+	}
+
+	if r.xrVariantsDir != "" {
+		_ = r.fs.RemoveAll(r.xrVariantsDir)
 	}
 }
 
@@ -135,7 +210,39 @@ func (r *Runner) RunTests() error {
 		return fmt.Errorf("testsuite specification is required")
 	}
 
-	// Create testsuite artifacts directory (always created, cleaned up when testsuite finishes)
+	if err := r.checkShardable(); err != nil {
+		return err
+	}
+
+	if err := r.expandXRVariants(); err != nil {
+		return err
+	}
+
+	selected := r.selectTestCases()
+	inShard := r.shardTestCases()
+
+	// Test cases of other shards run elsewhere and are left out of this run entirely
+	for i := range selected {
+		selected[i] = selected[i] && inShard[i]
+	}
+
+	if r.List {
+		r.printSelection(selected)
+		return nil
+	}
+
+	if r.ShardTotal > 0 && !slices.Contains(inShard, true) {
+		fmt.Fprintf(os.Stderr, "?   \t%s\t[no test cases in this shard]\n", r.testSuiteFile)
+		return nil
+	}
+
+	if r.ChangedSince != "" && !slices.Contains(selected, true) {
+		fmt.Fprintf(os.Stderr, "?   \t%s\t[no affected test cases]\n", r.testSuiteFile)
+		return nil
+	}
+
+	// Create testsuite artifacts directory (always created, cleaned up when testsuite finishes unless other testsuites
+	// can reference its outputs by name, in which case Cleanup removes it)
 	var err error
 
 	r.testSuiteArtifactsDir, err = afero.TempDir(r.fs, "", "xprin-testsuite-artifacts-")
@@ -143,9 +250,9 @@ func (r *Runner) RunTests() error {
 		return fmt.Errorf("failed to create testsuite artifacts directory: %w", err)
 	}
 
-	defer func() {
-		_ = r.fs.RemoveAll(r.testSuiteArtifactsDir)
-	}()
+	if r.testSuiteSpec.Name == "" {
+		defer r.Cleanup()
+	}
 
 	if r.Debug {
 		utils.DebugPrintf("Created testsuite artifacts directory: %s\n", r.testSuiteArtifactsDir)
@@ -166,21 +273,82 @@ func (r *Runner) RunTests() error {
 
 	// Create test suite result
 	testSuiteResult := engine.NewTestSuiteResult(r.testSuiteFile, r.Verbose)
+	testSuiteResult.Label = r.Label
+	r.testSuiteResult = testSuiteResult
+
+	var (
+		artifactsNames []string
+		artifactsTests []ArtifactsTest
+	)
+
+	if r.ArtifactsDir != "" {
+		artifactsNames = testCaseArtifactsNames(r.testSuiteSpec.Tests)
+
+		// Remove the artifacts of previous runs of the testsuite
+		if err := r.fs.RemoveAll(filepath.Join(r.ArtifactsDir, filepath.FromSlash(r.artifactsSuitePath()))); err != nil {
+			return fmt.Errorf("failed to remove previous artifacts of testsuite %s: %w", r.testSuiteFile, err)
+		}
+	}
+
+	// Loop through all test cases, after the test cases they depend on, and run them directly
+	for _, i := range r.testCaseOrder() {
+		testCase := r.testSuiteSpec.Tests[i]
+		if !inShard[i] {
+			continue
+		}
+
+		skipReason := r.failedDependency(testCase, testSuiteResult)
+		if skipReason == "" {
+			skipReason = r.missingSuiteResult(testCase)
+		}
+
+		if !selected[i] {
+			skipReason = fmt.Sprintf("not affected by changes since %s", r.ChangedSince)
+		}
+
+		if skipReason != "" {
+			testCaseResult := engine.NewTestCaseResult(testCase.Name, testCase.ID, r.Verbose, r.ShowRender, r.ShowValidate, r.ShowHooks, r.ShowAssertions)
+			testCaseResult.Label = r.Label
+			testCaseResult.Skip(skipReason)
+			testCaseResult.Print(r.output)
+			testSuiteResult.AddResult(testCaseResult)
+
+			if r.ArtifactsDir != "" {
+				artifactsTests = append(artifactsTests, r.artifactsTest(testCaseResult, artifactsNames[i]))
+			}
+
+			continue
+		}
+
+		if r.ArtifactsDir != "" {
+			r.testCaseArtifactsDir = filepath.Join(r.ArtifactsDir, filepath.FromSlash(r.artifactsSuitePath()), artifactsNames[i])
+		}
 
-	// Loop through all test cases and run them directly
-	for _, testCase := range r.testSuiteSpec.Tests {
 		// Run the test and let the engine handle everything
 		testCaseResult := r.runTestCase(testCase, testSuiteResult)
+		r.recordMutant(testCaseResult)
 		testCaseResult.Print(r.output) // Print immediately as test completes
 		testSuiteResult.AddResult(testCaseResult)
+
+		if r.ArtifactsDir != "" {
+			artifactsTests = append(artifactsTests, r.artifactsTest(testCaseResult, artifactsNames[i]))
+		}
 	}
 
+	r.testCaseArtifactsDir = ""
+
 	// Complete the test suite result
 	testSuiteResult.Complete()
 
 	// Print only the file summary (not individual test results)
 	testSuiteResult.Print(r.output)
 
+	if r.ArtifactsDir != "" {
+		if err := r.updateArtifactsIndex(testSuiteResult.Status, artifactsTests); err != nil {
+			return err
+		}
+	}
+
 	// Return error if any tests failed
 	if testSuiteResult.HasFailures() {
 		return fmt.Errorf("tests failed in testsuite %s", filepath.Base(r.testSuiteFile))
@@ -193,6 +361,10 @@ func (r *Runner) RunTests() error {
 //
 //nolint:gocognit // Complex test case execution with multiple validation and execution phases
 func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.TestSuiteResult) *engine.TestCaseResult {
+	if needsFuzz(testCase) {
+		return r.runFuzzTestCase(testCase, testSuiteResult)
+	}
+
 	if r.runTestCaseFunc != nil {
 		return r.runTestCaseFunc(testCase)
 	}
@@ -202,6 +374,26 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 	}
 
 	result := engine.NewTestCaseResult(testCase.Name, testCase.ID, r.Verbose, r.ShowRender, r.ShowValidate, r.ShowHooks, r.ShowAssertions)
+	result.Label = r.Label
+
+	if r.testSuiteSpec.HasCommon() {
+		testCase.MergeCommon(r.testSuiteSpec.Common)
+	}
+
+	// Skip the test case if it is not meant to run with the selected crossplane dependency
+	if !testCase.Crossplane.Allows(r.CrossplaneDependency()) {
+		if r.Debug {
+			utils.DebugPrintf("Skipping test case '%s' because it is not selected for %s\n", testCase.Name, r.CrossplaneDependency())
+		}
+
+		return result.Skip(fmt.Sprintf("not selected for %s", r.CrossplaneDependency()))
+	}
+
+	// A dry run has no results of other test cases and testsuites to render the templates referencing them with
+	if r.DryRun && referencesResults(flattenTestCase(testCase)) {
+		return result.WouldRun("not checked: its templates reference the results of other test cases or testsuites")
+	}
+
 	// Create a temporary directory for the test case (with inputs and outputs subdirectories)
 	var err error
 
@@ -214,6 +406,17 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		_ = r.fs.RemoveAll(r.testCaseTmpDir)
 	}()
 
+	// Keep the artifacts before the temporary directory is removed (deferred calls run last in, first out)
+	if r.testCaseArtifactsDir != "" {
+		artifactsDir := r.testCaseArtifactsDir
+
+		defer func() {
+			if err := r.saveArtifacts(result, artifactsDir); err != nil {
+				utils.WarningPrintf("failed to save artifacts of test case '%s': %v\n", result.Name, err)
+			}
+		}()
+	}
+
 	// Create subdirectories for inputs and outputs
 	r.inputsDir = filepath.Join(r.testCaseTmpDir, "inputs")
 
@@ -232,10 +435,6 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		utils.DebugPrintf("- Outputs: %s\n", r.outputsDir)
 	}
 
-	if r.testSuiteSpec.HasCommon() {
-		testCase.MergeCommon(r.testSuiteSpec.Common)
-	}
-
 	// Process template variables for this test case
 	if err := r.processTemplateVariables(&testCase, testSuiteResult); err != nil {
 		return result.Fail(fmt.Errorf("failed to process template variables: %w", err))
@@ -250,162 +449,7 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 	}
 
 	// Always resolve compositionPath, functionPath and all the crdPaths relative to the testsuite file and verify they exist
-	// Only resolve Claim or XR path based on which input type is being used
-	var (
-		failedExpandedPaths []string
-		unverifiedPaths     []string
-		anyPathExpanded     bool
-	)
-
-	// Expand input path based on type (Claim or XR)
-
-	if testCase.HasXR() {
-		if !filepath.IsAbs(testCase.Inputs.XR) {
-			anyPathExpanded = true
-		}
-
-		testCase.Inputs.XR, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.XR)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand XR path: %v", err))
-		}
-
-		if err := r.verifyPathExists(testCase.Inputs.XR); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("XR file not found: %v", err))
-		}
-	} else {
-		if !filepath.IsAbs(testCase.Inputs.Claim) {
-			anyPathExpanded = true
-		}
-
-		testCase.Inputs.Claim, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.Claim)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand Claim path: %v", err))
-		}
-
-		if err := r.verifyPathExists(testCase.Inputs.Claim); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("Claim file not found: %v", err))
-		}
-	}
-
-	if !filepath.IsAbs(testCase.Inputs.Composition) {
-		anyPathExpanded = true
-	}
-
-	testCase.Inputs.Composition, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.Composition)
-	if err != nil {
-		failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand composition path: %v", err))
-	}
-
-	if err := r.verifyPathExists(testCase.Inputs.Composition); err != nil {
-		unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("composition file not found: %v", err))
-	}
-
-	if !filepath.IsAbs(testCase.Inputs.Functions) {
-		anyPathExpanded = true
-	}
-
-	testCase.Inputs.Functions, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.Functions)
-	if err != nil {
-		failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand functions path: %v", err))
-	}
-
-	if err := r.verifyPathExists(testCase.Inputs.Functions); err != nil {
-		unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("functions file or dir not found: %v", err))
-	}
-
-	for i, originalCRDPath := range testCase.Inputs.CRDs {
-		if !filepath.IsAbs(originalCRDPath) {
-			anyPathExpanded = true
-		}
-
-		testCase.Inputs.CRDs[i], err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, originalCRDPath)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand CRD path %s: %v", originalCRDPath, err))
-			continue
-		}
-
-		if err := r.verifyPathExists(testCase.Inputs.CRDs[i]); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("crd file not found: %v", err))
-			continue
-		}
-	}
-
-	for key, originalContextFilePath := range testCase.Inputs.ContextFiles {
-		if !filepath.IsAbs(originalContextFilePath) {
-			anyPathExpanded = true
-		}
-
-		testCase.Inputs.ContextFiles[key], err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, originalContextFilePath)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand context file path for key '%s': %v", key, err))
-			continue
-		}
-
-		if err := r.verifyPathExists(testCase.Inputs.ContextFiles[key]); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("context file not found for key '%s': %v", key, err))
-			continue
-		}
-	}
-
-	if testCase.Inputs.ObservedResources != "" {
-		if !filepath.IsAbs(testCase.Inputs.ObservedResources) {
-			anyPathExpanded = true
-		}
-
-		testCase.Inputs.ObservedResources, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.ObservedResources)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand observed resources path: %v", err))
-		}
-
-		if err := r.verifyPathExists(testCase.Inputs.ObservedResources); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("observed resources file or dir not found: %v", err))
-		}
-	}
-
-	if testCase.Inputs.ExtraResources != "" {
-		if !filepath.IsAbs(testCase.Inputs.ExtraResources) {
-			anyPathExpanded = true
-		}
-
-		testCase.Inputs.ExtraResources, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.ExtraResources)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand extra resources path: %v", err))
-		}
-
-		if err := r.verifyPathExists(testCase.Inputs.ExtraResources); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("extra resources file or dir not found: %v", err))
-		}
-	}
-
-	if testCase.Inputs.FunctionCredentials != "" {
-		if !filepath.IsAbs(testCase.Inputs.FunctionCredentials) {
-			anyPathExpanded = true
-		}
-
-		testCase.Inputs.FunctionCredentials, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.FunctionCredentials)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand function credentials path: %v", err))
-		}
-
-		if err := r.verifyPathExists(testCase.Inputs.FunctionCredentials); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("function credentials file or dir not found: %v", err))
-		}
-	}
-
-	if testCase.Patches.XRD != "" {
-		if !filepath.IsAbs(testCase.Patches.XRD) {
-			anyPathExpanded = true
-		}
-
-		testCase.Patches.XRD, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Patches.XRD)
-		if err != nil {
-			failedExpandedPaths = append(failedExpandedPaths, fmt.Sprintf("failed to expand XRD path: %v", err))
-		}
-
-		if err := r.verifyPathExists(testCase.Patches.XRD); err != nil {
-			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("XRD file or dir not found: %v", err))
-		}
-	}
+	anyPathExpanded, failedExpandedPaths, unverifiedPaths := r.expandInputPaths(&testCase)
 
 	// Throw combined error if any paths failed to expand or verify
 	if len(failedExpandedPaths) > 0 || len(unverifiedPaths) > 0 {
@@ -416,6 +460,9 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		r.debugPrintTestCase(testCase, "Test specification with expanded input paths:")
 	}
 
+	// Keep the original composition path: a git comparison reads the composition at another ref of its repository
+	sourceComposition := testCase.Inputs.Composition
+
 	// Copy all inputs to the temporary inputs directory
 	if testCase.HasXR() {
 		testCase.Inputs.XR, err = r.copyInput(testCase.Inputs.XR, "xr")
@@ -434,6 +481,10 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		return result.Fail(err)
 	}
 
+	if err := r.mutateComposition(sourceComposition, testCase.Inputs.Composition); err != nil {
+		return result.Fail(err)
+	}
+
 	testCase.Inputs.Functions, err = r.copyInput(testCase.Inputs.Functions, "functions")
 	if err != nil {
 		return result.Fail(err)
@@ -451,6 +502,10 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		}
 	}
 
+	if err := r.copyCRDsFrom(testCase.Inputs.CRDsFrom, crdsDir); err != nil {
+		return result.Fail(err)
+	}
+
 	for key, contextFile := range testCase.Inputs.ContextFiles {
 		testCase.Inputs.ContextFiles[key], err = r.copyInput(contextFile, "context-files")
 		if err != nil {
@@ -486,9 +541,15 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		}
 	}
 
+	// A dry run stops once the inputs are copied, before the hooks, render and validate
+	if r.DryRun {
+		return result.WouldRun("")
+	}
+
 	// Execute pre-test hooks
 	if testCase.HasPreTestHooks() {
-		hookExecutor := newHookExecutor(r.Repositories, r.Debug, r.runCommand, r.renderTemplate)
+		hookExecutor := newHookExecutor(r.Repositories, r.templateVars(), r.Debug, r.runCommand, r.renderTemplate)
+		hookExecutor.suites = r.suiteTemplateContexts()
 
 		result.PreTestHooksResults, err = hookExecutor.executeHooks(testCase.Hooks.PreTest, "pre-test", testCase.Inputs, nil, testSuiteResult.GetCompletedTests())
 		result.ProcessPreTestHooksOutput()
@@ -522,42 +583,49 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		}
 	}
 
-	renderArgs := make([]string, 0, len(r.Render)+3)
-	renderArgs = append(renderArgs, r.Render...)
-	renderArgs = append(renderArgs, inputXR, testCase.Inputs.Composition, testCase.Inputs.Functions)
-
-	// Add context files if specified (map[string]string)
-	for key, contextFile := range testCase.Inputs.ContextFiles {
-		renderArgs = append(renderArgs, "--context-files", fmt.Sprintf("%s=%s", key, contextFile))
+	// Validate the XR and the Claim against the CRDs of the XRD they are patched with too, when they are validated
+	if testCase.HasCRDs() && testCase.Patches.XRD != "" {
+		if err := r.writeXRDCRDs(testCase.Patches.XRD, crdsDir); err != nil {
+			return result.Fail(err)
+		}
 	}
 
-	// Add context values if specified (map[string]string)
-	for key, contextValue := range testCase.Inputs.ContextValues {
-		renderArgs = append(renderArgs, "--context-values", fmt.Sprintf("%s=%s", key, contextValue))
-	}
+	// Validate the input XR (and the Claim) against their XRD before rendering, so that typos do not render silently
+	if testCase.HasCRDs() {
+		if err := r.validateInputs(result, crdsDir, inputXR, testCase.Inputs.Claim); err != nil {
+			return result.Fail(err)
+		}
 
-	// Add observed resources if specified (single string)
-	if testCase.Inputs.ObservedResources != "" {
-		renderArgs = append(renderArgs, "--observed-resources", testCase.Inputs.ObservedResources)
+		if result.HasFailedInputValidate {
+			return result.Fail(nil)
+		}
+	} else if r.Debug {
+		utils.DebugPrintf("Skipped input validation because no CRDs were specified\n")
 	}
 
-	// Add extra resources if specified (single string)
-	if testCase.Inputs.ExtraResources != "" {
-		renderArgs = append(renderArgs, "--extra-resources", testCase.Inputs.ExtraResources)
+	// Record the spec fields of the original XR or Claim, before they are converted and patched
+	input := testCase.Inputs.XR
+	if !testCase.HasXR() {
+		input = testCase.Inputs.Claim
 	}
 
-	// Add function credentials if specified (single string)
-	if testCase.Inputs.FunctionCredentials != "" {
-		renderArgs = append(renderArgs, "--function-credentials", testCase.Inputs.FunctionCredentials)
+	if err := r.recordInputCoverage(input, testCase.Patches.XRD, crdsDir); err != nil {
+		return result.Fail(err)
 	}
 
+	renderArgs := r.renderArgs(inputXR, testCase.Inputs.Composition, testCase.Inputs.Functions, testCase.Inputs)
+
 	// Run crossplane render command
 	if r.Debug {
-		utils.DebugPrintf("Running render command: %s %s\n", r.Dependencies["crossplane"], strings.Join(renderArgs, " "))
+		utils.DebugPrintf("Running render command: %s %s\n", r.Dependencies[r.CrossplaneDependency()], strings.Join(renderArgs, " "))
 	}
 
-	result.RawRenderOutput, err = r.runCommand(r.Dependencies["crossplane"], renderArgs...)
+	result.RawRenderOutput, err = r.runCommand(r.Dependencies[r.CrossplaneDependency()], renderArgs...)
 	if err != nil {
+		if err := r.recordRenderCoverage(sourceComposition, testCase.Inputs.Composition, nil, false); err != nil {
+			return result.Fail(err)
+		}
+
 		return result.FailRender()
 	}
 
@@ -578,6 +646,10 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 
 	result.Outputs.RenderCount = len(result.RenderedResources)
 
+	if err := r.recordRenderCoverage(sourceComposition, testCase.Inputs.Composition, result.RenderedResources, true); err != nil {
+		return result.Fail(err)
+	}
+
 	if len(result.RenderedResources) > 0 {
 		// Create separate XR file with just the first resource
 		result.Outputs.XR = filepath.Join(r.outputsDir, "xr.yaml")
@@ -616,21 +688,27 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 	}
 
 	var finalError []string
-	if len(testCase.Inputs.CRDs) >= 1 {
-		validateArgs := make([]string, 0, len(r.Validate)+3)
-		validateArgs = append(validateArgs, r.Validate...)
-		validateArgs = append(validateArgs, filepath.Join(r.inputsDir, "crds"), result.Outputs.Render)
-		// Run crossplane beta validate command
-		if r.Debug {
-			utils.DebugPrintf("Running validate command: %s %s\n", r.Dependencies["crossplane"], strings.Join(validateArgs, " "))
-		}
+	if testCase.HasCRDs() {
+		if r.BuiltinValidator {
+			if err := r.validateBuiltin(result, crdsDir); err != nil {
+				return result.Fail(err)
+			}
+		} else {
+			validateArgs := make([]string, 0, len(r.Validate)+3)
+			validateArgs = append(validateArgs, r.Validate...)
+			validateArgs = append(validateArgs, crdsDir, result.Outputs.Render)
+			// Run crossplane beta validate command
+			if r.Debug {
+				utils.DebugPrintf("Running validate command: %s %s\n", r.Dependencies[r.CrossplaneDependency()], strings.Join(validateArgs, " "))
+			}
 
-		result.RawValidateOutput, err = r.runCommand(r.Dependencies["crossplane"], validateArgs...)
-		if err != nil {
-			_ = result.MarkValidateFailed()
-		}
+			result.RawValidateOutput, err = r.runCommand(r.Dependencies[r.CrossplaneDependency()], validateArgs...)
+			if err != nil {
+				_ = result.MarkValidateFailed()
+			}
 
-		result.ProcessValidateOutput()
+			result.ProcessValidateOutput()
+		}
 
 		// Write validation output to the outputs directory
 		validateOutputFile := filepath.Join(r.outputsDir, "validate.txt")
@@ -645,7 +723,14 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		}
 	} else { //nolint:gocritic // keep the else block for visibility
 		if r.Debug {
-			utils.DebugPrintf("Skipped validate command \"%s %s\" because no CRDs were specified\n", r.Dependencies["crossplane"], strings.Join(r.Validate, " "))
+			utils.DebugPrintf("Skipped validate command \"%s %s\" because no CRDs were specified\n", r.Dependencies[r.CrossplaneDependency()], strings.Join(r.Validate, " "))
+		}
+	}
+
+	// Compare the render with the --compare-with baseline (collect the result but don't fail immediately)
+	if r.CompareWith != nil {
+		if err := r.compareRender(result, inputXR, sourceComposition, testCase.Inputs); err != nil {
+			return result.Fail(err)
 		}
 	}
 
@@ -667,7 +752,7 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 				utils.DebugPrintf("Executing %d xprin assertions for test case '%s'\n", len(testCase.Assertions.Xprin), testCase.Name)
 			}
 
-			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsXprin(testCase.Assertions.Xprin)...)
+			result.AssertionsResults = append(result.AssertionsResults, r.withSourcePositions(&testCase, "xprin", exec.executeAssertionsXprin(testCase.Assertions.Xprin))...)
 		}
 
 		if testCase.HasAssertionsDiff() {
@@ -675,7 +760,7 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 				utils.DebugPrintf("Executing %d diff assertions for test case '%s'\n", len(testCase.Assertions.Diff), testCase.Name)
 			}
 
-			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsDiff(testCase.Assertions.Diff)...)
+			result.AssertionsResults = append(result.AssertionsResults, r.withSourcePositions(&testCase, "diff", exec.executeAssertionsDiff(testCase.Assertions.Diff))...)
 		}
 
 		if testCase.HasAssertionsDyff() {
@@ -683,7 +768,7 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 				utils.DebugPrintf("Executing %d dyff assertions for test case '%s'\n", len(testCase.Assertions.Dyff), testCase.Name)
 			}
 
-			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsDyff(testCase.Assertions.Dyff)...)
+			result.AssertionsResults = append(result.AssertionsResults, r.withSourcePositions(&testCase, "dyff", exec.executeAssertionsDyff(testCase.Assertions.Dyff))...)
 		}
 
 		// Format assertions output and set hasFailedAssertions
@@ -712,7 +797,8 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 
 	// Execute post-test hooks (after assertions)
 	if testCase.HasPostTestHooks() {
-		hookExecutor := newHookExecutor(r.Repositories, r.Debug, r.runCommand, r.renderTemplate)
+		hookExecutor := newHookExecutor(r.Repositories, r.templateVars(), r.Debug, r.runCommand, r.renderTemplate)
+		hookExecutor.suites = r.suiteTemplateContexts()
 
 		result.PostTestHooksResults, _ = hookExecutor.executeHooks(testCase.Hooks.PostTest, "post-test", testCase.Inputs, &result.Outputs, testSuiteResult.GetCompletedTests())
 		result.ProcessPostTestHooksOutput()
@@ -742,6 +828,10 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 			*result.Outputs.Assertions = filepath.Join(artifactsDir, "assertions.txt")
 		}
 
+		if result.Outputs.Compare != nil {
+			*result.Outputs.Compare = filepath.Join(artifactsDir, "compare.txt")
+		}
+
 		// Update Rendered map paths to point to artifact paths
 		for key, path := range result.Outputs.Rendered {
 			filename := filepath.Base(path)
@@ -766,6 +856,288 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 	return result.Complete()
 }
 
+// expandInputPaths resolves the input paths of a test case (and its XRD patch) relative to the testsuite file and verifies
+// that they exist. It returns whether any relative path was expanded, and the paths that failed to expand or verify.
+//
+//nolint:gocognit // One expansion and verification step per input field
+func (r *Runner) expandInputPaths(testCase *api.TestCase) (anyPathExpanded bool, failedExpandedPaths, unverifiedPaths []string) {
+	var err error
+
+	// The problems are prefixed with the position of their field in the testsuite file
+	at := func(path, format string, args ...any) string {
+		return r.sourcePosition(testCase, path) + fmt.Sprintf(format, args...)
+	}
+
+	// Only resolve Claim or XR path based on which input type is being used
+	if testCase.HasXR() {
+		if !filepath.IsAbs(testCase.Inputs.XR) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.XR, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.XR)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("inputs.xr", "failed to expand XR path: %v", err))
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.XR); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("inputs.xr", "XR file not found: %v", err))
+		}
+	} else {
+		if !filepath.IsAbs(testCase.Inputs.Claim) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.Claim, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.Claim)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("inputs.claim", "failed to expand Claim path: %v", err))
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.Claim); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("inputs.claim", "Claim file not found: %v", err))
+		}
+	}
+
+	if !filepath.IsAbs(testCase.Inputs.Composition) {
+		anyPathExpanded = true
+	}
+
+	testCase.Inputs.Composition, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.Composition)
+	if err != nil {
+		failedExpandedPaths = append(failedExpandedPaths, at("inputs.composition", "failed to expand composition path: %v", err))
+	}
+
+	if err := r.verifyPathExists(testCase.Inputs.Composition); err != nil {
+		unverifiedPaths = append(unverifiedPaths, at("inputs.composition", "composition file not found: %v", err))
+	}
+
+	if !filepath.IsAbs(testCase.Inputs.Functions) {
+		anyPathExpanded = true
+	}
+
+	testCase.Inputs.Functions, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.Functions)
+	if err != nil {
+		failedExpandedPaths = append(failedExpandedPaths, at("inputs.functions", "failed to expand functions path: %v", err))
+	}
+
+	if err := r.verifyPathExists(testCase.Inputs.Functions); err != nil {
+		unverifiedPaths = append(unverifiedPaths, at("inputs.functions", "functions file or dir not found: %v", err))
+	}
+
+	for i, originalCRDPath := range testCase.Inputs.CRDs {
+		if !filepath.IsAbs(originalCRDPath) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.CRDs[i], err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, originalCRDPath)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at(fmt.Sprintf("inputs.crds[%d]", i), "failed to expand CRD path %s: %v", originalCRDPath, err))
+			continue
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.CRDs[i]); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at(fmt.Sprintf("inputs.crds[%d]", i), "crd file not found: %v", err))
+			continue
+		}
+	}
+
+	for i, entry := range testCase.Inputs.CRDsFrom {
+		// Package references are resolved from the CRDs cache, only package files are paths
+		if !packagecache.IsPackageFile(entry) {
+			continue
+		}
+
+		if !filepath.IsAbs(entry) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.CRDsFrom[i], err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, entry)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at(fmt.Sprintf("inputs.crds-from[%d]", i), "failed to expand crds-from path %s: %v", entry, err))
+			continue
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.CRDsFrom[i]); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at(fmt.Sprintf("inputs.crds-from[%d]", i), "crds-from file not found: %v", err))
+			continue
+		}
+	}
+
+	for key, originalContextFilePath := range testCase.Inputs.ContextFiles {
+		if !filepath.IsAbs(originalContextFilePath) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.ContextFiles[key], err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, originalContextFilePath)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("inputs.context-files."+key, "failed to expand context file path for key '%s': %v", key, err))
+			continue
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.ContextFiles[key]); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("inputs.context-files."+key, "context file not found for key '%s': %v", key, err))
+			continue
+		}
+	}
+
+	if testCase.Inputs.ObservedResources != "" {
+		if !filepath.IsAbs(testCase.Inputs.ObservedResources) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.ObservedResources, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.ObservedResources)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("inputs.observed-resources", "failed to expand observed resources path: %v", err))
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.ObservedResources); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("inputs.observed-resources", "observed resources file or dir not found: %v", err))
+		}
+	}
+
+	if testCase.Inputs.ExtraResources != "" {
+		if !filepath.IsAbs(testCase.Inputs.ExtraResources) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.ExtraResources, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.ExtraResources)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("inputs.extra-resources", "failed to expand extra resources path: %v", err))
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.ExtraResources); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("inputs.extra-resources", "extra resources file or dir not found: %v", err))
+		}
+	}
+
+	if testCase.Inputs.FunctionCredentials != "" {
+		if !filepath.IsAbs(testCase.Inputs.FunctionCredentials) {
+			anyPathExpanded = true
+		}
+
+		testCase.Inputs.FunctionCredentials, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Inputs.FunctionCredentials)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("inputs.function-credentials", "failed to expand function credentials path: %v", err))
+		}
+
+		if err := r.verifyPathExists(testCase.Inputs.FunctionCredentials); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("inputs.function-credentials", "function credentials file or dir not found: %v", err))
+		}
+	}
+
+	if testCase.Patches.XRD != "" {
+		if !filepath.IsAbs(testCase.Patches.XRD) {
+			anyPathExpanded = true
+		}
+
+		testCase.Patches.XRD, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, testCase.Patches.XRD)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("patches.xrd", "failed to expand XRD path: %v", err))
+		}
+
+		if err := r.verifyPathExists(testCase.Patches.XRD); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("patches.xrd", "XRD file or dir not found: %v", err))
+		}
+	}
+
+	if testCase.XRVariants != nil && testCase.XRVariants.XRD != "" {
+		if !filepath.IsAbs(testCase.XRVariants.XRD) {
+			anyPathExpanded = true
+		}
+
+		// Do not expand the xr-variants shared with the testsuite specification in place
+		variants := *testCase.XRVariants
+		testCase.XRVariants = &variants
+
+		variants.XRD, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, variants.XRD)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("xr-variants.xrd", "failed to expand xr-variants XRD path: %v", err))
+		}
+
+		if err := r.verifyPathExists(variants.XRD); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("xr-variants.xrd", "xr-variants XRD file not found: %v", err))
+		}
+	}
+
+	if testCase.Fuzz != nil && testCase.Fuzz.XRD != "" {
+		if !filepath.IsAbs(testCase.Fuzz.XRD) {
+			anyPathExpanded = true
+		}
+
+		// Do not expand the fuzz configuration shared with the testsuite specification in place
+		fuzz := *testCase.Fuzz
+		testCase.Fuzz = &fuzz
+
+		fuzz.XRD, err = r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, fuzz.XRD)
+		if err != nil {
+			failedExpandedPaths = append(failedExpandedPaths, at("fuzz.xrd", "failed to expand fuzz XRD path: %v", err))
+		}
+
+		if err := r.verifyPathExists(fuzz.XRD); err != nil {
+			unverifiedPaths = append(unverifiedPaths, at("fuzz.xrd", "fuzz XRD file not found: %v", err))
+		}
+	}
+
+	return anyPathExpanded, failedExpandedPaths, unverifiedPaths
+}
+
+// withSourcePositions prefixes the messages of the results of an engine's assertions that errored with the position
+// of their assertion in the testsuite file. The results are in the order of the assertions.
+func (r *Runner) withSourcePositions(testCase *api.TestCase, assertionEngine string, results []engine.AssertionResult) []engine.AssertionResult {
+	for i := range results {
+		if results[i].Status == engine.StatusError() {
+			results[i].Message = r.sourcePosition(testCase, fmt.Sprintf("assertions.%s[%d]", assertionEngine, i)) + results[i].Message
+		}
+	}
+
+	return results
+}
+
+// sourcePosition returns the position of a field of a test case in the testsuite file as a "file:line:col: " prefix
+// for its errors, or an empty string when the position is unknown (see api.TestCase.Position).
+func (r *Runner) sourcePosition(testCase *api.TestCase, path string) string {
+	position, ok := testCase.Position(path)
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%s: ", r.testSuiteFile, position)
+}
+
+// renderArgs returns the crossplane render arguments for the given XR, composition and functions
+// followed by the optional render inputs of the test case.
+func (r *Runner) renderArgs(inputXR, composition, functions string, inputs api.Inputs) []string {
+	renderArgs := make([]string, 0, len(r.Render)+3)
+	renderArgs = append(renderArgs, r.Render...)
+	renderArgs = append(renderArgs, inputXR, composition, functions)
+
+	// Add context files if specified (map[string]string)
+	for key, contextFile := range inputs.ContextFiles {
+		renderArgs = append(renderArgs, "--context-files", fmt.Sprintf("%s=%s", key, contextFile))
+	}
+
+	// Add context values if specified (map[string]string)
+	for key, contextValue := range inputs.ContextValues {
+		renderArgs = append(renderArgs, "--context-values", fmt.Sprintf("%s=%s", key, contextValue))
+	}
+
+	// Add observed resources if specified (single string)
+	if inputs.ObservedResources != "" {
+		renderArgs = append(renderArgs, "--observed-resources", inputs.ObservedResources)
+	}
+
+	// Add extra resources if specified (single string)
+	if inputs.ExtraResources != "" {
+		renderArgs = append(renderArgs, "--extra-resources", inputs.ExtraResources)
+	}
+
+	// Add function credentials if specified (single string)
+	if inputs.FunctionCredentials != "" {
+		renderArgs = append(renderArgs, "--function-credentials", inputs.FunctionCredentials)
+	}
+
+	return renderArgs
+}
+
 // renderTemplate renders Go template syntax with the given context.
 func (r *Runner) renderTemplate(content string, templateContext *templateContext, templateName string) (string, error) {
 	// Parse and execute template
@@ -808,7 +1180,8 @@ func (r *Runner) processTemplateVariables(testCase *api.TestCase, testSuiteResul
 	content = testexecutionUtils.RestoreTemplateVars(content)
 
 	// Render template
-	templateContext := newTemplateContext(r.Repositories, testCase.Inputs, nil, testSuiteResult.GetCompletedTests())
+	templateContext := newTemplateContext(r.Repositories, r.templateVars(), testCase.Inputs, nil, testSuiteResult.GetCompletedTests())
+	templateContext.Suites = r.suiteTemplateContexts()
 
 	content, err = r.renderTemplate(content, templateContext, "testcase")
 	if err != nil {
@@ -69,6 +69,31 @@ func newMockRunner(options *testexecutionUtils.Options, mocks ...func(*Runner))
 	return r
 }
 
+// mockCopyXRD makes the copies of XRD inputs write validateTestXRD, so that the CRDs of patches.xrd can be derived,
+// and the copies of Claim inputs write a Claim, so that the inputs can be validated against them.
+func mockCopyXRD(r *Runner) {
+	r.copy = func(_, dest string, _ ...cp.Options) error {
+		switch filepath.Base(filepath.Dir(dest)) {
+		case "xrd":
+			return afero.WriteFile(r.fs, dest, []byte(validateTestXRD), 0o600)
+		case "claim":
+			return afero.WriteFile(r.fs, dest, []byte("apiVersion: example.org/v1\nkind: Bucket\nmetadata:\n  name: bucket\n"), 0o600)
+		default:
+			return nil
+		}
+	}
+}
+
+// mockPatchXR makes patching write validTestXR, valid against validateTestXRD, so that the patched XR can be
+// validated against the CRDs of patches.xrd.
+func mockPatchXR(r *Runner) {
+	r.patchXRFunc = func(_ *Runner, _, outputPath string, _ api.Patches) (string, error) {
+		path := filepath.Join(outputPath, "patched-xr.yaml")
+
+		return path, afero.WriteFile(r.fs, path, []byte(validTestXR), 0o600)
+	}
+}
+
 // makeOptions is a helper to create Options from a config and allow overrides for tests.
 func makeOptions(cfg *config.Config, render, validate []string, overrides ...func(*testexecutionUtils.Options)) *testexecutionUtils.Options {
 	// If render/validate are nil, extract from cfg.Subcommands (if present)
@@ -204,7 +229,7 @@ func TestNewTemplateContext(t *testing.T) {
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 		outputs := &engine.Outputs{Render: "rendered.yaml"}
 
-		context := newTemplateContext(repos, inputs, outputs, map[string]*engine.TestCaseResult{})
+		context := newTemplateContext(repos, nil, inputs, outputs, map[string]*engine.TestCaseResult{})
 
 		assert.Equal(t, repos, context.Repositories)
 		assert.Equal(t, inputs, context.Inputs)
@@ -214,7 +239,7 @@ func TestNewTemplateContext(t *testing.T) {
 	t.Run("with nil repositories", func(t *testing.T) {
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 
-		context := newTemplateContext(nil, inputs, nil, map[string]*engine.TestCaseResult{})
+		context := newTemplateContext(nil, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 
 		assert.NotNil(t, context.Repositories)
 		assert.Empty(t, context.Repositories)
@@ -226,12 +251,49 @@ func TestNewTemplateContext(t *testing.T) {
 		repos := map[string]string{"myrepo": "/path/to/repo"}
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 
-		context := newTemplateContext(repos, inputs, nil, map[string]*engine.TestCaseResult{})
+		context := newTemplateContext(repos, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 
 		assert.Equal(t, repos, context.Repositories)
 		assert.Equal(t, inputs, context.Inputs)
 		assert.Nil(t, context.Outputs)
 	})
+
+	t.Run("with vars", func(t *testing.T) {
+		vars := map[string]string{"branch": "main"}
+
+		context := newTemplateContext(nil, vars, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+
+		assert.Equal(t, vars, context.Vars)
+	})
+
+	t.Run("with nil vars", func(t *testing.T) {
+		context := newTemplateContext(nil, nil, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+
+		assert.NotNil(t, context.Vars)
+		assert.Empty(t, context.Vars)
+	})
+}
+
+func TestTemplateVars(t *testing.T) {
+	t.Run("precedence is CLI over testsuite over config", func(t *testing.T) {
+		runner := &Runner{
+			Options: &testexecutionUtils.Options{
+				ConfigVars: map[string]string{"branch": "config", "region": "eu-west-1", "provider": "config"},
+				Vars:       map[string]string{"branch": "cli"},
+			},
+			testSuiteSpec: &api.TestSuiteSpec{
+				Common: api.Common{Vars: map[string]string{"branch": "suite", "provider": "suite"}},
+			},
+		}
+
+		assert.Equal(t, map[string]string{"branch": "cli", "region": "eu-west-1", "provider": "suite"}, runner.templateVars())
+	})
+
+	t.Run("no vars anywhere", func(t *testing.T) {
+		runner := &Runner{Options: &testexecutionUtils.Options{}}
+
+		assert.Empty(t, runner.templateVars())
+	})
 }
 
 func TestRunTests(t *testing.T) {
@@ -323,6 +385,35 @@ func TestRunTests(t *testing.T) {
 	}
 }
 
+// TestRunTests_NamedTestSuite tests that named testsuites keep their testsuite artifacts until Cleanup.
+func TestRunTests_NamedTestSuite(t *testing.T) {
+	for _, name := range []string{"", "network"} {
+		t.Run("name "+name, func(t *testing.T) {
+			runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Name: name, Tests: []api.TestCase{{Name: "vpc", ID: "vpc"}}})
+			runner.output = &bytes.Buffer{}
+			runner.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+				result := createTestCaseResult(testCase.Name, false, nil)
+				result.ID = testCase.ID
+
+				return result
+			}
+
+			require.NoError(t, runner.RunTests())
+			require.NotNil(t, runner.TestSuiteResult())
+			assert.Contains(t, runner.TestSuiteResult().GetCompletedTests(), "vpc")
+
+			if name == "" {
+				assert.NoDirExists(t, runner.testSuiteArtifactsDir)
+				return
+			}
+
+			assert.DirExists(t, runner.testSuiteArtifactsDir)
+			runner.Cleanup()
+			assert.NoDirExists(t, runner.testSuiteArtifactsDir)
+		})
+	}
+}
+
 func TestRunTestsIntegration(t *testing.T) {
 	options := &testexecutionUtils.Options{
 		ShowRender:   false,
@@ -921,14 +1012,12 @@ func TestRunTestCase(t *testing.T) {
 				},
 			},
 			setup: func(r *Runner) {
+				mockCopyXRD(r)
 				// Mock convertClaimToXRFunc to return a fake XR file path
 				r.convertClaimToXRFunc = func(_ *Runner, _, outputPath string) (string, error) {
 					return filepath.Join(outputPath, "xr.yaml"), nil
 				}
-				// Mock patchXRFunc to return a fake patched XR file path
-				r.patchXRFunc = func(_ *Runner, _, outputPath string, _ api.Patches) (string, error) {
-					return filepath.Join(outputPath, "patched-xr.yaml"), nil
-				}
+				mockPatchXR(r)
 				// Mock runCommand to return successful results
 				r.runCommand = func(name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
@@ -1086,10 +1175,8 @@ func TestRunTestCase(t *testing.T) {
 				},
 			},
 			setup: func(r *Runner) {
-				// Mock patchXRFunc to return a fake patched XR file path
-				r.patchXRFunc = func(_ *Runner, _, outputPath string, _ api.Patches) (string, error) {
-					return filepath.Join(outputPath, "patched-xr.yaml"), nil
-				}
+				mockCopyXRD(r)
+				mockPatchXR(r)
 				// Mock runCommand to return successful results
 				r.runCommand = func(name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
@@ -2645,6 +2732,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 				},
 			},
 			setup: func(r *Runner) {
+				mockCopyXRD(r)
 				// Mock runCommand to return successful results
 				r.runCommand = func(name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
@@ -2663,10 +2751,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 					_ = path
 					return nil
 				}
-				// Mock patchXRFunc to return a fake patched XR file path
-				r.patchXRFunc = func(_ *Runner, _, outputPath string, _ api.Patches) (string, error) {
-					return filepath.Join(outputPath, "patched-xr.yaml"), nil
-				}
+				mockPatchXR(r)
 			},
 			wantError: "",
 		},
@@ -2886,7 +2971,7 @@ common:
 			"otherrepo": "/path/to/otherrepo",
 		}
 
-		templateContext := newTemplateContext(repos, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(repos, nil, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2908,7 +2993,7 @@ hooks:
 			Composition: "my-composition.yaml",
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2933,7 +3018,7 @@ hooks:
 			FunctionCredentials: "my-creds.yaml",
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2963,7 +3048,7 @@ hooks:
 			},
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2986,7 +3071,7 @@ hooks:
 			Render: "rendered-resources.yaml",
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3010,7 +3095,7 @@ hooks:
 			RenderCount: 5,
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3033,7 +3118,7 @@ hooks:
 			RenderCount: 3,
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3058,7 +3143,7 @@ hooks:
 			},
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3081,7 +3166,7 @@ hooks:
 			Assertions: &assertionsPath,
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3102,7 +3187,7 @@ hooks:
 			Assertions: nil, // No assertions run
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3126,7 +3211,7 @@ hooks:
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 		outputs := &engine.Outputs{XR: "rendered-xr.yaml"}
 
-		templateContext := newTemplateContext(repos, inputs, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(repos, nil, inputs, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3144,11 +3229,33 @@ hooks:
 		assert.NotContains(t, out, "{{ .Outputs.XR }}")
 	})
 
+	t.Run("vars", func(t *testing.T) {
+		yaml := "composition: {{ .Repositories.myrepo }}/{{ .Vars.branch }}/composition.yaml"
+		repos := map[string]string{"myrepo": "/path/to/repo"}
+		vars := map[string]string{"branch": "feature-x"}
+
+		templateContext := newTemplateContext(repos, vars, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		runner := &Runner{}
+		out, err := runner.renderTemplate(yaml, templateContext, "test")
+		require.NoError(t, err)
+		assert.Equal(t, "composition: /path/to/repo/feature-x/composition.yaml", out)
+	})
+
+	t.Run("unknown var", func(t *testing.T) {
+		yaml := "composition: {{ .Vars.missing }}"
+
+		templateContext := newTemplateContext(nil, map[string]string{"branch": "main"}, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		runner := &Runner{}
+		_, err := runner.renderTemplate(yaml, templateContext, "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "map has no entry for key \"missing\"")
+	})
+
 	t.Run("unknown repository variable", func(t *testing.T) {
 		yaml := "functions: {{ .Repositories.unknownrepo }}/foo"
 		repos := map[string]string{"myrepo": "/some/path"}
 
-		templateContext := newTemplateContext(repos, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(repos, nil, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		_, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.Error(t, err)
@@ -3159,7 +3266,7 @@ hooks:
 		yaml := "hooks:\n  pre-test:\n  - run: \"echo '{{ .Inputs.UnknownField }}'\""
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		_, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.Error(t, err)
@@ -3170,7 +3277,7 @@ hooks:
 		yaml := "hooks:\n  post-test:\n  - run: \"echo '{{ .Outputs.UnknownField }}'\""
 		outputs := &engine.Outputs{XR: "rendered-xr.yaml"}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		_, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.Error(t, err)
@@ -3215,6 +3322,80 @@ func TestProcessTemplateVariables(t *testing.T) {
 	assert.NotEmpty(t, testCase.Hooks.PreTest[0].Run)
 }
 
+// TestProcessTemplateVariables_Vars tests that vars from all sources are rendered into test case fields.
+func TestProcessTemplateVariables_Vars(t *testing.T) {
+	testCase := api.TestCase{
+		Name: "vars-test",
+		Inputs: api.Inputs{
+			XR:          testexecutionUtils.CreatePlaceholder(".Vars.xr"),
+			Composition: fmt.Sprintf("compositions/%s/composition.yaml", testexecutionUtils.CreatePlaceholder(".Vars.branch")),
+			Functions:   fmt.Sprintf("functions-%s.yaml", testexecutionUtils.CreatePlaceholder(".Vars.provider")),
+		},
+	}
+
+	runner := &Runner{
+		Options: &testexecutionUtils.Options{
+			ConfigVars: map[string]string{"xr": "config-xr.yaml", "provider": "v1"},
+			Vars:       map[string]string{"branch": "cli-branch"},
+		},
+		testSuiteSpec: &api.TestSuiteSpec{
+			Common: api.Common{Vars: map[string]string{"branch": "suite-branch", "provider": "v2"}},
+		},
+	}
+
+	testSuiteResult := engine.NewTestSuiteResult("test-suite.yaml", false)
+	err := runner.processTemplateVariables(&testCase, testSuiteResult)
+	require.NoError(t, err)
+
+	assert.Equal(t, "config-xr.yaml", testCase.Inputs.XR)
+	assert.Equal(t, "compositions/cli-branch/composition.yaml", testCase.Inputs.Composition)
+	assert.Equal(t, "functions-v2.yaml", testCase.Inputs.Functions)
+}
+
+// TestProcessTemplateVariables_Suites tests that the outputs of the testsuites in depends-on are rendered into test case fields.
+func TestProcessTemplateVariables_Suites(t *testing.T) {
+	network := engine.NewTestSuiteResult("network_xprin.yaml", false)
+	vpc := createTestCaseResult("vpc", false, nil)
+	vpc.ID = "vpc"
+	vpc.Outputs.XR = "/artifacts/vpc/xr.yaml"
+	network.AddResult(vpc)
+
+	runner := &Runner{
+		Options: &testexecutionUtils.Options{
+			Suites: map[string]*engine.TestSuiteResult{"network": network, "skipped": nil},
+		},
+		testSuiteSpec: &api.TestSuiteSpec{},
+	}
+
+	testCase := api.TestCase{
+		Name:   "subnet",
+		Inputs: api.Inputs{XR: testexecutionUtils.CreatePlaceholder(".Suites.network.Tests.vpc.Outputs.XR")},
+	}
+
+	require.NoError(t, runner.processTemplateVariables(&testCase, engine.NewTestSuiteResult("test-suite.yaml", false)))
+	assert.Equal(t, "/artifacts/vpc/xr.yaml", testCase.Inputs.XR)
+
+	t.Run("testsuite not in depends-on", func(t *testing.T) {
+		testCase := api.TestCase{
+			Name:   "subnet",
+			Inputs: api.Inputs{XR: testexecutionUtils.CreatePlaceholder(".Suites.other.Tests.vpc.Outputs.XR")},
+		}
+
+		err := runner.processTemplateVariables(&testCase, engine.NewTestSuiteResult("test-suite.yaml", false))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `map has no entry for key "other"`)
+	})
+
+	t.Run("testsuite that ran no test cases", func(t *testing.T) {
+		testCase := api.TestCase{
+			Name:   "subnet",
+			Inputs: api.Inputs{XR: testexecutionUtils.CreatePlaceholder(".Suites.skipped.Tests.vpc.Outputs.XR")},
+		}
+
+		require.Error(t, runner.processTemplateVariables(&testCase, engine.NewTestSuiteResult("test-suite.yaml", false)))
+	})
+}
+
 // TestProcessTemplateVariables_NoTemplateVars tests processTemplateVariables with no template variables.
 func TestProcessTemplateVariables_NoTemplateVars(t *testing.T) {
 	fs := afero.NewMemMapFs()
@@ -3488,6 +3669,114 @@ metadata:
 	assert.Equal(t, 2, result.Outputs.RenderCount, "RenderCount should match number of resources")
 }
 
+func TestRunTestCase_SourcePositions(t *testing.T) {
+	testSuiteSpec, err := api.Decode([]byte(`common:
+  inputs:
+    composition: comp.yaml
+    functions: functions.yaml
+tests:
+- name: missing paths
+  inputs:
+    xr: missing.yaml
+- name: assertion error
+  inputs:
+    xr: xr.yaml
+  assertions:
+    xprin:
+    - name: pass
+      type: Count
+      value: 1
+    - name: error
+      type: Exists
+      resource: ConfigMap
+`))
+	require.NoError(t, err)
+
+	testRunner := newMockRunner(&testexecutionUtils.Options{Render: []string{config.RenderSubcommand}}, func(r *Runner) {
+		r.fs = afero.NewMemMapFs()
+		r.testSuiteSpec = testSuiteSpec
+		r.verifyPathExists = func(path string) error {
+			if path == "missing.yaml" || path == "comp.yaml" {
+				return fmt.Errorf("not found")
+			}
+
+			return nil
+		}
+		r.runCommand = func(_ string, _ ...string) ([]byte, error) {
+			return []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n"), nil
+		}
+	})
+
+	t.Run("missing paths", func(t *testing.T) {
+		result := testRunner.runTestCase(testSuiteSpec.Tests[0], engine.NewTestSuiteResult(testSuiteFile, false))
+		require.Error(t, result.Error)
+		assert.Contains(t, result.Error.Error(), testSuiteFile+":8:9: XR file not found: not found")
+		assert.Contains(t, result.Error.Error(), testSuiteFile+":3:18: composition file not found: not found")
+	})
+
+	t.Run("assertion error", func(t *testing.T) {
+		testRunner.verifyPathExists = func(_ string) error { return nil }
+
+		result := testRunner.runTestCase(testSuiteSpec.Tests[1], engine.NewTestSuiteResult(testSuiteFile, false))
+		require.Len(t, result.AssertionsResults, 2)
+		assert.Equal(t, "found 1 resources (as expected)", result.AssertionsResults[0].Message)
+		assert.Equal(t, testSuiteFile+":17:7: exists assertion value must be in format 'Kind/name', got 'ConfigMap'", result.AssertionsResults[1].Message)
+	})
+}
+
+func TestRunTests_DryRun(t *testing.T) {
+	testSuiteSpec := &api.TestSuiteSpec{
+		Common: api.Common{
+			Inputs: api.Inputs{Composition: "comp.yaml", Functions: "functions.yaml"},
+			Hooks:  api.Hooks{PreTest: []api.Hook{{Run: "exit 1"}}},
+		},
+		Tests: []api.TestCase{
+			{Name: "ready", ID: "ready", Inputs: api.Inputs{XR: "xr.yaml", CRDs: []string{"crd.yaml"}}},
+			{Name: "missing paths", Inputs: api.Inputs{XR: "missing.yaml"}},
+			{Name: "undefined variable", Inputs: api.Inputs{XR: testexecutionUtils.PlaceholderOpen + ".Vars.undefined" + testexecutionUtils.PlaceholderClose}},
+			{Name: "chained", Inputs: api.Inputs{XR: testexecutionUtils.PlaceholderOpen + ".Tests.ready.Outputs.XR" + testexecutionUtils.PlaceholderClose}},
+		},
+	}
+
+	var (
+		buf    bytes.Buffer
+		copied []string
+	)
+
+	testRunner := newMockRunner(&testexecutionUtils.Options{DryRun: true}, func(r *Runner) {
+		r.fs = afero.NewMemMapFs()
+		r.testSuiteSpec = testSuiteSpec
+		r.output = &buf
+		r.verifyPathExists = func(path string) error {
+			if path == "missing.yaml" {
+				return fmt.Errorf("not found")
+			}
+
+			return nil
+		}
+		r.copy = func(src, _ string, _ ...cp.Options) error {
+			copied = append(copied, src)
+			return nil
+		}
+		r.runCommand = func(name string, args ...string) ([]byte, error) {
+			t.Errorf("dry run ran %s %v", name, args)
+			return nil, nil
+		}
+	})
+
+	err := testRunner.RunTests()
+	require.Error(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "--- WOULD RUN: ready\n")
+	assert.Contains(t, output, "--- FAIL: missing paths")
+	assert.Contains(t, output, "XR file not found: not found")
+	assert.Contains(t, output, "--- FAIL: undefined variable")
+	assert.Contains(t, output, "failed to process template variables")
+	assert.Contains(t, output, "--- WOULD RUN: chained\n    not checked: its templates reference the results of other test cases or testsuites\n")
+	assert.Equal(t, []string{"xr.yaml", "comp.yaml", "functions.yaml", "crd.yaml"}, copied)
+}
+
 // TestArtifactsDirectory tests the artifacts directory functionality.
 func TestArtifactsDirectory(t *testing.T) {
 	t.Run("creates artifacts directory in runTests", func(t *testing.T) {
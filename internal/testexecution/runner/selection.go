@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/packagecache"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+)
+
+// selectTestCases returns, for each test case of the testsuite, whether it should run.
+// Without --changed-since every test case is selected. Otherwise a test case is selected when the testsuite file
+// or any of its dependency paths changed, or when a selected test case references its outputs.
+func (r *Runner) selectTestCases() []bool {
+	selected := make([]bool, len(r.testSuiteSpec.Tests))
+
+	if r.ChangedSince == "" || r.isChanged(r.testSuiteFile) {
+		for i := range selected {
+			selected[i] = true
+		}
+
+		return selected
+	}
+
+	for i, testCase := range r.testSuiteSpec.Tests {
+		selected[i] = r.isAffected(testCase)
+	}
+
+	// Test cases in depends-on or referenced through {{ .Tests.<id> }} must run before the selected test cases that use them
+	required := requiredTestCases(r.testSuiteSpec)
+	for i := range selected {
+		if !selected[i] {
+			continue
+		}
+
+		for _, j := range required[i] {
+			selected[j] = true
+		}
+	}
+
+	return selected
+}
+
+// isAffected reports whether a test case depends on any of the changed files. Test cases whose dependency paths
+// cannot be resolved (e.g. templates using the outputs of other test cases) are always affected.
+func (r *Runner) isAffected(testCase api.TestCase) bool {
+	paths, err := r.dependencyPaths(testCase)
+	if err != nil {
+		if r.Debug {
+			utils.DebugPrintf("Selecting test case '%s' because its dependencies cannot be resolved: %v\n", testCase.Name, err)
+		}
+
+		return true
+	}
+
+	for _, path := range paths {
+		if r.isChanged(path) {
+			if r.Debug {
+				utils.DebugPrintf("Selecting test case '%s' because %s changed since %s\n", testCase.Name, path, r.ChangedSince)
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// isChanged reports whether path, or any file below it when path is a directory, is one of the changed files.
+func (r *Runner) isChanged(path string) bool {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	path = resolveSymlinks(path)
+
+	for _, changed := range r.ChangedFiles {
+		if changed == path || strings.HasPrefix(changed, path+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveTestCase returns a test case with the common inputs merged, template variables processed and input paths
+// resolved the same way runTestCase does, without verifying that the paths exist.
+func (r *Runner) resolveTestCase(testCase api.TestCase) (api.TestCase, error) {
+	if r.testSuiteSpec.HasCommon() {
+		testCase.MergeCommon(r.testSuiteSpec.Common)
+	}
+
+	// Do not expand the slices and maps shared with the testsuite specification in place
+	testCase.Inputs.CRDs = slices.Clone(testCase.Inputs.CRDs)
+	testCase.Inputs.CRDsFrom = slices.Clone(testCase.Inputs.CRDsFrom)
+	testCase.Inputs.ContextFiles = maps.Clone(testCase.Inputs.ContextFiles)
+
+	if err := r.processTemplateVariables(&testCase, engine.NewTestSuiteResult(r.testSuiteFile, false)); err != nil {
+		return api.TestCase{}, fmt.Errorf("failed to process template variables: %w", err)
+	}
+
+	if err := testCase.CheckMandatoryFields(); err != nil {
+		return api.TestCase{}, err
+	}
+
+	if _, failedExpandedPaths, _ := r.expandInputPaths(&testCase); len(failedExpandedPaths) > 0 {
+		return api.TestCase{}, fmt.Errorf("failed to expand paths: %s", strings.Join(failedExpandedPaths, ", "))
+	}
+
+	return testCase, nil
+}
+
+// resolveTestCasesWithoutRunning resolves the test cases of the testsuite (see resolveTestCase) for the commands that
+// read their inputs without running them, such as xprin mutate and xprin crds pull. The test cases whose templates
+// reference the results of other test cases or testsuites cannot be resolved without running them: they are skipped
+// with a warning. Other test cases that cannot be resolved fail.
+func (r *Runner) resolveTestCasesWithoutRunning() ([]api.TestCase, error) {
+	resolved := make([]api.TestCase, 0, len(r.testSuiteSpec.Tests))
+
+	for _, testCase := range r.testSuiteSpec.Tests {
+		merged := testCase
+		if r.testSuiteSpec.HasCommon() {
+			merged.MergeCommon(r.testSuiteSpec.Common)
+		}
+
+		if referencesResults(flattenTestCase(merged)) {
+			utils.WarningPrintf("Skipping test case '%s' of %s: its templates reference the results of other test cases or testsuites\n", testCase.Name, r.testSuiteFile)
+			continue
+		}
+
+		resolvedTestCase, err := r.resolveTestCase(testCase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve test case '%s': %w", testCase.Name, err)
+		}
+
+		resolved = append(resolved, resolvedTestCase)
+	}
+
+	return resolved, nil
+}
+
+// dependencyPaths returns the resolved paths a test case reads: its inputs, its XRD patch, the XRD of its xr-variants
+// or fuzz configuration and the golden files of its diff and dyff assertions. Paths are resolved the same way runTestCase does, without
+// verifying that they exist.
+func (r *Runner) dependencyPaths(testCase api.TestCase) ([]string, error) {
+	testCase, err := r.resolveTestCase(testCase)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{testCase.Inputs.XR, testCase.Inputs.Claim, testCase.Inputs.Composition, testCase.Inputs.Functions}
+	paths = append(paths, testCase.Inputs.CRDs...)
+
+	for _, entry := range testCase.Inputs.CRDsFrom {
+		if packagecache.IsPackageFile(entry) {
+			paths = append(paths, entry)
+		}
+	}
+
+	paths = append(paths, slices.Collect(maps.Values(testCase.Inputs.ContextFiles))...)
+	paths = append(paths, testCase.Inputs.ObservedResources, testCase.Inputs.ExtraResources, testCase.Inputs.FunctionCredentials, testCase.Patches.XRD)
+
+	if testCase.XRVariants != nil {
+		paths = append(paths, testCase.XRVariants.XRD)
+	}
+
+	if testCase.Fuzz != nil {
+		paths = append(paths, testCase.Fuzz.XRD)
+	}
+
+	for _, assertion := range slices.Concat(testCase.Assertions.Diff, testCase.Assertions.Dyff) {
+		expected, err := r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, assertion.Expected)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand golden file path for assertion '%s': %w", assertion.Name, err)
+		}
+
+		paths = append(paths, expected)
+	}
+
+	return slices.DeleteFunc(paths, func(path string) bool { return path == "" }), nil
+}
+
+// WatchPaths returns the testsuite file and the dependency paths of every test case whose paths can be resolved,
+// i.e. the paths whose changes select test cases of this testsuite.
+func (r *Runner) WatchPaths() []string {
+	paths := []string{r.testSuiteFile}
+
+	for _, testCase := range r.testSuiteSpec.Tests {
+		dependencies, err := r.dependencyPaths(testCase)
+		if err != nil {
+			if r.Debug {
+				utils.DebugPrintf("Not watching the dependencies of test case '%s' because they cannot be resolved: %v\n", testCase.Name, err)
+			}
+
+			continue
+		}
+
+		paths = append(paths, dependencies...)
+	}
+
+	slices.Sort(paths)
+
+	return slices.Compact(paths)
+}
+
+// requiredTestCases returns, for each test case of the testsuite, the indexes of the test cases that must run before
+// it: the test cases in its depends-on or whose outputs it references, as {{ .Tests.<id> }} or
+// {{ index .Tests "<id>" }}, and, transitively, the test cases these require.
+func requiredTestCases(testSuiteSpec *api.TestSuiteSpec) [][]int {
+	tests := testSuiteSpec.Tests
+
+	indexes := make(map[string]int)
+	for i, testCase := range tests {
+		if testCase.ID != "" {
+			indexes[testCase.ID] = i
+		}
+	}
+
+	direct := make([][]int, len(tests))
+	for i, testCase := range tests {
+		if testSuiteSpec.HasCommon() {
+			testCase.MergeCommon(testSuiteSpec.Common)
+		}
+
+		for _, id := range referencedTestCaseIDs(testCase) {
+			if j, ok := indexes[id]; ok && j != i {
+				direct[i] = append(direct[i], j)
+			}
+		}
+	}
+
+	required := make([][]int, len(tests))
+	for i := range tests {
+		seen := make([]bool, len(tests))
+		seen[i] = true
+
+		for pending := slices.Clone(direct[i]); len(pending) > 0; {
+			j := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+
+			if seen[j] {
+				continue
+			}
+
+			seen[j] = true
+			required[i] = append(required[i], j)
+			pending = append(pending, direct[j]...)
+		}
+
+		slices.Sort(required[i])
+	}
+
+	return required
+}
+
+// referencedTestCaseIDs returns the IDs of the test cases a test case depends on or references the outputs of.
+func referencedTestCaseIDs(testCase api.TestCase) []string {
+	ids := slices.Clone(testCase.DependsOn)
+
+	for _, value := range flattenTestCase(testCase) {
+		ids = append(ids, testexecutionUtils.TestReferences(value)...)
+	}
+
+	return ids
+}
+
+// printSelection prints the testsuite file and name of each selected test case, one per line.
+func (r *Runner) printSelection(selected []bool) {
+	for i, testCase := range r.testSuiteSpec.Tests {
+		if !selected[i] {
+			continue
+		}
+
+		name := testCase.Name
+		if r.Label != "" {
+			name = fmt.Sprintf("%s [%s]", name, r.Label)
+		}
+
+		fmt.Fprintf(r.output, "%s: %s\n", r.testSuiteFile, name) //nolint:errcheck // output function, error handling not practical
+	}
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// newSelectionRunner returns a runner for a testsuite in dir with the given files changed since "main".
+func newSelectionRunner(t *testing.T, dir string, spec *api.TestSuiteSpec, changed ...string) *Runner {
+	t.Helper()
+
+	changedFiles := make([]string, 0, len(changed))
+	for _, name := range changed {
+		changedFiles = append(changedFiles, filepath.Join(dir, name))
+	}
+
+	options := &testexecutionUtils.Options{
+		Repositories: map[string]string{"shared": filepath.Join(dir, "shared")},
+		ChangedSince: "main",
+		ChangedFiles: changedFiles,
+	}
+
+	return NewRunner(options, filepath.Join(dir, "suite_xprin.yaml"), spec)
+}
+
+// placeholders replaces template variables with placeholders, like the testsuite loader does.
+func placeholders(s string) string {
+	return strings.NewReplacer("{{", testexecutionUtils.PlaceholderOpen, "}}", testexecutionUtils.PlaceholderClose).Replace(s)
+}
+
+func selectionSpec() *api.TestSuiteSpec {
+	return &api.TestSuiteSpec{
+		Common: api.Common{
+			Inputs: api.Inputs{
+				Composition: "composition.yaml",
+				Functions:   "functions",
+			},
+		},
+		Tests: []api.TestCase{
+			{
+				Name:   "aws",
+				Inputs: api.Inputs{XR: "aws/xr.yaml", CRDs: []string{"crds/aws.yaml"}, CRDsFrom: []string{"crossplane.yaml", "xpkg.crossplane.io/crossplane-contrib/provider-aws-s3:v2.0.0"}},
+			},
+			{
+				Name:       "gcp",
+				Inputs:     api.Inputs{Claim: "gcp/claim.yaml", ContextFiles: map[string]string{"env": "gcp/env.yaml"}},
+				Patches:    api.Patches{XRD: "gcp/xrd.yaml"},
+				Assertions: api.Assertions{Dyff: []api.AssertionGoldenFile{{Name: "golden", Expected: "golden/gcp.yaml"}}},
+			},
+			{
+				Name:   "azure",
+				Inputs: api.Inputs{XR: placeholders("{{ .Repositories.shared }}/azure/xr.yaml")},
+			},
+		},
+	}
+}
+
+func TestSelectTestCases(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    []bool
+	}{
+		{
+			name: "nothing changed",
+			want: []bool{false, false, false},
+		},
+		{
+			name:    "common input selects every test case",
+			changed: []string{"composition.yaml"},
+			want:    []bool{true, true, true},
+		},
+		{
+			name:    "file in a functions directory",
+			changed: []string{"functions/function-patch.yaml"},
+			want:    []bool{true, true, true},
+		},
+		{
+			name:    "XR and CRD",
+			changed: []string{"crds/aws.yaml"},
+			want:    []bool{true, false, false},
+		},
+		{
+			name:    "context file",
+			changed: []string{"gcp/env.yaml"},
+			want:    []bool{false, true, false},
+		},
+		{
+			name:    "XRD patch",
+			changed: []string{"gcp/xrd.yaml"},
+			want:    []bool{false, true, false},
+		},
+		{
+			name:    "golden file",
+			changed: []string{"golden/gcp.yaml"},
+			want:    []bool{false, true, false},
+		},
+		{
+			name:    "templated path",
+			changed: []string{"shared/azure/xr.yaml"},
+			want:    []bool{false, false, true},
+		},
+		{
+			name:    "testsuite file selects every test case",
+			changed: []string{"suite_xprin.yaml"},
+			want:    []bool{true, true, true},
+		},
+		{
+			name:    "unrelated file",
+			changed: []string{"README.md", "functionsREADME.md"},
+			want:    []bool{false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newSelectionRunner(t, dir, selectionSpec(), tt.changed...)
+			assert.Equal(t, tt.want, r.selectTestCases())
+		})
+	}
+
+	t.Run("every test case is selected without --changed-since", func(t *testing.T) {
+		r := newSelectionRunner(t, dir, selectionSpec())
+		r.ChangedSince = ""
+
+		assert.Equal(t, []bool{true, true, true}, r.selectTestCases())
+	})
+
+	t.Run("paths are not expanded in the testsuite specification", func(t *testing.T) {
+		spec := selectionSpec()
+		r := newSelectionRunner(t, dir, spec, "crds/aws.yaml")
+
+		r.selectTestCases()
+
+		assert.Equal(t, []string{"crds/aws.yaml"}, spec.Tests[0].Inputs.CRDs)
+		assert.Equal(t, map[string]string{"env": "gcp/env.yaml"}, spec.Tests[1].Inputs.ContextFiles)
+	})
+}
+
+func TestSelectTestCases_CrossTestReferences(t *testing.T) {
+	dir := t.TempDir()
+
+	spec := &api.TestSuiteSpec{
+		Common: api.Common{Inputs: api.Inputs{Composition: "composition.yaml", Functions: "functions.yaml"}},
+		Tests: []api.TestCase{
+			{Name: "setup", ID: "setup", Inputs: api.Inputs{XR: "setup/xr.yaml"}},
+			{Name: "unrelated", ID: "unrelated", Inputs: api.Inputs{XR: "unrelated/xr.yaml"}},
+			{
+				Name:   "follow-up",
+				Inputs: api.Inputs{XR: "follow-up/xr.yaml"},
+				Hooks: api.Hooks{PostTest: []api.Hook{
+					{Run: placeholders("diff {{ .Tests.setup.Outputs.XR }} {{ .Outputs.XR }}")},
+				}},
+			},
+			{
+				Name:   "chained",
+				Inputs: api.Inputs{XR: placeholders("{{ .Tests.setup.Outputs.XR }}")},
+			},
+		},
+	}
+
+	t.Run("referenced test cases run before the selected ones", func(t *testing.T) {
+		r := newSelectionRunner(t, dir, spec, "follow-up/xr.yaml")
+		assert.Equal(t, []bool{true, false, true, true}, r.selectTestCases())
+	})
+
+	t.Run("unresolvable paths are always selected", func(t *testing.T) {
+		r := newSelectionRunner(t, dir, spec, "unrelated/xr.yaml")
+		assert.Equal(t, []bool{true, true, false, true}, r.selectTestCases())
+	})
+
+	t.Run("test cases in depends-on run before the selected ones", func(t *testing.T) {
+		spec := &api.TestSuiteSpec{
+			Common: spec.Common,
+			Tests: []api.TestCase{
+				{Name: "setup", ID: "setup", Inputs: api.Inputs{XR: "setup/xr.yaml"}},
+				{Name: "verify", DependsOn: []string{"setup"}, Inputs: api.Inputs{XR: "verify/xr.yaml"}},
+			},
+		}
+
+		r := newSelectionRunner(t, dir, spec, "verify/xr.yaml")
+		assert.Equal(t, []bool{true, true}, r.selectTestCases())
+	})
+
+	t.Run("references are matched by whole ID, in any form and transitively", func(t *testing.T) {
+		spec := &api.TestSuiteSpec{
+			Common: spec.Common,
+			Tests: []api.TestCase{
+				{Name: "a", ID: "a", Inputs: api.Inputs{XR: "a/xr.yaml"}},
+				{Name: "ab", ID: "ab", Inputs: api.Inputs{XR: "ab/xr.yaml"}},
+				{Name: "base", ID: "base", Inputs: api.Inputs{XR: "base/xr.yaml"}},
+				{Name: "setup", ID: "setup", DependsOn: []string{"base"}, Inputs: api.Inputs{XR: "setup/xr.yaml"}},
+				{
+					Name:   "verify",
+					Inputs: api.Inputs{XR: "verify/xr.yaml"},
+					Hooks: api.Hooks{PostTest: []api.Hook{
+						{Run: placeholders(`diff {{ .Tests.ab.Outputs.XR }} {{ (index .Tests "setup").Outputs.XR }}`)},
+					}},
+				},
+			},
+		}
+
+		r := newSelectionRunner(t, dir, spec, "verify/xr.yaml")
+		assert.Equal(t, []bool{false, true, true, true, true}, r.selectTestCases())
+	})
+}
+
+func TestRequiredTestCases(t *testing.T) {
+	spec := &api.TestSuiteSpec{
+		Common: api.Common{Hooks: api.Hooks{PreTest: []api.Hook{{Run: placeholders("cat {{ .Tests.first.Outputs.XR }}")}}}},
+		Tests: []api.TestCase{
+			{Name: "first", ID: "first"},
+			{Name: "second", ID: "second", DependsOn: []string{"first"}},
+			{Name: "third", DependsOn: []string{"second", "unknown"}, Hooks: api.Hooks{PreTest: []api.Hook{}}},
+		},
+	}
+
+	// The common pre-test hook references first, except for the test case that overrides it
+	assert.Equal(t, [][]int{nil, {0}, {0, 1}}, requiredTestCases(spec))
+}
+
+func TestWatchPaths(t *testing.T) {
+	dir := t.TempDir()
+
+	spec := selectionSpec()
+	spec.Tests = append(spec.Tests, api.TestCase{
+		Name:   "chained",
+		Inputs: api.Inputs{XR: placeholders("{{ .Tests.setup.Outputs.XR }}")},
+	})
+
+	r := newSelectionRunner(t, dir, spec)
+
+	want := []string{
+		"aws/xr.yaml", "composition.yaml", "crds/aws.yaml", "crossplane.yaml", "functions",
+		"gcp/claim.yaml", "gcp/env.yaml", "gcp/xrd.yaml", "golden/gcp.yaml",
+		"shared/azure/xr.yaml", "suite_xprin.yaml",
+	}
+	for i, name := range want {
+		want[i] = filepath.Join(dir, name)
+	}
+
+	assert.Equal(t, want, r.WatchPaths())
+}
+
+func TestRunTests_Selection(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("list prints the selected test cases without running them", func(t *testing.T) {
+		r := newSelectionRunner(t, dir, selectionSpec(), "gcp/env.yaml", "shared/azure/xr.yaml")
+		r.List = true
+		r.Label = "crossplane-2.0"
+		r.runTestCaseFunc = func(api.TestCase) *engine.TestCaseResult {
+			require.Fail(t, "test cases must not run with --list")
+			return nil
+		}
+
+		var out bytes.Buffer
+
+		r.output = &out
+
+		require.NoError(t, r.RunTests())
+
+		suite := filepath.Join(dir, "suite_xprin.yaml")
+		assert.Equal(t, suite+": gcp [crossplane-2.0]\n"+suite+": azure [crossplane-2.0]\n", out.String())
+	})
+
+	t.Run("unaffected test cases are skipped", func(t *testing.T) {
+		r := newSelectionRunner(t, dir, selectionSpec(), "gcp/env.yaml")
+		r.Verbose = true
+
+		var ran []string
+
+		r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+			ran = append(ran, testCase.Name)
+			return createTestCaseResult(testCase.Name, true, nil)
+		}
+
+		var out bytes.Buffer
+
+		r.output = &out
+
+		require.NoError(t, r.RunTests())
+		assert.Equal(t, []string{"gcp"}, ran)
+		assert.Contains(t, out.String(), "--- SKIP: aws")
+		assert.Contains(t, out.String(), "not affected by changes since main")
+	})
+}
+
+func TestRunTests_ChangedSinceSuiteDependencies(t *testing.T) {
+	network := engine.NewTestSuiteResult("network_xprin.yaml", false)
+	network.AddResult(engine.NewTestCaseResult("vpc", "vpc", false, false, false, false, false).Skip("not affected by changes since main"))
+
+	subnet := createTestCaseResult("subnet", false, nil)
+	subnet.ID = "subnet"
+	network.AddResult(subnet)
+
+	spec := &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "uses-vpc", Inputs: api.Inputs{XR: placeholders("{{ .Suites.network.Tests.vpc.Outputs.XR }}")}},
+		{Name: "uses-subnet", Inputs: api.Inputs{XR: placeholders("{{ .Suites.network.Tests.subnet.Outputs.XR }}")}},
+		{Name: "uses-idle", Inputs: api.Inputs{XR: placeholders("{{ .Suites.idle.Tests.setup.Outputs.XR }}")}},
+	}}
+
+	r := newSelectionRunner(t, t.TempDir(), spec, "other.yaml")
+	r.Verbose = true
+	r.Suites = map[string]*engine.TestSuiteResult{"network": network, "idle": nil}
+
+	var out bytes.Buffer
+
+	r.output = &out
+
+	var ran []string
+
+	r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, testCase.Name)
+		return createTestCaseResult(testCase.Name, true, nil)
+	}
+
+	require.NoError(t, r.RunTests())
+
+	assert.Equal(t, []string{"uses-subnet"}, ran)
+	assert.Contains(t, out.String(), "--- SKIP: uses-vpc")
+	assert.Contains(t, out.String(), "test case vpc of testsuite network was skipped")
+	assert.Contains(t, out.String(), "--- SKIP: uses-idle")
+	assert.Contains(t, out.String(), "test case setup of testsuite idle did not run")
+	assert.NotContains(t, r.suiteTemplateContexts()["network"].Tests, "vpc", "skipped test cases have no outputs to reference")
+}
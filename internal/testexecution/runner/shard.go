@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"sigs.k8s.io/yaml"
+)
+
+// shardTestCases returns, for each test case of the testsuite, whether it belongs to the shard of this run.
+// Without --shard-total every test case belongs to it. Test cases connected through depends-on or {{ .Tests.<id> }}
+// references form a group that is assigned as a whole, by the hash of the testsuite path and the ID (or name) of the first
+// test case of the group, so that chained test cases always run on the same shard.
+func (r *Runner) shardTestCases() []bool {
+	tests := r.testSuiteSpec.Tests
+	inShard := make([]bool, len(tests))
+
+	if r.ShardTotal <= 0 {
+		for i := range inShard {
+			inShard[i] = true
+		}
+
+		return inShard
+	}
+
+	// group[i] is the index of the first test case of the group of test case i
+	group := make([]int, len(tests))
+	for i := range group {
+		group[i] = i
+	}
+
+	var find func(i int) int
+
+	find = func(i int) int {
+		if group[i] != i {
+			group[i] = find(group[i])
+		}
+
+		return group[i]
+	}
+
+	for i, required := range requiredTestCases(r.testSuiteSpec) {
+		for _, j := range required {
+			a, b := find(i), find(j)
+			group[max(a, b)] = min(a, b)
+		}
+	}
+
+	suite := relativeTestSuitePath(r.testSuiteFile)
+
+	for i := range tests {
+		first := tests[find(i)]
+
+		key := first.ID
+		if key == "" {
+			key = first.Name
+		}
+
+		inShard[i] = shardOf(suite, key, r.ShardTotal) == r.ShardIndex
+	}
+
+	return inShard
+}
+
+// suiteReferenceRe matches the references to the results of other testsuites in template expressions,
+// {{ .Suites.<name> }} or {{ index .Suites "<name>" }}.
+var suiteReferenceRe = regexp.MustCompile(`(?:^|[^\w.])\.Suites\b`) //nolint:gochecknoglobals // compiled once
+
+// checkShardable returns an error when the testsuite is sharded and references the results of other testsuites: the
+// testsuites in depends-on are sharded too, so the test cases it references could run on another shard.
+func (r *Runner) checkShardable() error {
+	if r.ShardTotal <= 0 {
+		return nil
+	}
+
+	data, err := yaml.Marshal(r.testSuiteSpec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal testsuite: %w", err)
+	}
+
+	if suiteReferenceRe.MatchString(testexecutionUtils.RestoreTemplateVars(string(data))) {
+		return fmt.Errorf("cannot shard a testsuite referencing the results of other testsuites with .Suites, as they can run on other shards")
+	}
+
+	return nil
+}
+
+// shardOf returns the shard, between 0 and total-1, of the test case group with the given key in a testsuite.
+func shardOf(suite, key string, total int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(suite + "\x00" + key))
+
+	return int(h.Sum32() % uint32(total)) //nolint:gosec // total is a positive number of shards
+}
+
+// relativeTestSuitePath returns the testsuite path used to assign shards and lay out artifacts: relative to the working
+// directory when possible and with forward slashes, so that runners checking out the repository at different locations
+// agree on the shards.
+func relativeTestSuitePath(testSuiteFile string) string {
+	path := filepath.Clean(testSuiteFile)
+
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+				path = rel
+			}
+		}
+	}
+
+	return filepath.ToSlash(path)
+}
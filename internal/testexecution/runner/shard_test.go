@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// shardSpec returns a testsuite with independent test cases and a chain of test cases referencing each other.
+func shardSpec() *api.TestSuiteSpec {
+	spec := &api.TestSuiteSpec{}
+
+	for i := range 20 {
+		spec.Tests = append(spec.Tests, api.TestCase{Name: fmt.Sprintf("test-%d", i)})
+	}
+
+	spec.Tests = append(spec.Tests,
+		api.TestCase{Name: "setup", ID: "setup"},
+		api.TestCase{Name: "upgrade", ID: "upgrade", Inputs: api.Inputs{XR: placeholders("{{ .Tests.setup.Outputs.XR }}")}},
+		api.TestCase{Name: "verify", Hooks: api.Hooks{PostTest: []api.Hook{{Run: placeholders("diff {{ .Tests.upgrade.Outputs.XR }} {{ .Outputs.XR }}")}}}},
+	)
+
+	return spec
+}
+
+func newShardRunner(spec *api.TestSuiteSpec, index, total int) *Runner {
+	return NewRunner(&testexecutionUtils.Options{ShardIndex: index, ShardTotal: total}, "tests/suite_xprin.yaml", spec)
+}
+
+func TestShardTestCases(t *testing.T) {
+	const total = 3
+
+	spec := shardSpec()
+	counts := make([]int, len(spec.Tests))
+
+	var shards []int
+
+	for index := range total {
+		inShard := newShardRunner(spec, index, total).shardTestCases()
+		require.Len(t, inShard, len(spec.Tests))
+
+		var count int
+
+		for i, ok := range inShard {
+			if ok {
+				counts[i]++
+				count++
+			}
+		}
+
+		shards = append(shards, count)
+
+		// Chained test cases run on the same shard
+		assert.Equal(t, inShard[20], inShard[21])
+		assert.Equal(t, inShard[21], inShard[22])
+	}
+
+	// Every test case belongs to exactly one shard, and every shard gets test cases
+	for i, count := range counts {
+		assert.Equal(t, 1, count, "test case %s", spec.Tests[i].Name)
+	}
+
+	for index, count := range shards {
+		assert.Positive(t, count, "shard %d", index)
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		assert.Equal(t, newShardRunner(spec, 1, total).shardTestCases(), newShardRunner(shardSpec(), 1, total).shardTestCases())
+	})
+
+	t.Run("depends-on keeps test cases together", func(t *testing.T) {
+		spec := shardSpec()
+		spec.Tests = append(spec.Tests,
+			api.TestCase{Name: "base", ID: "base"},
+			api.TestCase{Name: "install", ID: "install", DependsOn: []string{"base"}},
+			api.TestCase{Name: "check", Hooks: api.Hooks{PreTest: []api.Hook{{Run: placeholders(`cat {{ (index .Tests "install").Outputs.XR }}`)}}}},
+		)
+
+		for index := range total {
+			inShard := newShardRunner(spec, index, total).shardTestCases()
+			assert.Equal(t, inShard[23], inShard[24])
+			assert.Equal(t, inShard[24], inShard[25])
+		}
+	})
+
+	t.Run("every test case without sharding", func(t *testing.T) {
+		for _, ok := range newShardRunner(spec, 0, 0).shardTestCases() {
+			assert.True(t, ok)
+		}
+	})
+}
+
+func TestRelativeTestSuitePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	wd, err := filepath.Abs(".")
+	require.NoError(t, err)
+
+	assert.Equal(t, "tests/suite_xprin.yaml", relativeTestSuitePath("tests/suite_xprin.yaml"))
+	assert.Equal(t, "tests/suite_xprin.yaml", relativeTestSuitePath("./tests/../tests/suite_xprin.yaml"))
+	assert.Equal(t, "tests/suite_xprin.yaml", relativeTestSuitePath(filepath.Join(wd, "tests", "suite_xprin.yaml")))
+	assert.Equal(t, "/elsewhere/suite_xprin.yaml", relativeTestSuitePath("/elsewhere/suite_xprin.yaml"))
+}
+
+func TestRunTests_Shard(t *testing.T) {
+	spec := shardSpec()
+
+	var ran []string
+
+	for index := range 2 {
+		r := newShardRunner(spec, index, 2)
+		r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+			ran = append(ran, testCase.Name)
+
+			result := createTestCaseResult(testCase.Name, true, nil)
+			result.ID = testCase.ID
+
+			return result
+		}
+		r.Verbose = true
+
+		var out bytes.Buffer
+
+		r.output = &out
+
+		require.NoError(t, r.RunTests())
+		assert.NotContains(t, out.String(), "SKIP")
+	}
+
+	assert.ElementsMatch(t, []string{
+		"test-0", "test-1", "test-2", "test-3", "test-4", "test-5", "test-6", "test-7", "test-8", "test-9",
+		"test-10", "test-11", "test-12", "test-13", "test-14", "test-15", "test-16", "test-17", "test-18", "test-19",
+		"setup", "upgrade", "verify",
+	}, ran)
+}
+
+func TestRunTests_ShardSuiteReferences(t *testing.T) {
+	spec := &api.TestSuiteSpec{
+		DependsOn: []string{"setup_xprin.yaml"},
+		Tests: []api.TestCase{
+			{Name: "upgrade", Inputs: api.Inputs{XR: placeholders(`{{ (index .Suites "setup").Tests.xr.Outputs.XR }}`)}},
+		},
+	}
+
+	r := newShardRunner(spec, 0, 2)
+	r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+		t.Errorf("test case %s ran", testCase.Name)
+		return createTestCaseResult(testCase.Name, true, nil)
+	}
+
+	require.EqualError(t, r.RunTests(), "cannot shard a testsuite referencing the results of other testsuites with .Suites, as they can run on other shards")
+
+	t.Run("without sharding", func(t *testing.T) {
+		require.NoError(t, newShardRunner(spec, 0, 0).checkShardable())
+	})
+
+	t.Run("without references", func(t *testing.T) {
+		require.NoError(t, newShardRunner(shardSpec(), 0, 2).checkShardable())
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/crossplane-contrib/xprin/internal/validator"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// errorOnMissingSchemasFlag is the flag of the validate subcommand that makes resources without a CRD fail validation.
+// The builtin validator honours it too, so that both validators fail in the same cases.
+const errorOnMissingSchemasFlag = "--error-on-missing-schemas"
+
+// validateBuiltin validates the rendered resources in-process against the CRDs and XRDs in crdsDir.
+func (r *Runner) validateBuiltin(result *engine.TestCaseResult, crdsDir string) error {
+	crds, err := validator.LoadCRDs(r.fs, []string{crdsDir})
+	if err != nil {
+		return err
+	}
+
+	v, err := validator.New(crds)
+	if err != nil {
+		return fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Validating %d rendered resources with the builtin validator against %d CRDs\n", len(result.RenderedResources), len(crds))
+	}
+
+	result.ProcessValidateResults(v.Validate(context.Background(), result.RenderedResources), slices.Contains(r.Validate, errorOnMissingSchemasFlag))
+
+	return nil
+}
+
+// validateInputs validates the input XR and, when set, the Claim against the CRDs in crdsDir with the builtin
+// validator, reporting unknown fields as pruning warnings. It only runs for the test cases that are validated, i.e.
+// with crds or crds-from, and is skipped when no CRD defines the inputs, e.g. when the test case has no XRD.
+func (r *Runner) validateInputs(result *engine.TestCaseResult, crdsDir, inputXR, claim string) error {
+	crds, err := validator.LoadCRDs(r.fs, []string{crdsDir})
+	if err != nil {
+		return err
+	}
+
+	if len(crds) == 0 {
+		if r.Debug {
+			utils.DebugPrintf("Skipped input validation because no CRDs were found\n")
+		}
+
+		return nil
+	}
+
+	v, err := validator.New(crds)
+	if err != nil {
+		return fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	var inputs []*unstructured.Unstructured
+
+	for _, file := range []string{inputXR, claim} {
+		if file == "" {
+			continue
+		}
+
+		resources, err := validator.LoadResources(r.fs, file)
+		if err != nil {
+			return err
+		}
+
+		inputs = append(inputs, resources...)
+	}
+
+	results := v.ValidateInputs(context.Background(), inputs)
+	if !slices.ContainsFunc(results, func(result engine.ValidateResult) bool { return !result.MissingSchema }) {
+		if r.Debug {
+			utils.DebugPrintf("Skipped input validation because no XRD defines the inputs\n")
+		}
+
+		return nil
+	}
+
+	result.ProcessInputValidateResults(results, r.InputWarningsFatal)
+
+	inputValidateOutputFile := filepath.Join(r.outputsDir, "input-validate.txt")
+	if err := afero.WriteFile(r.fs, inputValidateOutputFile, result.RawInputValidateOutput, 0o600); err != nil {
+		return fmt.Errorf("failed to write input validation output to file: %w", err)
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Wrote input validation output to: %s\n", inputValidateOutputFile)
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const validateTestXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.org
+spec:
+  group: example.org
+  names:
+    kind: XBucket
+    plural: xbuckets
+  versions:
+    - name: v1
+      served: true
+      referenceable: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              required: [region]
+              properties:
+                region:
+                  type: string
+`
+
+const validTestXR = `apiVersion: example.org/v1
+kind: XBucket
+metadata:
+  name: bucket
+spec:
+  region: eu-west-1
+`
+
+func TestValidateBuiltin(t *testing.T) {
+	newValidateRunner := func(validate []string) *Runner {
+		r := NewRunner(&testexecutionUtils.Options{Validate: validate, BuiltinValidator: true}, testSuiteFile, &api.TestSuiteSpec{})
+		r.fs = afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/crds/xrd.yaml", []byte(validateTestXRD), 0o600))
+
+		return r
+	}
+
+	rendered := []byte(`apiVersion: example.org/v1
+kind: XBucket
+metadata:
+  name: bucket
+spec:
+  regoin: eu-west-1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`)
+
+	t.Run("invalid resources fail validation", func(t *testing.T) {
+		r := newValidateRunner(nil)
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+		require.NoError(t, result.ProcessRenderOutput(rendered))
+
+		require.NoError(t, r.validateBuiltin(result, "/inputs/crds"))
+
+		assert.True(t, result.HasFailedValidate)
+		require.Len(t, result.ValidateResults, 2)
+		assert.Equal(t, []engine.ValidateError{
+			{Type: engine.ValidateErrorSchema, Field: "spec.region", Message: "Required value"},
+			{Type: engine.ValidateErrorSchema, Field: "spec.regoin", Message: `Invalid value: "regoin": unknown field: "regoin"`},
+		}, result.ValidateResults[0].Errors)
+		assert.True(t, result.ValidateResults[1].MissingSchema)
+		assert.Contains(t, string(result.RawValidateOutput), "[x] schema validation error example.org/v1, Kind=XBucket, bucket : spec.region: Required value")
+	})
+
+	t.Run("missing schemas fail with --error-on-missing-schemas", func(t *testing.T) {
+		valid := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n")
+
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+		require.NoError(t, result.ProcessRenderOutput(valid))
+		require.NoError(t, newValidateRunner([]string{"beta", "validate"}).validateBuiltin(result, "/inputs/crds"))
+		assert.False(t, result.HasFailedValidate)
+
+		result = engine.NewTestCaseResult("test", "", false, false, false, false, false)
+		require.NoError(t, result.ProcessRenderOutput(valid))
+		require.NoError(t, newValidateRunner([]string{"beta", "validate", "--error-on-missing-schemas"}).validateBuiltin(result, "/inputs/crds"))
+		assert.True(t, result.HasFailedValidate)
+	})
+
+	t.Run("invalid CRDs", func(t *testing.T) {
+		r := newValidateRunner(nil)
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/crds/invalid.yaml", []byte("kind: [unclosed"), 0o600))
+
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+		require.ErrorContains(t, r.validateBuiltin(result, "/inputs/crds"), "failed to load CRDs from /inputs/crds/invalid.yaml")
+	})
+}
+
+func TestValidateInputs(t *testing.T) {
+	newInputsRunner := func(xr string, warningsFatal bool) *Runner {
+		r := NewRunner(&testexecutionUtils.Options{InputWarningsFatal: warningsFatal}, testSuiteFile, &api.TestSuiteSpec{})
+		r.fs = afero.NewMemMapFs()
+		r.outputsDir = "/outputs"
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/crds/xrd.yaml", []byte(validateTestXRD), 0o600))
+		require.NoError(t, afero.WriteFile(r.fs, "/inputs/xr/xr.yaml", []byte(xr), 0o600))
+
+		return r
+	}
+
+	typoXR := validTestXR + "  regoin: eu-west-1\n"
+
+	t.Run("valid XR", func(t *testing.T) {
+		r := newInputsRunner(validTestXR, false)
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+
+		require.NoError(t, r.validateInputs(result, "/inputs/crds", "/inputs/xr/xr.yaml", ""))
+
+		assert.False(t, result.HasFailedInputValidate)
+		require.Len(t, result.InputValidateResults, 1)
+
+		data, err := afero.ReadFile(r.fs, "/outputs/input-validate.txt")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "[✓] example.org/v1, Kind=XBucket, bucket validated successfully")
+	})
+
+	t.Run("unknown fields are pruning warnings", func(t *testing.T) {
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+
+		require.NoError(t, newInputsRunner(typoXR, false).validateInputs(result, "/inputs/crds", "/inputs/xr/xr.yaml", ""))
+
+		assert.False(t, result.HasFailedInputValidate)
+		assert.Contains(t, string(result.RawInputValidateOutput), `[!] pruning warning example.org/v1, Kind=XBucket, bucket : spec.regoin: unknown field "regoin" would be pruned`)
+	})
+
+	t.Run("pruning warnings fail with --input-warnings-as-errors", func(t *testing.T) {
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+
+		require.NoError(t, newInputsRunner(typoXR, true).validateInputs(result, "/inputs/crds", "/inputs/xr/xr.yaml", ""))
+
+		assert.True(t, result.HasFailedInputValidate)
+	})
+
+	t.Run("missing required fields fail", func(t *testing.T) {
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+
+		require.NoError(t, newInputsRunner("apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\nspec: {}\n", false).validateInputs(result, "/inputs/crds", "/inputs/xr/xr.yaml", ""))
+
+		assert.True(t, result.HasFailedInputValidate)
+		assert.Contains(t, string(result.RawInputValidateOutput), "spec.region: Required value")
+	})
+
+	t.Run("skipped without an XRD of the inputs", func(t *testing.T) {
+		r := newInputsRunner("apiVersion: example.org/v1\nkind: XQueue\nmetadata:\n  name: queue\n", false)
+		result := engine.NewTestCaseResult("test", "", false, false, false, false, false)
+
+		require.NoError(t, r.validateInputs(result, "/inputs/crds", "/inputs/xr/xr.yaml", ""))
+
+		assert.Nil(t, result.InputValidateResults)
+		assert.False(t, result.HasFailedInputValidate)
+	})
+}
+
+func TestRunTestCase_InputValidation(t *testing.T) {
+	testCase := api.TestCase{
+		Name:    "test",
+		Inputs:  api.Inputs{XR: "xr.yaml", Composition: "comp.yaml", Functions: "functions.yaml", CRDs: []string{"crd.yaml"}},
+		Patches: api.Patches{XRD: "xrd.yaml"},
+	}
+
+	run := func(t *testing.T, testCase api.TestCase, xr string) (*engine.TestCaseResult, bool, bool) {
+		t.Helper()
+
+		rendered, validated := false, false
+		r := newMockRunner(&testexecutionUtils.Options{Dependencies: map[string]string{"crossplane": "crossplane"}, Render: []string{"render"}, Validate: []string{"beta", "validate"}}, mockCopyXRD)
+		r.fs = afero.NewMemMapFs()
+		r.testSuiteSpec = &api.TestSuiteSpec{}
+		r.patchXRFunc = func(_ *Runner, _, outputPath string, _ api.Patches) (string, error) {
+			path := filepath.Join(outputPath, "patched-xr.yaml")
+
+			return path, afero.WriteFile(r.fs, path, []byte(xr), 0o600)
+		}
+		r.runCommand = func(_ string, args ...string) ([]byte, error) {
+			if len(args) > 0 && args[0] == "render" {
+				rendered = true
+			}
+
+			if len(args) > 0 && args[0] == "beta" {
+				validated = true
+			}
+
+			return []byte(validTestXR), nil
+		}
+
+		return r.runTestCase(testCase, engine.NewTestSuiteResult("test-suite.yaml", false)), rendered, validated
+	}
+
+	t.Run("valid inputs are rendered", func(t *testing.T) {
+		result, rendered, validated := run(t, testCase, validTestXR)
+
+		assert.Equal(t, engine.StatusPass(), result.Status)
+		assert.True(t, rendered)
+		assert.True(t, validated)
+		require.Len(t, result.InputValidateResults, 1)
+	})
+
+	t.Run("XRD patch without crds is not validated", func(t *testing.T) {
+		withoutCRDs := testCase
+		withoutCRDs.Inputs.CRDs = nil
+
+		result, rendered, validated := run(t, withoutCRDs, validTestXR)
+
+		assert.Equal(t, engine.StatusPass(), result.Status)
+		assert.True(t, rendered)
+		assert.False(t, validated)
+		assert.Nil(t, result.Outputs.Validate)
+		assert.Nil(t, result.InputValidateResults)
+		assert.Empty(t, result.RawInputValidateOutput)
+	})
+
+	t.Run("invalid inputs are rendered without crds", func(t *testing.T) {
+		withoutCRDs := testCase
+		withoutCRDs.Inputs.CRDs = nil
+
+		result, rendered, _ := run(t, withoutCRDs, "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\nspec:\n  regoin: eu-west-1\n")
+
+		assert.True(t, rendered)
+		assert.False(t, result.HasFailedInputValidate)
+		assert.Nil(t, result.InputValidateResults)
+	})
+
+	t.Run("invalid inputs fail before render", func(t *testing.T) {
+		result, rendered, _ := run(t, testCase, "apiVersion: example.org/v1\nkind: XBucket\nmetadata:\n  name: bucket\nspec:\n  regoin: eu-west-1\n")
+
+		assert.Equal(t, engine.StatusFail(), result.Status)
+		assert.False(t, rendered)
+		assert.True(t, result.HasFailedInputValidate)
+		assert.Contains(t, result.FormattedInputValidateOutput, "spec.region: Required value")
+		assert.Contains(t, result.FormattedInputValidateOutput, "spec.regoin: unknown field")
+	})
+}
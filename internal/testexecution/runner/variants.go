@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/generatexr"
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+)
+
+// expandXRVariants replaces every test case with xr-variants by one test case per XR generated from its XRD, named
+// after the variant and with the generated XR as xr input. The test cases keep their xr-variants, so that changes to
+// the XRD select them.
+func (r *Runner) expandXRVariants() error {
+	if !slices.ContainsFunc(r.testSuiteSpec.Tests, needsXRVariants) {
+		return nil
+	}
+
+	var err error
+
+	r.xrVariantsDir, err = afero.TempDir(r.fs, "", "xprin-xr-variants-")
+	if err != nil {
+		return fmt.Errorf("failed to create XR variants directory: %w", err)
+	}
+
+	tests := make([]api.TestCase, 0, len(r.testSuiteSpec.Tests))
+
+	for i, testCase := range r.testSuiteSpec.Tests {
+		if !needsXRVariants(testCase) {
+			tests = append(tests, testCase)
+			continue
+		}
+
+		variants, err := r.generateXRVariants(*testCase.XRVariants)
+		if err != nil {
+			return fmt.Errorf("failed to generate the XR variants of test case '%s': %w", testCase.Name, err)
+		}
+
+		if r.Debug {
+			utils.DebugPrintf("Generated %d XR variants for test case '%s'\n", len(variants), testCase.Name)
+		}
+
+		for j, variant := range variants {
+			file := filepath.Join(r.xrVariantsDir, fmt.Sprintf("%d-%d.yaml", i, j))
+
+			data, err := yaml.Marshal(variant.XR.Object)
+			if err != nil {
+				return fmt.Errorf("failed to marshal XR variant %s of test case '%s': %w", variant.Name, testCase.Name, err)
+			}
+
+			if err := afero.WriteFile(r.fs, file, data, 0o600); err != nil {
+				return fmt.Errorf("failed to write XR variant %s of test case '%s': %w", variant.Name, testCase.Name, err)
+			}
+
+			// runTestCase replaces the input paths in place, so the variants cannot share them
+			variantTestCase := testCase
+			variantTestCase.Name = fmt.Sprintf("%s [%s]", testCase.Name, variant.Name)
+			variantTestCase.Inputs.XR = file
+			variantTestCase.Inputs.CRDs = slices.Clone(testCase.Inputs.CRDs)
+			variantTestCase.Inputs.CRDsFrom = slices.Clone(testCase.Inputs.CRDsFrom)
+			variantTestCase.Inputs.ContextFiles = maps.Clone(testCase.Inputs.ContextFiles)
+			tests = append(tests, variantTestCase)
+		}
+	}
+
+	spec := *r.testSuiteSpec
+	spec.Tests = tests
+	r.testSuiteSpec = &spec
+
+	return nil
+}
+
+// needsXRVariants reports whether a test case has xr-variants that were not expanded yet.
+func needsXRVariants(testCase api.TestCase) bool {
+	return testCase.XRVariants != nil && !testCase.HasXR()
+}
+
+// generateXRVariants generates the XRs of xr-variants from their XRD, resolved relative to the testsuite file.
+func (r *Runner) generateXRVariants(variants api.XRVariants) ([]generatexr.Variant, error) {
+	path, err := r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, variants.XRD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand XRD path: %w", err)
+	}
+
+	data, err := afero.ReadFile(r.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XRD: %w", err)
+	}
+
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	if err := yaml.Unmarshal(data, xrd); err != nil {
+		return nil, fmt.Errorf("failed to parse XRD %s: %w", path, err)
+	}
+
+	return generatexr.Generate(xrd, generatexr.Options{Version: variants.Version, Kinds: variants.Kinds})
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"sigs.k8s.io/yaml"
+)
+
+func TestExpandXRVariants(t *testing.T) {
+	newVariantsRunner := func(tests ...api.TestCase) *Runner {
+		r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Tests: tests})
+		r.fs = afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(r.fs, "/xrd.yaml", []byte(validateTestXRD), 0o600))
+
+		return r
+	}
+
+	t.Run("one test case per variant", func(t *testing.T) {
+		spec := &api.TestSuiteSpec{Tests: []api.TestCase{
+			{Name: "plain", Inputs: api.Inputs{XR: "xr.yaml"}},
+			{Name: "generated", Inputs: api.Inputs{CRDs: []string{"crd.yaml"}}, XRVariants: &api.XRVariants{XRD: "xrd.yaml", Kinds: []string{"minimal", "maximal"}}},
+		}}
+		r := newVariantsRunner(spec.Tests...)
+		r.testSuiteSpec = spec
+
+		require.NoError(t, r.expandXRVariants())
+
+		tests := r.testSuiteSpec.Tests
+		require.Len(t, tests, 3)
+		assert.Equal(t, "plain", tests[0].Name)
+		assert.Equal(t, "generated [minimal]", tests[1].Name)
+		assert.Equal(t, "generated [maximal]", tests[2].Name)
+		assert.Len(t, spec.Tests, 2, "the testsuite specification must not be modified")
+
+		data, err := afero.ReadFile(r.fs, tests[1].Inputs.XR)
+		require.NoError(t, err)
+
+		xr := map[string]any{}
+		require.NoError(t, yaml.Unmarshal(data, &xr))
+		assert.Equal(t, map[string]any{"region": "value"}, xr["spec"])
+
+		tests[1].Inputs.CRDs[0] = "/tmp/copied.yaml"
+		assert.Equal(t, "crd.yaml", tests[2].Inputs.CRDs[0], "variants must not share their input paths")
+
+		require.NoError(t, r.expandXRVariants())
+		assert.Len(t, r.testSuiteSpec.Tests, 3, "expanded test cases must not be expanded again")
+
+		r.Cleanup()
+
+		exists, err := afero.Exists(r.fs, tests[1].Inputs.XR)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("missing XRD", func(t *testing.T) {
+		r := newVariantsRunner(api.TestCase{Name: "generated", XRVariants: &api.XRVariants{XRD: "missing.yaml"}})
+		require.ErrorContains(t, r.expandXRVariants(), "failed to generate the XR variants of test case 'generated'")
+	})
+}
+
+func TestRunTests_XRVariants(t *testing.T) {
+	r := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "generated", XRVariants: &api.XRVariants{XRD: "xrd.yaml", Kinds: []string{"minimal", "maximal"}}},
+	}})
+	r.fs = afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(r.fs, "/xrd.yaml", []byte(validateTestXRD), 0o600))
+
+	var out bytes.Buffer
+
+	r.output = &out
+
+	var ran []string
+
+	r.runTestCaseFunc = func(testCase api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, testCase.Name)
+		return createTestCaseResult(testCase.Name, false, nil)
+	}
+
+	require.NoError(t, r.RunTests())
+	assert.Equal(t, []string{"generated [minimal]", "generated [maximal]"}, ran)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ChangedFiles returns the absolute paths of the files that changed since ref in the git repository containing dir:
+// files that differ between ref and HEAD (including deleted and renamed files) and files with staged, unstaged or
+// untracked changes in the working tree.
+func ChangedFiles(dir, ref string) ([]string, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository for %s: %w", dir, err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git worktree: %w", err)
+	}
+
+	fromTree, err := commitTree(repo, plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+
+	headTree, err := commitTree(repo, plumbing.Revision(plumbing.HEAD))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	changes, err := object.DiffTree(fromTree, headTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s with HEAD: %w", ref, err)
+	}
+
+	names := make(map[string]bool)
+
+	for _, change := range changes {
+		if change.From.Name != "" {
+			names[change.From.Name] = true
+		}
+
+		if change.To.Name != "" {
+			names[change.To.Name] = true
+		}
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git worktree status: %w", err)
+	}
+
+	for name, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			names[name] = true
+		}
+	}
+
+	root := worktree.Filesystem.Root()
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	files := make([]string, 0, len(names))
+	for name := range names {
+		files = append(files, filepath.Join(root, filepath.FromSlash(name)))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// commitTree returns the tree of the commit that revision resolves to.
+func commitTree(repo *git.Repository, revision plumbing.Revision) (*object.Tree, error) {
+	hash, err := repo.ResolveRevision(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return commit.Tree()
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestChangedFiles(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	repo, err := git.PlainInit(root, false)
+	require.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	write := func(name, content string) {
+		t.Helper()
+
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	commit := func(message string) {
+		t.Helper()
+
+		require.NoError(t, worktree.AddGlob("."))
+
+		_, err := worktree.Commit(message, &git.CommitOptions{
+			All:    true,
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+
+	write("compositions/aws.yaml", "v1")
+	write("compositions/gcp.yaml", "v1")
+	write("functions.yaml", "v1")
+	write("removed.yaml", "v1")
+	commit("initial")
+
+	_, err = repo.CreateTag("base", mustHead(t, repo), nil)
+	require.NoError(t, err)
+
+	write("compositions/aws.yaml", "v2")
+	require.NoError(t, os.Remove(filepath.Join(root, "removed.yaml")))
+	commit("change aws")
+
+	write("functions.yaml", "v2")        // unstaged change
+	write("tests/new_xprin.yaml", "new") // untracked file
+
+	t.Run("changes since a tag", func(t *testing.T) {
+		files, err := ChangedFiles(filepath.Join(root, "compositions"), "base")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			filepath.Join(root, "compositions", "aws.yaml"),
+			filepath.Join(root, "functions.yaml"),
+			filepath.Join(root, "removed.yaml"),
+			filepath.Join(root, "tests", "new_xprin.yaml"),
+		}, files)
+	})
+
+	t.Run("only working tree changes since HEAD", func(t *testing.T) {
+		files, err := ChangedFiles(root, "HEAD")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			filepath.Join(root, "functions.yaml"),
+			filepath.Join(root, "tests", "new_xprin.yaml"),
+		}, files)
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := ChangedFiles(root, "does-not-exist")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve does-not-exist")
+	})
+
+	t.Run("not a git repository", func(t *testing.T) {
+		_, err := ChangedFiles(t.TempDir(), "HEAD")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open git repository")
+	})
+}
+
+func mustHead(t *testing.T, repo *git.Repository) plumbing.Hash {
+	t.Helper()
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	return head.Hash()
+}
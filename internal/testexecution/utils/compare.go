@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Prefixes of --compare-with values that do not name a dependency.
+const (
+	CompareFunctionsPrefix = "functions:"
+	CompareGitPrefix       = "git:"
+)
+
+// ComparisonKind is the kind of baseline a test case render is compared with.
+type ComparisonKind string
+
+// Supported comparison kinds.
+const (
+	ComparisonDependency ComparisonKind = "dependency" // Render with another crossplane dependency
+	ComparisonFunctions  ComparisonKind = "functions"  // Render with an alternate functions file
+	ComparisonGit        ComparisonKind = "git"        // Render with the composition at another git ref of a repository
+)
+
+// Comparison describes the baseline render of a differential test run (from --compare-with).
+type Comparison struct {
+	Kind       ComparisonKind
+	Dependency string // Crossplane dependency name (ComparisonDependency)
+	Functions  string // Path to the alternate functions file or directory (ComparisonFunctions)
+	Repository string // Name of the configured repository holding the composition (ComparisonGit)
+	Ref        string // Git ref the composition is read from (ComparisonGit)
+}
+
+// ParseComparison parses a --compare-with value: a dependency name (e.g. crossplane-2.0),
+// functions:<path> or git:<repository>@<ref>.
+func ParseComparison(value string) (*Comparison, error) {
+	switch {
+	case value == "":
+		return nil, fmt.Errorf("comparison must not be empty")
+	case strings.HasPrefix(value, CompareFunctionsPrefix):
+		path := strings.TrimPrefix(value, CompareFunctionsPrefix)
+		if path == "" {
+			return nil, fmt.Errorf("invalid comparison %q: expected %s<path>", value, CompareFunctionsPrefix)
+		}
+
+		return &Comparison{Kind: ComparisonFunctions, Functions: path}, nil
+	case strings.HasPrefix(value, CompareGitPrefix):
+		repository, ref, found := strings.Cut(strings.TrimPrefix(value, CompareGitPrefix), "@")
+		if !found || repository == "" || ref == "" {
+			return nil, fmt.Errorf("invalid comparison %q: expected %s<repository>@<ref>", value, CompareGitPrefix)
+		}
+
+		return &Comparison{Kind: ComparisonGit, Repository: repository, Ref: ref}, nil
+	default:
+		return &Comparison{Kind: ComparisonDependency, Dependency: value}, nil
+	}
+}
+
+// String returns the comparison in --compare-with form.
+func (c *Comparison) String() string {
+	switch c.Kind {
+	case ComparisonFunctions:
+		return CompareFunctionsPrefix + c.Functions
+	case ComparisonGit:
+		return CompareGitPrefix + c.Repository + "@" + c.Ref
+	default:
+		return c.Dependency
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestParseComparison(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		want        *Comparison
+		errContains string
+	}{
+		{
+			name:  "dependency",
+			value: "crossplane-2.0",
+			want:  &Comparison{Kind: ComparisonDependency, Dependency: "crossplane-2.0"},
+		},
+		{
+			name:  "functions file",
+			value: "functions:/tmp/functions-next.yaml",
+			want:  &Comparison{Kind: ComparisonFunctions, Functions: "/tmp/functions-next.yaml"},
+		},
+		{
+			name:  "git ref",
+			value: "git:mycompositions@v1.2.0",
+			want:  &Comparison{Kind: ComparisonGit, Repository: "mycompositions", Ref: "v1.2.0"},
+		},
+		{
+			name:  "git ref containing @",
+			value: "git:mycompositions@main@{1}",
+			want:  &Comparison{Kind: ComparisonGit, Repository: "mycompositions", Ref: "main@{1}"},
+		},
+		{
+			name:        "empty",
+			value:       "",
+			errContains: "comparison must not be empty",
+		},
+		{
+			name:        "functions without path",
+			value:       "functions:",
+			errContains: "expected functions:<path>",
+		},
+		{
+			name:        "git without ref",
+			value:       "git:mycompositions",
+			errContains: "expected git:<repository>@<ref>",
+		},
+		{
+			name:        "git without repository",
+			value:       "git:@main",
+			errContains: "expected git:<repository>@<ref>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseComparison(tt.value)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.value, got.String())
+		})
+	}
+}
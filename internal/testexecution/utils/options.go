@@ -17,17 +17,74 @@ limitations under the License.
 // Package utils provides shared utilities for test execution including options, path expansion, and template processing.
 package utils
 
+import (
+	"io"
+
+	"github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/coverage"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/mutation"
+)
+
+// DefaultCrossplane is the name of the crossplane dependency used when no other one is selected.
+const DefaultCrossplane = "crossplane"
+
+// Retention policies of the artifacts written to Options.ArtifactsDir.
+const (
+	ArtifactsRetentionAlways    = "always"     // Keep the artifacts of every test case that ran
+	ArtifactsRetentionOnFailure = "on-failure" // Keep the artifacts of failed test cases only
+)
+
 // Options groups all test runner options for easier passing to ProcessTargets and related functions.
 type Options struct {
-	Dependencies   map[string]string
-	Repositories   map[string]string
-	ShowRender     bool
-	ShowValidate   bool
-	ShowHooks      bool
-	ShowAssertions bool
-	Verbose        bool
-	Debug          bool
-	Color          bool // When true, diff output is colorized (resolved from --color on|off|auto in the CLI).
-	Render         []string
-	Validate       []string
+	Dependencies       map[string]string
+	Repositories       map[string]string
+	ShowRender         bool
+	ShowValidate       bool
+	ShowHooks          bool
+	ShowAssertions     bool
+	Verbose            bool
+	Debug              bool
+	Color              bool // When true, diff output is colorized (resolved from --color on|off|auto in the CLI).
+	Render             []string
+	Validate           []string
+	BuiltinValidator   bool                               // Validate the rendered resources in-process instead of with the crossplane validate subcommand.
+	InputWarningsFatal bool                               // Fail the input validation on pruning warnings too (from --input-warnings-as-errors).
+	ConfigVars         map[string]string                  // Template variables from the xprin config file (lowest precedence).
+	Vars               map[string]string                  // Template variables from --var and --var-file (highest precedence).
+	Crossplane         string                             // Name of the crossplane dependency used for render and validate (DefaultCrossplane when empty).
+	CrossplaneRuns     []string                           // Crossplane dependencies to run every testsuite with (from --crossplane); empty means a single run.
+	Label              string                             // Label shown next to test names and testsuite paths (the crossplane dependency of a matrix run).
+	CompareWith        *Comparison                        // Baseline every render is compared with (from --compare-with); nil disables comparisons.
+	ChangedSince       string                             // Git ref from --changed-since; when set, only test cases affected by ChangedFiles run.
+	ChangedFiles       []string                           // Absolute paths of the files changed since ChangedSince.
+	List               bool                               // Print the selected test cases instead of running them (from --list).
+	DryRun             bool                               // Prepare the test cases up to the copy of their inputs, without running hooks, render or validate (from --dry-run).
+	Include            []string                           // File name patterns of testsuite files (from --include or the config); empty means the default patterns.
+	Exclude            []string                           // Gitignore-style patterns of paths skipped by discovery (from the config and --exclude).
+	ShardIndex         int                                // Shard of the test cases to run, between 0 and ShardTotal-1 (from --shard-index).
+	ShardTotal         int                                // Number of shards the test cases are partitioned into (from --shard-total); 0 disables sharding.
+	ArtifactsDir       string                             // Directory the artifacts of test cases are kept in (from --artifacts-dir); empty keeps none.
+	ArtifactsRetention string                             // Which artifacts to keep: ArtifactsRetentionAlways or ArtifactsRetentionOnFailure.
+	CRDsCacheDir       string                             // Package cache directory the CRDs of crds-from inputs are loaded from (from --crds-cache-dir).
+	Suites             map[string]*engine.TestSuiteResult // Results of the named testsuites the testsuite depends on, set per testsuite by the processor.
+	Coverage           bool                               // Report the coverage of the Compositions and XRDs by the test cases (from --coverage).
+	CoverageFile       string                             // File the machine-readable coverage report is written to (from --coverage-file).
+	CoverageReport     *coverage.Report                   // Coverage of the current run, set by the processor when Coverage is set.
+	Mutant             *mutation.Mutant                   // Mutated Composition the test cases run with, set by the processor for xprin mutate.
+	Output             io.Writer                          // Writer the test results are printed to; nil means stdout.
+}
+
+// CrossplaneDependencies returns the sorted names of the configured crossplane dependencies.
+func (o *Options) CrossplaneDependencies() []string {
+	return config.CrossplaneDependencies(o.Dependencies)
+}
+
+// CrossplaneDependency returns the name of the crossplane dependency to use.
+func (o *Options) CrossplaneDependency() string {
+	if o.Crossplane == "" {
+		return DefaultCrossplane
+	}
+
+	return o.Crossplane
 }
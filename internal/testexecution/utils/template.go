@@ -54,3 +54,26 @@ func RestoreTemplateVars(content string) string {
 
 	return content
 }
+
+// TestReferenceRe matches the references to the results of other test cases in template expressions,
+// {{ .Tests.<id> }} or {{ index .Tests "<id>" }}, with the ID in its first or second group.
+var TestReferenceRe = regexp.MustCompile(`(?:^|[^\w.])\.Tests\.([A-Za-z0-9_]+)|index\s+\.Tests\s+"([^"]+)"`) //nolint:gochecknoglobals // compiled once
+
+// TestReferences returns the IDs of the test cases whose results content references, in order of appearance.
+func TestReferences(content string) []string {
+	var ids []string
+
+	for _, match := range TestReferenceRe.FindAllStringSubmatch(content, -1) {
+		if match[1] != "" {
+			ids = append(ids, match[1])
+		} else {
+			ids = append(ids, match[2])
+		}
+	}
+
+	return ids
+}
+
+// SuiteTestReferenceRe matches the references to the results of test cases of other testsuites in template
+// expressions, {{ .Suites.<name>.Tests.<id> }}, with the testsuite name in its first group and the ID in its second.
+var SuiteTestReferenceRe = regexp.MustCompile(`(?:^|[^\w.])\.Suites\.([A-Za-z0-9_]+)\.Tests\.([A-Za-z0-9_]+)`) //nolint:gochecknoglobals // compiled once
@@ -17,6 +17,7 @@ limitations under the License.
 package utils
 
 import (
+	"slices"
 	"strings"
 	"testing"
 )
@@ -262,3 +263,29 @@ func extractTemplateVarNames(content string) []string {
 
 	return vars
 }
+
+func TestTestReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "field and index references",
+			content: `{{ .Tests.a.Outputs.XR }} {{ index .Tests "b-1" }} {{.Tests.c}}`,
+			want:    []string{"a", "b-1", "c"},
+		},
+		{
+			name:    "other references",
+			content: `{{ .Vars.Tests.a }} {{ .Suites.s.Tests.a }} {{ .Inputs.XR }}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TestReferences(tt.content); !slices.Equal(got, tt.want) {
+				t.Errorf("TestReferences() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadVarsFile reads a YAML file with a flat map of template variables.
+// Scalar values (strings, numbers, booleans) are kept as written; nested maps and lists are rejected.
+func LoadVarsFile(fs afero.Fs, path string) (map[string]string, error) {
+	expandedPath, err := utils.ExpandTildeAbs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand vars file path %s: %w", path, err)
+	}
+
+	data, err := afero.ReadFile(fs, expandedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vars file %s: %w", path, err)
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse vars file %s: %w", path, err)
+	}
+
+	vars := make(map[string]string, len(raw))
+
+	var invalidKeys []string
+
+	for key, node := range raw {
+		if node.Kind == yaml.AliasNode {
+			node = *node.Alias
+		}
+
+		switch {
+		case node.Kind != yaml.ScalarNode:
+			invalidKeys = append(invalidKeys, key)
+		case node.Tag == "!!null":
+			vars[key] = ""
+		default:
+			// Keep the scalar as written, e.g. 1000000 or 1.10 rather than 1e+06 or 1.1
+			vars[key] = node.Value
+		}
+	}
+
+	if len(invalidKeys) > 0 {
+		sort.Strings(invalidKeys)
+		return nil, fmt.Errorf("vars file %s: values must be scalars, got a map or list for %v", path, invalidKeys)
+	}
+
+	return vars, nil
+}
+
+// MergeVars merges template variable layers in order of increasing precedence:
+// keys in later layers override the same keys in earlier layers. Nil layers are skipped.
+func MergeVars(layers ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, layer := range layers {
+		maps.Copy(merged, layer)
+	}
+
+	return merged
+}
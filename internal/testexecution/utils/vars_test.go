@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestLoadVarsFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		want        map[string]string
+		errContains string
+	}{
+		{
+			name:    "string values",
+			content: "branch: main\nregion: eu-west-1\n",
+			want:    map[string]string{"branch": "main", "region": "eu-west-1"},
+		},
+		{
+			name:    "scalar values are converted to strings",
+			content: "replicas: 3\nratio: 1.5\nenabled: true\nempty:\n",
+			want:    map[string]string{"replicas": "3", "ratio": "1.5", "enabled": "true", "empty": ""},
+		},
+		{
+			name:    "numbers are kept as written",
+			content: "size: 1000000\nbig: 12345678901234567890\nversion: 1.10\nunset: ~\n",
+			want:    map[string]string{"size": "1000000", "big": "12345678901234567890", "version": "1.10", "unset": ""},
+		},
+		{
+			name:    "JSON file",
+			content: `{"size": 1000000, "branch": "main", "enabled": false}`,
+			want:    map[string]string{"size": "1000000", "branch": "main", "enabled": "false"},
+		},
+		{
+			name:    "empty file",
+			content: "",
+			want:    map[string]string{},
+		},
+		{
+			name:        "nested values are rejected",
+			content:     "provider:\n  version: v1\nlist: [a, b]\n",
+			errContains: "values must be scalars, got a map or list for [list provider]",
+		},
+		{
+			name:        "invalid YAML",
+			content:     "branch: [main\n",
+			errContains: "failed to parse vars file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "/vars.yaml", []byte(tt.content), 0o600))
+
+			got, err := LoadVarsFile(fs, "/vars.yaml")
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadVarsFile(afero.NewMemMapFs(), "/missing.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read vars file /missing.yaml")
+	})
+}
+
+func TestMergeVars(t *testing.T) {
+	config := map[string]string{"branch": "config", "region": "eu"}
+	suite := map[string]string{"branch": "suite", "provider": "v1"}
+	cli := map[string]string{"branch": "cli"}
+
+	got := MergeVars(config, suite, nil, cli)
+	assert.Equal(t, map[string]string{"branch": "cli", "region": "eu", "provider": "v1"}, got)
+
+	assert.Equal(t, map[string]string{}, MergeVars())
+	assert.Equal(t, "config", config["branch"], "input layers must not be modified")
+}
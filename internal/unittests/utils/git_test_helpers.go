@@ -107,6 +107,7 @@ func CreateGitRepo(t *testing.T, opts GitRepoOptions) {
 		}
 
 		_, err = wt.Commit("Initial commit", &git.CommitOptions{
+			AllowEmptyCommits: true,
 			Author: &object.Signature{
 				Name:  opts.UserName,
 				Email: opts.UserEmail,
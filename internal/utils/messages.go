@@ -14,7 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package utils provides utility functions for printing messages and for path expansion and validation.
+// Package utils provides utility functions for printing messages, for path expansion and validation, and for
+// unstructured objects.
 package utils
 
 import (
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/xrdtocrd"
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/spf13/afero"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Groups of the definitions loaded by LoadCRDs.
+const (
+	crdGroup = "apiextensions.k8s.io"
+	xrdGroup = "apiextensions.crossplane.io"
+)
+
+// LoadCRDs reads the CRDs in the given files and directories (recursively, .yaml, .yml and .json files).
+// XRDs are converted to the CRDs Crossplane generates for them: the CRD of their composite resource and, with
+// claimNames, the CRD of their claim. Other resources are ignored.
+func LoadCRDs(fs afero.Fs, paths []string) ([]*extv1.CustomResourceDefinition, error) {
+	var crds []*extv1.CustomResourceDefinition
+
+	err := walkManifests(fs, paths, func(file string) error {
+		fileCRDs, err := loadFile(fs, file)
+		if err != nil {
+			return fmt.Errorf("failed to load CRDs from %s: %w", file, err)
+		}
+
+		crds = append(crds, fileCRDs...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return crds, nil
+}
+
+// LoadXRDs reads the XRDs in the given files and directories (recursively, .yaml, .yml and .json files). Other
+// resources are ignored.
+func LoadXRDs(fs afero.Fs, paths []string) ([]*apiextensionsv1.CompositeResourceDefinition, error) {
+	var xrds []*apiextensionsv1.CompositeResourceDefinition
+
+	err := walkManifests(fs, paths, func(file string) error {
+		resources, err := LoadResources(fs, file)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range resources {
+			gvk := obj.GroupVersionKind()
+			if gvk.Group != xrdGroup || gvk.Kind != "CompositeResourceDefinition" {
+				continue
+			}
+
+			xrd := &apiextensionsv1.CompositeResourceDefinition{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, xrd); err != nil {
+				return fmt.Errorf("failed to decode XRD %s: %w", obj.GetName(), err)
+			}
+
+			xrds = append(xrds, xrd)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return xrds, nil
+}
+
+// walkManifests calls fn for each of the given files and for the manifests in the given directories.
+func walkManifests(fs afero.Fs, paths []string, fn func(file string) error) error {
+	for _, path := range paths {
+		err := afero.Walk(fs, path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() || (file != path && !isManifest(file)) {
+				return nil
+			}
+
+			return fn(file)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isManifest reports whether a file found in a directory holds manifests.
+func isManifest(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// loadFile reads the CRDs and XRDs of a (multi-document) YAML or JSON file.
+func loadFile(fs afero.Fs, file string) ([]*extv1.CustomResourceDefinition, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var crds []*extv1.CustomResourceDefinition
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		gvk := obj.GroupVersionKind()
+
+		switch {
+		case gvk.Group == crdGroup && gvk.Kind == "CustomResourceDefinition":
+			crd := &extv1.CustomResourceDefinition{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+				return nil, fmt.Errorf("failed to decode CRD %s: %w", obj.GetName(), err)
+			}
+
+			crds = append(crds, crd)
+		case gvk.Group == xrdGroup && gvk.Kind == "CompositeResourceDefinition":
+			xrd := &apiextensionsv1.CompositeResourceDefinition{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, xrd); err != nil {
+				return nil, fmt.Errorf("failed to decode XRD %s: %w", obj.GetName(), err)
+			}
+
+			xrdCRDs, err := xrdtocrd.ForXRD(xrd)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert XRD %s: %w", obj.GetName(), err)
+			}
+
+			crds = append(crds, xrdCRDs...)
+		}
+	}
+
+	return crds, nil
+}
+
+// LoadResources reads the resources of a (multi-document) YAML or JSON file.
+func LoadResources(fs afero.Fs, file string) ([]*unstructured.Unstructured, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*unstructured.Unstructured
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to load resources from %s: %w", file, err)
+		}
+
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		resources = append(resources, obj)
+	}
+
+	return resources, nil
+}
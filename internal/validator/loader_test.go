@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const bucketCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: buckets.storage.example.org
+spec:
+  group: storage.example.org
+  names:
+    kind: Bucket
+    plural: buckets
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              required: [region]
+              properties:
+                region:
+                  type: string
+                  enum: [eu-west-1, us-east-1]
+                versioning:
+                  type: boolean
+                  default: false
+                replicas:
+                  type: integer
+              x-kubernetes-validations:
+                - rule: "!has(self.replicas) || self.replicas <= 3"
+                  message: at most 3 replicas
+`
+
+const xbucketXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.org
+spec:
+  group: example.org
+  names:
+    kind: XBucket
+    plural: xbuckets
+  claimNames:
+    kind: BucketClaim
+    plural: bucketclaims
+  versions:
+    - name: v1alpha1
+      served: true
+      referenceable: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                size:
+                  type: string
+`
+
+func TestLoadCRDs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/crds/bucket.yaml", []byte(bucketCRD), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "/crds/nested/definitions.yml", []byte(xbucketXRD+"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: ignored\n"), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "/crds/README.md", []byte("not a manifest"), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "/single.txt", []byte(bucketCRD), 0o600))
+
+	t.Run("directories and files", func(t *testing.T) {
+		crds, err := LoadCRDs(fs, []string{"/crds", "/single.txt"})
+		require.NoError(t, err)
+		require.Len(t, crds, 4)
+
+		assert.Equal(t, "buckets.storage.example.org", crds[0].GetName())
+		assert.Equal(t, "xbuckets.example.org", crds[1].GetName())
+		assert.Equal(t, "bucketclaims.example.org", crds[2].GetName())
+		assert.Equal(t, "buckets.storage.example.org", crds[3].GetName())
+	})
+
+	t.Run("XRDs are converted to the CRDs of their composite resource and claim", func(t *testing.T) {
+		crds, err := LoadCRDs(fs, []string{"/crds/nested"})
+		require.NoError(t, err)
+		require.Len(t, crds, 2)
+
+		crd := crds[0]
+		assert.Equal(t, "example.org", crd.Spec.Group)
+		assert.Equal(t, "XBucket", crd.Spec.Names.Kind)
+		require.Len(t, crd.Spec.Versions, 1)
+		assert.Equal(t, "v1alpha1", crd.Spec.Versions[0].Name)
+		assert.True(t, crd.Spec.Versions[0].Storage)
+
+		props := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties
+		assert.Contains(t, props, "metadata")
+		assert.Equal(t, "string", props["spec"].Properties["size"].Type)
+		assert.Contains(t, props["spec"].Properties, "compositionRef")
+		assert.Contains(t, props["status"].Properties, "conditions")
+
+		claim := crds[1]
+		assert.Equal(t, "BucketClaim", claim.Spec.Names.Kind)
+		assert.Equal(t, "string", claim.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"].Properties["size"].Type)
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "/invalid/crd.yaml", []byte("kind: [unclosed"), 0o600))
+
+		_, err := LoadCRDs(fs, []string{"/invalid"})
+		require.ErrorContains(t, err, "failed to load CRDs from /invalid/crd.yaml")
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		_, err := LoadCRDs(fs, []string{"/missing"})
+		require.Error(t, err)
+	})
+}
+
+func TestLoadXRDs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/crds/bucket.yaml", []byte(bucketCRD), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "/crds/nested/definitions.yml", []byte(xbucketXRD+"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: ignored\n"), 0o600))
+
+	t.Run("only XRDs are loaded", func(t *testing.T) {
+		xrds, err := LoadXRDs(fs, []string{"/crds"})
+		require.NoError(t, err)
+		require.Len(t, xrds, 1)
+
+		assert.Equal(t, "xbuckets.example.org", xrds[0].GetName())
+		assert.Equal(t, "BucketClaim", xrds[0].Spec.ClaimNames.Kind)
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "/invalid/xrd.yaml", []byte("kind: [unclosed"), 0o600))
+
+		_, err := LoadXRDs(fs, []string{"/invalid"})
+		require.ErrorContains(t, err, "failed to load resources from /invalid/xrd.yaml")
+	})
+}
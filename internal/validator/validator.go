@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validator validates resources against the OpenAPI schemas of their CRDs in-process,
+// like crossplane beta validate does, without running crossplane.
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+)
+
+// Validator validates resources against the schemas of a set of CRDs.
+type Validator struct {
+	schemas map[schema.GroupVersionKind]*versionSchema
+}
+
+// versionSchema is the schema of a version of a CRD, prepared for validation.
+type versionSchema struct {
+	structural *structuralschema.Structural
+	validator  validation.SchemaValidator
+	cel        *cel.Validator // nil when the schema has no x-kubernetes-validations
+}
+
+// New returns a Validator for the resources defined by crds.
+func New(crds []*extv1.CustomResourceDefinition) (*Validator, error) {
+	v := &Validator{schemas: make(map[schema.GroupVersionKind]*versionSchema)}
+
+	for _, crd := range crds {
+		internal := &apiextensions.CustomResourceDefinition{}
+		if err := extv1.Convert_v1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(crd, internal, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert CRD %s: %w", crd.GetName(), err)
+		}
+
+		for _, version := range internal.Spec.Versions {
+			// Top-level and per-version schemas are mutually exclusive
+			props := internal.Spec.Validation
+			if version.Schema != nil {
+				props = version.Schema
+			}
+
+			if props == nil || props.OpenAPIV3Schema == nil {
+				continue
+			}
+
+			structural, err := structuralschema.NewStructural(props.OpenAPIV3Schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create structural schema of CRD %s version %s: %w", crd.GetName(), version.Name, err)
+			}
+
+			sv, _, err := validation.NewSchemaValidator(props.OpenAPIV3Schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create schema validator of CRD %s version %s: %w", crd.GetName(), version.Name, err)
+			}
+
+			gvk := schema.GroupVersionKind{Group: internal.Spec.Group, Version: version.Name, Kind: internal.Spec.Names.Kind}
+			v.schemas[gvk] = &versionSchema{
+				structural: structural,
+				validator:  sv,
+				cel:        cel.NewValidator(structural, true, celconfig.PerCallLimit),
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// Validate validates each resource against the schema of its CRD, after applying the defaults of the schema
+// to a copy of it. Unknown fields and x-kubernetes-validations rules are validated too.
+func (v *Validator) Validate(ctx context.Context, resources []*unstructured.Unstructured) []engine.ValidateResult {
+	return v.validate(ctx, resources, false)
+}
+
+// ValidateInputs validates the input resources of a test case (an XR or a Claim) like Validate, except that unknown
+// fields are reported as warnings: the API server prunes them instead of rejecting the resource.
+func (v *Validator) ValidateInputs(ctx context.Context, resources []*unstructured.Unstructured) []engine.ValidateResult {
+	return v.validate(ctx, resources, true)
+}
+
+// validate validates each resource against the schema of its CRD, reporting unknown fields as warnings when
+// pruneUnknownFields is set and as errors otherwise.
+func (v *Validator) validate(ctx context.Context, resources []*unstructured.Unstructured, pruneUnknownFields bool) []engine.ValidateResult {
+	results := make([]engine.ValidateResult, 0, len(resources))
+
+	for _, resource := range resources {
+		gvk := resource.GroupVersionKind()
+		result := engine.ValidateResult{GroupVersionKind: gvk.String(), Name: resourceName(resource)}
+
+		s, ok := v.schemas[gvk]
+		if !ok {
+			result.MissingSchema = true
+			results = append(results, result)
+
+			continue
+		}
+
+		obj := resource.DeepCopy().UnstructuredContent()
+		structuraldefaulting.Default(obj, s.structural)
+
+		errs := validation.ValidateCustomResource(nil, obj, s.validator)
+		if pruneUnknownFields {
+			result.Warnings = append(result.Warnings, prunedFields(obj, s.structural)...)
+		} else {
+			errs = append(errs, unknownFields(obj, s.structural)...)
+		}
+
+		result.Errors = append(result.Errors, validateErrors(engine.ValidateErrorSchema, errs)...)
+
+		errs, _ = s.cel.Validate(ctx, nil, s.structural, obj, nil, celconfig.RuntimeCELCostBudget)
+		result.Errors = append(result.Errors, validateErrors(engine.ValidateErrorCEL, errs)...)
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// unknownFields returns an error for each field of obj that is not in the schema.
+func unknownFields(obj map[string]any, s *structuralschema.Structural) field.ErrorList {
+	var errs field.ErrorList
+
+	for _, path := range unknownFieldPaths(obj, s) {
+		name := path[strings.LastIndex(path, ".")+1:]
+		errs = append(errs, field.Invalid(field.NewPath(path), name, fmt.Sprintf("unknown field: \"%s\"", name)))
+	}
+
+	return errs
+}
+
+// prunedFields returns a pruning warning for each field of obj that is not in the schema.
+func prunedFields(obj map[string]any, s *structuralschema.Structural) []engine.ValidateError {
+	var warnings []engine.ValidateError
+
+	for _, path := range unknownFieldPaths(obj, s) {
+		name := path[strings.LastIndex(path, ".")+1:]
+		warnings = append(warnings, engine.ValidateError{
+			Type:    engine.ValidateWarningPruning,
+			Field:   path,
+			Message: fmt.Sprintf("unknown field \"%s\" would be pruned", name),
+		})
+	}
+
+	return warnings
+}
+
+// unknownFieldPaths returns the paths of the fields of obj that are not in the schema.
+func unknownFieldPaths(obj map[string]any, s *structuralschema.Structural) []string {
+	// Pruning removes the unknown fields, so prune a copy
+	pruned := runtime.DeepCopyJSON(obj)
+
+	return pruning.PruneWithOptions(pruned, s, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+}
+
+// validateErrors converts field errors to validation errors of the given type.
+func validateErrors(errType string, errs field.ErrorList) []engine.ValidateError {
+	result := make([]engine.ValidateError, 0, len(errs))
+
+	for _, err := range errs {
+		result = append(result, engine.ValidateError{Type: errType, Field: err.Field, Message: err.ErrorBody()})
+	}
+
+	return result
+}
+
+// resourceName returns the name of a resource or, for composed resources without a name, their composition resource name.
+func resourceName(resource *unstructured.Unstructured) string {
+	if name := resource.GetName(); name != "" {
+		return name
+	}
+
+	return resource.GetAnnotations()["crossplane.io/composition-resource-name"]
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/crds/bucket.yaml", []byte(bucketCRD+"---\n"+xbucketXRD), 0o600))
+
+	crds, err := LoadCRDs(fs, []string{"/crds"})
+	require.NoError(t, err)
+
+	v, err := New(crds)
+	require.NoError(t, err)
+
+	return v
+}
+
+func bucket(name string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "storage.example.org/v1",
+		"kind":       "Bucket",
+		"metadata":   map[string]any{"name": name},
+		"spec":       spec,
+	}}
+}
+
+func TestValidate(t *testing.T) {
+	v := newTestValidator(t)
+
+	const bucketGVK = "storage.example.org/v1, Kind=Bucket"
+
+	tests := []struct {
+		name     string
+		resource *unstructured.Unstructured
+		want     engine.ValidateResult
+	}{
+		{
+			name:     "valid resource",
+			resource: bucket("valid", map[string]any{"region": "eu-west-1"}),
+			want:     engine.ValidateResult{GroupVersionKind: bucketGVK, Name: "valid"},
+		},
+		{
+			name:     "required field",
+			resource: bucket("required", map[string]any{}),
+			want: engine.ValidateResult{GroupVersionKind: bucketGVK, Name: "required", Errors: []engine.ValidateError{
+				{Type: engine.ValidateErrorSchema, Field: "spec.region", Message: "Required value"},
+			}},
+		},
+		{
+			name:     "enum and unknown field",
+			resource: bucket("enum", map[string]any{"region": "mars-1", "regoin": "eu-west-1"}),
+			want: engine.ValidateResult{GroupVersionKind: bucketGVK, Name: "enum", Errors: []engine.ValidateError{
+				{Type: engine.ValidateErrorSchema, Field: "spec.region", Message: `Unsupported value: "mars-1": supported values: "eu-west-1", "us-east-1"`},
+				{Type: engine.ValidateErrorSchema, Field: "spec.regoin", Message: `Invalid value: "regoin": unknown field: "regoin"`},
+			}},
+		},
+		{
+			name:     "CEL rule",
+			resource: bucket("cel", map[string]any{"region": "eu-west-1", "replicas": int64(5)}),
+			want: engine.ValidateResult{GroupVersionKind: bucketGVK, Name: "cel", Errors: []engine.ValidateError{
+				{Type: engine.ValidateErrorCEL, Field: "spec", Message: `Invalid value: "object": at most 3 replicas`},
+			}},
+		},
+		{
+			name: "resource from an XRD",
+			resource: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.org/v1alpha1",
+				"kind":       "XBucket",
+				"metadata":   map[string]any{"name": "xbucket"},
+				"spec":       map[string]any{"size": "large"},
+			}},
+			want: engine.ValidateResult{GroupVersionKind: "example.org/v1alpha1, Kind=XBucket", Name: "xbucket"},
+		},
+		{
+			name: "Crossplane injected fields of an XR",
+			resource: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.org/v1alpha1",
+				"kind":       "XBucket",
+				"metadata":   map[string]any{"name": "xbucket"},
+				"spec": map[string]any{
+					"size":           "large",
+					"compositionRef": map[string]any{"name": "xbuckets"},
+					"resourceRefs":   []any{map[string]any{"apiVersion": "storage.example.org/v1", "kind": "Bucket", "name": "bucket"}},
+				},
+				"status": map[string]any{
+					"conditions": []any{map[string]any{"type": "Ready", "status": "True", "reason": "Available", "lastTransitionTime": "2026-01-01T00:00:00Z"}},
+				},
+			}},
+			want: engine.ValidateResult{GroupVersionKind: "example.org/v1alpha1, Kind=XBucket", Name: "xbucket"},
+		},
+		{
+			name: "claim from an XRD",
+			resource: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.org/v1alpha1",
+				"kind":       "BucketClaim",
+				"metadata":   map[string]any{"name": "claim", "namespace": "default"},
+				"spec":       map[string]any{"size": "large", "compositeDeletePolicy": "Foreground"},
+			}},
+			want: engine.ValidateResult{GroupVersionKind: "example.org/v1alpha1, Kind=BucketClaim", Name: "claim"},
+		},
+		{
+			name: "missing schema",
+			resource: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"annotations": map[string]any{"crossplane.io/composition-resource-name": "config"},
+				},
+			}},
+			want: engine.ValidateResult{GroupVersionKind: "/v1, Kind=ConfigMap", Name: "config", MissingSchema: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := v.Validate(t.Context(), []*unstructured.Unstructured{tt.resource})
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.want, results[0])
+		})
+	}
+}
+
+func TestValidate_DefaultsDoNotChangeResources(t *testing.T) {
+	v := newTestValidator(t)
+	resource := bucket("defaults", map[string]any{"region": "eu-west-1", "unknown": true})
+
+	results := v.Validate(t.Context(), []*unstructured.Unstructured{resource})
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Errors, 1)
+
+	assert.Equal(t, map[string]any{"region": "eu-west-1", "unknown": true}, resource.Object["spec"])
+}
+
+func TestValidateInputs(t *testing.T) {
+	v := newTestValidator(t)
+
+	results := v.ValidateInputs(t.Context(), []*unstructured.Unstructured{
+		bucket("typo", map[string]any{"regoin": "eu-west-1"}),
+	})
+	require.Len(t, results, 1)
+
+	assert.Equal(t, []engine.ValidateError{
+		{Type: engine.ValidateErrorSchema, Field: "spec.region", Message: "Required value"},
+	}, results[0].Errors)
+	assert.Equal(t, []engine.ValidateError{
+		{Type: engine.ValidateWarningPruning, Field: "spec.regoin", Message: `unknown field "regoin" would be pruned`},
+	}, results[0].Warnings)
+}